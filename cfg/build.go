@@ -0,0 +1,6 @@
+//go:build !production
+
+package config
+
+// secretsFromEnvOnly is true in builds with -tags production, there jwt_secret can't come from the config file.
+const secretsFromEnvOnly = false
@@ -0,0 +1,5 @@
+//go:build production
+
+package config
+
+const secretsFromEnvOnly = true
@@ -2,12 +2,17 @@ package config
 
 import (
 	"fmt"
-	"sync"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
 
 type DBConfig struct {
+	// ConnString is a postgres:// url or key=value DSN, takes precedence over the fields below.
 	ConnString string `toml:"conn_string"`
 	Host       string `toml:"host"`
 	Port       int    `toml:"port"`
@@ -15,82 +20,234 @@ type DBConfig struct {
 	Password   string `toml:"password"`
 	Database   string `toml:"database"`
 	SSLMode    string `toml:"sslmode"`
-}
 
-type AppConfig struct {
-	APIKeys  map[string]string `toml:"api_keys"`
-	Database DBConfig          `toml:"database"`
-	Server   struct {
-		Host    string `toml:"host"`
-		Port    int    `toml:"port"`
-		IsDevel bool   `toml:"is_devel"`
-	} `toml:"server"`
-
-	AuthConfig struct {
-		JWTSecret       string `toml:"jwt_secret"`
-		JWTAccessExpiry int    `toml:"jwt_access_expiry"`
-	} `toml:"auth"`
+	MaxConns          int32 `toml:"max_conns"` // 0 - pgxpool default (max(4, NumCPU))
+	MinConns          int32 `toml:"min_conns"`
+	ConnectTimeout    int   `toml:"connect_timeout"`     // in seconds
+	MaxConnLifetime   int   `toml:"max_conn_lifetime"`   // in minutes
+	MaxConnIdleTime   int   `toml:"max_conn_idle_time"`  // in minutes
+	HealthCheckPeriod int   `toml:"health_check_period"` // in seconds
 }
 
-type Config struct {
-	APIKeys map[string]string
+// DSN returns ConnString or a postgres:// url built from the separate fields.
+func (d DBConfig) DSN() string {
+	if d.ConnString != "" {
+		return d.ConnString
+	}
 
-	Database DBConfig
-	Server   struct {
-		Host    string
-		Port    int
-		IsDevel bool
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     d.Host + ":" + strconv.Itoa(d.Port),
+		Path:     "/" + d.Database,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
 	}
-	AuthConfig struct {
-		JWTSecret       string
-		JWTAccessExpiry int // in hours
+	return u.String()
+}
+
+type ServerConfig struct {
+	Host    string `toml:"host"`
+	Port    int    `toml:"port"`
+	IsDevel bool   `toml:"is_devel"`
+
+	// ShutdownTimeout in seconds: how long in-flight requests, bot and workers are drained on SIGTERM
+	ShutdownTimeout int `toml:"shutdown_timeout"`
+	ReadTimeout     int `toml:"read_timeout"`    // in seconds
+	WriteTimeout    int `toml:"write_timeout"`   // in seconds
+	IdleTimeout     int `toml:"idle_timeout"`    // in seconds, keep-alive connections
+	RequestTimeout  int `toml:"request_timeout"` // in seconds, deadline of request context
+
+	CORSOrigins []string `toml:"cors_origins"`
+
+	// TrustedProxies are CIDRs of reverse proxies whose X-Forwarded-For is trusted for the client IP
+	// (rate limits, logs). Empty - the API is exposed directly and the peer address is the client IP.
+	TrustedProxies []string `toml:"trusted_proxies"`
+
+	// refresh_token cookie. SameSite=None is allowed by browsers only for Secure cookies.
+	CookieSecure   bool   `toml:"cookie_secure"`
+	CookieSameSite string `toml:"cookie_same_site"` // none | lax | strict
+}
+
+// SameSite returns CookieSameSite as http.SameSite, none by default.
+func (s ServerConfig) SameSite() http.SameSite {
+	switch strings.ToLower(s.CookieSameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "strict":
+		return http.SameSiteStrictMode
+	default:
+		return http.SameSiteNoneMode
 	}
 }
 
-var (
-	appConfig *AppConfig
-	loadOnce  sync.Once
-	errLoad   error
-)
+type AuthConfig struct {
+	JWTSecret       string `toml:"jwt_secret"`
+	JWTAccessExpiry int    `toml:"jwt_access_expiry"` // in hours
+
+	// SigningAlg is one of HS256 (shared jwt_secret), RS256 or EdDSA (rotated key pairs, published via JWKS).
+	SigningAlg  string `toml:"signing_alg"`
+	KeyRotation int    `toml:"key_rotation"` // in hours, how long a key pair is used for signing
+	KeyOverlap  int    `toml:"key_overlap"`  // in hours, how long a rotated key is still accepted
+
+	// AcceptLegacyHS256 keeps accepting HS256 tokens signed with jwt_secret after switching signing_alg
+	// to RS256/EdDSA until LegacyHS256Until, so users are not logged out at once. Ignored for HS256.
+	AcceptLegacyHS256 bool   `toml:"accept_legacy_hs256"`
+	LegacyHS256Until  string `toml:"legacy_hs256_until"` // RFC 3339, required with accept_legacy_hs256
+
+	InitDataMaxAge int `toml:"init_data_max_age"` // in seconds, max age of MAX WebApp auth_date
+
+	// DevLogin enables POST /auth/dev/login and -dev-token flag. Works only with server.is_devel
+	// and is refused by production builds.
+	DevLogin  bool  `toml:"dev_login"`
+	DevUserID int64 `toml:"dev_user_id"` // fixture user for dev login, created if missing
+
+	ImpersonationExpiry int `toml:"impersonation_expiry"` // in minutes, lifetime of admin "view as user" tokens
+}
+
+// RateQuota allows Requests per Period seconds on average with bursts up to Burst requests.
+type RateQuota struct {
+	Requests int `toml:"requests"`
+	Period   int `toml:"period"` // in seconds
+	Burst    int `toml:"burst"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Store   string `toml:"store"` // memory | postgres
+
+	Public RateQuota `toml:"public"` // per IP on public routes
+	User   RateQuota `toml:"user"`   // per user on protected routes
+
+	// Routes overrides quota for "METHOD /route/path", e.g. "POST /auth/login".
+	Routes map[string]RateQuota `toml:"routes"`
+}
+
+// TracingConfig configures export of OpenTelemetry spans over OTLP/HTTP, disabled by default.
+type TracingConfig struct {
+	Enabled     bool    `toml:"enabled"`
+	Endpoint    string  `toml:"endpoint"`     // host:port of OTLP/HTTP collector, default localhost:4318
+	Insecure    bool    `toml:"insecure"`     // plain HTTP instead of HTTPS
+	SampleRatio float64 `toml:"sample_ratio"` // share of traces started here that are sampled, default 1
+}
+
+type Config struct {
+	APIKeys map[string]string `toml:"api_keys"`
 
+	Database   DBConfig        `toml:"database"`
+	Server     ServerConfig    `toml:"server"`
+	AuthConfig AuthConfig      `toml:"auth"`
+	RateLimit  RateLimitConfig `toml:"rate_limit"`
+	Tracing    TracingConfig   `toml:"tracing"`
+}
+
+// Default returns config used for everything that is not set by file, env or flags.
+func Default() Config {
+	return Config{
+		APIKeys: map[string]string{},
+		Database: DBConfig{
+			Host:              "localhost",
+			Port:              5432,
+			SSLMode:           "disable",
+			ConnectTimeout:    5,
+			MaxConnLifetime:   60,
+			MaxConnIdleTime:   30,
+			HealthCheckPeriod: 60,
+		},
+		Server: ServerConfig{
+			Host:            "0.0.0.0",
+			Port:            8080,
+			ShutdownTimeout: 20,
+			ReadTimeout:     15,
+			WriteTimeout:    15,
+			IdleTimeout:     120,
+			RequestTimeout:  120,
+			CORSOrigins: []string{
+				"https://hackaton-max.vercel.app",
+				"https://msokovykh.ru",
+				"https://www.msokovykh.ru",
+			},
+			CookieSecure:   true,
+			CookieSameSite: "none",
+		},
+		AuthConfig: AuthConfig{
+			JWTAccessExpiry:     24,
+			SigningAlg:          "HS256",
+			KeyRotation:         24 * 30,
+			InitDataMaxAge:      3600,
+			ImpersonationExpiry: 15,
+		},
+		RateLimit: RateLimitConfig{
+			Store:  "memory",
+			Public: RateQuota{Requests: 60, Period: 60, Burst: 20},
+			User:   RateQuota{Requests: 300, Period: 60, Burst: 60},
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load builds config in layers: Default, then file at path (skipped if path is empty),
+// then UNI_BOT_* environment variables (see applyEnv). Flags are applied by the caller,
+// after that the config has to be checked with Validate.
 func Load(path string) (Config, error) {
-	loadOnce.Do(func() {
-		var cfg AppConfig
-		if _, errLoad = toml.DecodeFile(path, &cfg); errLoad != nil {
-			errLoad = fmt.Errorf("failed to load config: %w", errLoad)
-			return
+	cfg := Default()
+
+	if path != "" {
+		if _, err := toml.DecodeFile(path, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to load config %s: %w", path, err)
 		}
-		appConfig = &cfg
-	})
+	}
 
-	if errLoad != nil {
-		return Config{}, errLoad
+	if secretsFromEnvOnly && cfg.AuthConfig.JWTSecret != "" {
+		return Config{}, fmt.Errorf("auth.jwt_secret can't be set in %s in production builds, use env %sAUTH_JWT_SECRET", path, EnvPrefix)
 	}
 
-	cfg := Config{
-		APIKeys:  appConfig.APIKeys,
-		Database: appConfig.Database,
-		Server: struct {
-			Host    string
-			Port    int
-			IsDevel bool
-		}{
-			Host:    appConfig.Server.Host,
-			Port:    appConfig.Server.Port,
-			IsDevel: appConfig.Server.IsDevel,
-		},
-		AuthConfig: struct {
-			JWTSecret       string
-			JWTAccessExpiry int
-		}{
-			JWTSecret:       appConfig.AuthConfig.JWTSecret,
-			JWTAccessExpiry: appConfig.AuthConfig.JWTAccessExpiry,
-		},
+	if err := applyEnv(&cfg, EnvPrefix); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.AuthConfig.KeyOverlap <= 0 {
+		cfg.AuthConfig.KeyOverlap = cfg.AuthConfig.JWTAccessExpiry
+	}
+	for route, quota := range cfg.RateLimit.Routes {
+		cfg.RateLimit.Routes[route] = quota.withDefaults(cfg.RateLimit.Public)
 	}
 
 	return cfg, nil
 }
 
-func GetAppConfig() *AppConfig {
-	return appConfig
+// LoadDatabase loads only the database section in the same layers as Load,
+// so the migrate command doesn't need the rest of the config and its secrets.
+func LoadDatabase(path string) (DBConfig, error) {
+	cfg := struct {
+		Database DBConfig `toml:"database"`
+	}{Database: Default().Database}
+
+	if path != "" {
+		// other sections are left undecoded
+		if _, err := toml.DecodeFile(path, &cfg); err != nil {
+			return DBConfig{}, fmt.Errorf("failed to load config %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnvStruct(reflect.ValueOf(&cfg.Database).Elem(), EnvPrefix+"DATABASE_"); err != nil {
+		return DBConfig{}, err
+	}
+
+	return cfg.Database, nil
+}
+
+func (q RateQuota) withDefaults(d RateQuota) RateQuota {
+	if q.Requests <= 0 {
+		q.Requests = d.Requests
+	}
+	if q.Period <= 0 {
+		q.Period = d.Period
+	}
+	if q.Burst <= 0 {
+		q.Burst = q.Requests
+	}
+	return q
 }
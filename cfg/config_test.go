@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateRejectsPlaceholderSecret(t *testing.T) {
+	cfg := Default()
+	cfg.Database.ConnString = "postgres://app@db/app"
+	cfg.AuthConfig.JWTSecret = "your-very-secure-secret-key-here"
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "placeholder") {
+		t.Fatalf("Validate() = %v, want placeholder error", err)
+	}
+
+	cfg.AuthConfig.JWTSecret = strings.Repeat("k", minJWTSecretLen)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateRejectsShortKeyOverlap(t *testing.T) {
+	cfg := Default()
+	cfg.Database.ConnString = "postgres://app@db/app"
+	cfg.AuthConfig.SigningAlg = "RS256"
+	cfg.AuthConfig.JWTAccessExpiry = 24
+	cfg.AuthConfig.KeyOverlap = 1
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "auth.key_overlap") {
+		t.Fatalf("Validate() = %v, want key_overlap error", err)
+	}
+
+	cfg.AuthConfig.KeyOverlap = 24
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestLoadDatabaseIgnoresOtherSections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	const file = `
+[database]
+host = "db"
+user = "app"
+database = "app"
+
+[auth]
+jwt_secret = "your-very-secure-secret-key-here"
+signing_alg = "unknown"
+`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvPrefix+"DATABASE_PASSWORD", "from-env")
+
+	db, err := LoadDatabase(path)
+	if err != nil {
+		t.Fatalf("LoadDatabase() = %v", err)
+	}
+	if err := db.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	if db.Host != "db" || db.Port != 5432 || db.Password != "from-env" {
+		t.Errorf("LoadDatabase() = %+v", db)
+	}
+}
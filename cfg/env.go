@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix of environment variables overriding the config file.
+const EnvPrefix = "UNI_BOT_"
+
+// applyEnv overrides config values from environment variables named by toml keys:
+// UNI_BOT_DATABASE_PASSWORD sets database.password, UNI_BOT_AUTH_JWT_SECRET sets auth.jwt_secret,
+// UNI_BOT_API_KEYS_MAX_BOT sets api_keys.max_bot. Lists are comma separated.
+// Empty variables are ignored, so docker compose can pass optional ones through.
+func applyEnv(cfg *Config, prefix string) error {
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), prefix)
+}
+
+func applyEnvStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("toml")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(key)
+		field := v.Field(i)
+
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := applyEnvStruct(field, name+"_"); err != nil {
+				return err
+			}
+		case reflect.Map:
+			if field.Type().Elem().Kind() != reflect.String {
+				// maps of sections (rate_limit.routes) are configured by file only
+				continue
+			}
+			applyEnvMap(field, name+"_")
+		default:
+			value, ok := os.LookupEnv(name)
+			if !ok || value == "" {
+				continue
+			}
+			if err := setValue(field, value); err != nil {
+				return fmt.Errorf("bad value of %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// applyEnvMap sets map[string]string entries from all variables starting with prefix,
+// the rest of the name in lower case is the key.
+func applyEnvMap(field reflect.Value, prefix string) {
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, prefix) || value == "" {
+			continue
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		key := strings.ToLower(strings.TrimPrefix(name, prefix))
+		field.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+	}
+}
+
+func setValue(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxLegacyHS256 limits how long HS256 tokens are accepted after switching to RS256/EdDSA.
+const maxLegacyHS256 = 30 * 24 * time.Hour
+
+// minJWTSecretLen is required outside of development: HS256 with a short secret is brute-forceable.
+const minJWTSecretLen = 32
+
+// placeholderSecrets are jwt_secret values from example configs and docs, they are public.
+var placeholderSecrets = []string{
+	"your-very-secure-secret-key-here",
+	"your-secret-key",
+	"changeme",
+	"secret",
+}
+
+// Validate checks the whole config and reports every problem at once, so the app fails on start
+// instead of on the first request that needs a broken value.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if err := c.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	s := c.Server
+	check(validPort(s.Port), "server.port must be in 1..65535, got %d", s.Port)
+	check(s.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(s.ReadTimeout > 0, "server.read_timeout must be positive")
+	check(s.WriteTimeout > 0, "server.write_timeout must be positive")
+	check(s.IdleTimeout > 0, "server.idle_timeout must be positive")
+	check(s.RequestTimeout > 0, "server.request_timeout must be positive")
+	for _, origin := range s.CORSOrigins {
+		u, err := url.Parse(origin)
+		check(origin == "*" || (err == nil && u.Scheme != "" && u.Host != ""), "server.cors_origins: %q is not an origin like https://example.com", origin)
+	}
+	for _, proxy := range s.TrustedProxies {
+		_, _, err := net.ParseCIDR(proxy)
+		check(err == nil, "server.trusted_proxies: %q is not a CIDR like 10.0.0.0/8", proxy)
+	}
+	check(slices.Contains([]string{"none", "lax", "strict"}, strings.ToLower(s.CookieSameSite)),
+		"server.cookie_same_site must be none, lax or strict, got %q", s.CookieSameSite)
+	check(!strings.EqualFold(s.CookieSameSite, "none") || s.CookieSecure,
+		"server.cookie_same_site = none requires server.cookie_secure, browsers drop such cookies")
+
+	a := c.AuthConfig
+	check(!slices.Contains(placeholderSecrets, strings.ToLower(a.JWTSecret)),
+		"auth.jwt_secret is a placeholder from the example config, generate a random one (env %sAUTH_JWT_SECRET)", EnvPrefix)
+	check(a.JWTAccessExpiry > 0, "auth.jwt_access_expiry must be positive")
+	check(slices.Contains([]string{"HS256", "RS256", "EdDSA"}, a.SigningAlg), "auth.signing_alg must be HS256, RS256 or EdDSA, got %q", a.SigningAlg)
+	if a.SigningAlg == "HS256" {
+		check(a.JWTSecret != "", "auth.jwt_secret is required for HS256 (env %sAUTH_JWT_SECRET)", EnvPrefix)
+		check(s.IsDevel || a.JWTSecret == "" || len(a.JWTSecret) >= minJWTSecretLen,
+			"auth.jwt_secret must be at least %d bytes outside of development", minJWTSecretLen)
+	} else {
+		check(a.KeyRotation > 0, "auth.key_rotation must be positive")
+		// a token outlives the key that signed it by up to jwt_access_expiry, 0 is filled with it by Load
+		check(a.KeyOverlap == 0 || a.KeyOverlap >= a.JWTAccessExpiry,
+			"auth.key_overlap (%d) must not be less than auth.jwt_access_expiry (%d), tokens signed with a rotated key would be rejected before they expire",
+			a.KeyOverlap, a.JWTAccessExpiry)
+		if a.AcceptLegacyHS256 {
+			check(a.JWTSecret != "", "auth.jwt_secret is required with auth.accept_legacy_hs256")
+			until, err := time.Parse(time.RFC3339, a.LegacyHS256Until)
+			check(err == nil, "auth.legacy_hs256_until must be RFC 3339 time like 2026-11-01T00:00:00Z, got %q", a.LegacyHS256Until)
+			// legacy tokens are short-lived, there is no reason to trust the shared secret for long
+			check(err != nil || until.Before(time.Now().Add(maxLegacyHS256)),
+				"auth.legacy_hs256_until must be within %s from now", maxLegacyHS256)
+		}
+	}
+	check(a.InitDataMaxAge > 0, "auth.init_data_max_age must be positive")
+	check(a.ImpersonationExpiry > 0, "auth.impersonation_expiry must be positive")
+
+	rl := c.RateLimit
+	check(rl.Store == "memory" || rl.Store == "postgres", "rate_limit.store must be memory or postgres, got %q", rl.Store)
+	if rl.Enabled {
+		check(rl.Public.valid(), "rate_limit.public: requests, period and burst must be positive")
+		check(rl.User.valid(), "rate_limit.user: requests, period and burst must be positive")
+		for route, quota := range rl.Routes {
+			check(quota.valid(), "rate_limit.routes.%q: requests, period and burst must be positive", route)
+		}
+	}
+
+	if c.Tracing.Enabled {
+		check(c.Tracing.Endpoint != "", "tracing.endpoint is required")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be in 0..1, got %v", c.Tracing.SampleRatio)
+
+	return errors.Join(errs...)
+}
+
+// Validate checks the database section, it is all the migrate command needs.
+func (d DBConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if d.ConnString == "" {
+		check(d.Host != "", "database.host is required (or database.conn_string)")
+		check(d.User != "", "database.user is required (or database.conn_string)")
+		check(d.Database != "", "database.database is required (or database.conn_string)")
+		check(validPort(d.Port), "database.port must be in 1..65535, got %d", d.Port)
+		check(slices.Contains([]string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}, d.SSLMode),
+			"database.sslmode %q is unknown", d.SSLMode)
+	}
+	check(d.MaxConns >= 0 && d.MinConns >= 0, "database.max_conns and database.min_conns can't be negative")
+	check(d.MaxConns == 0 || d.MinConns <= d.MaxConns, "database.min_conns (%d) is greater than database.max_conns (%d)", d.MinConns, d.MaxConns)
+	check(d.ConnectTimeout > 0, "database.connect_timeout must be positive")
+	check(d.MaxConnLifetime > 0, "database.max_conn_lifetime must be positive")
+	check(d.MaxConnIdleTime > 0, "database.max_conn_idle_time must be positive")
+	check(d.HealthCheckPeriod > 0, "database.health_check_period must be positive")
+	return errors.Join(errs...)
+}
+
+func (q RateQuota) valid() bool {
+	return q.Requests > 0 && q.Period > 0 && q.Burst > 0
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
@@ -18,6 +18,8 @@ import (
 	config "github.com/max-main-team/backend_hackaton_MAX/cfg"
 	_ "github.com/max-main-team/backend_hackaton_MAX/docs"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/app"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
 	"github.com/vmkteam/embedlog"
 )
 
@@ -25,6 +27,10 @@ var (
 	flVerbose = flag.Bool("verbose", false, "print verbose output")
 	flJSON    = flag.Bool("json", false, "print output as JSON")
 	flDev     = flag.Bool("dev", true, "uses development mode")
+	flDevTok  = flag.Int64("dev-token", -1, "print access token for max_users_data.id (0 - fixture user) and exit, requires auth.dev_login")
+	flConfig  = flag.String("config", "cfg/config.toml", "path to config file, empty - defaults only. "+config.EnvPrefix+"* env vars override it")
+	flHost    = flag.String("host", "", "overrides server.host")
+	flPort    = flag.Int("port", 0, "overrides server.port")
 )
 
 const (
@@ -32,80 +38,134 @@ const (
 )
 
 func main() {
+	// os.Exit не выполняет defer, поэтому приложение работает в run: пул и трейсинг закрываются до выхода
+	if err := run(); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+func run() error {
 	flag.Parse()
 	ctx := context.Background()
 
-	sl := embedlog.NewLogger(*flVerbose, *flJSON)
+	base := embedlog.NewLogger(*flVerbose, *flJSON)
 	if *flDev {
-		sl = embedlog.NewDevLogger()
+		base = embedlog.NewDevLogger()
 	}
+	// все логи проходят через редактирование токенов, хэшей init data и cookie
+	sl := logging.New(base)
 	slog.SetDefault(sl.Log())
 
-	cfg, err := config.Load("cfg/config.toml")
-	if err != nil {
-		sl.Errorf("Failed to load config. error: %v", err)
-		exitOnError(err)
-	}
+	// migrate не нужны секреты и остальные секции конфига
+	if flag.Arg(0) == "migrate" {
+		dbCfg, err := config.LoadDatabase(*flConfig)
+		if err != nil {
+			return err
+		}
+		if err := dbCfg.Validate(); err != nil {
+			return fmt.Errorf("invalid config:\n%w", err)
+		}
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Database,
-		cfg.Database.SSLMode,
-	)
+		pool, err := connectDB(ctx, dbCfg, sl)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		return runMigrate(ctx, pool, sl, flag.Args()[1:])
+	}
 
-	poolCfg, err := pgxpool.ParseConfig(dsn)
+	// слои конфига: значения по умолчанию -> файл -> переменные окружения UNI_BOT_* -> флаги
+	cfg, err := config.Load(*flConfig)
 	if err != nil {
-		sl.Errorf("failed to parse pgx config: %v", err)
-		exitOnError(err)
+		return err
+	}
+	if *flHost != "" {
+		cfg.Server.Host = *flHost
+	}
+	if *flPort != 0 {
+		cfg.Server.Port = *flPort
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
 	}
 
-	// Настройка таймаутов для базы данных
-	poolCfg.ConnConfig.ConnectTimeout = 5 * time.Second // Таймаут подключения
-	poolCfg.MaxConnLifetime = 1 * time.Hour
-	poolCfg.MaxConnIdleTime = 30 * time.Minute
-	poolCfg.HealthCheckPeriod = 1 * time.Minute
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, appName)
+	if err != nil {
+		return fmt.Errorf("failed to setup tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			sl.Errorf("failed to flush traces: %v", err)
+		}
+	}()
 
-	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	pool, err := connectDB(ctx, cfg.Database, sl)
 	if err != nil {
-		sl.Errorf("failed to create pgx pool: %v", err)
-		exitOnError(err)
+		return err
 	}
 	defer pool.Close()
 
-	if err := pool.Ping(ctx); err != nil {
-		sl.Errorf("db ping failed: %v", err)
-		exitOnError(err)
+	application, err := app.New(appName, sl, cfg, pool)
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
 	}
 
-	var version string
-	if err := pool.QueryRow(ctx, "select version()").Scan(&version); err != nil {
-		sl.Errorf("failed to get version: %v", err)
-		exitOnError(err)
+	if *flDevTok >= 0 {
+		token, err := application.DevToken(ctx, *flDevTok)
+		if err != nil {
+			return fmt.Errorf("failed to issue dev token: %w", err)
+		}
+		fmt.Println(token)
+		return nil
 	}
-	sl.Print(ctx, "connected to db", "version", version)
 
-	application := app.New(appName, sl, cfg, pool)
+	// SIGTERM/SIGINT отменяют ctx: HTTP сервер, бот и воркеры завершаются штатно
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-
-	// Run
-	go func() {
-		if err := application.Run(ctx); err != nil {
-			exitOnError(err)
-		}
-	}()
-	<-quit
+	if err := application.Run(runCtx); err != nil {
+		return fmt.Errorf("application stopped with error: %w", err)
+	}
 
 	sl.Print(ctx, "Application finished")
+	return nil
 }
 
-func exitOnError(err error) {
+// connectDB creates the pool and checks the connection.
+func connectDB(ctx context.Context, dbCfg config.DBConfig, sl logging.Logger) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dbCfg.DSN())
 	if err != nil {
-		slog.Error(err.Error())
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to parse pgx config: %w", err)
+	}
+
+	// Настройка таймаутов для базы данных
+	poolCfg.ConnConfig.ConnectTimeout = time.Duration(dbCfg.ConnectTimeout) * time.Second // Таймаут подключения
+	poolCfg.MaxConnLifetime = time.Duration(dbCfg.MaxConnLifetime) * time.Minute
+	poolCfg.MaxConnIdleTime = time.Duration(dbCfg.MaxConnIdleTime) * time.Minute
+	poolCfg.HealthCheckPeriod = time.Duration(dbCfg.HealthCheckPeriod) * time.Second
+	if dbCfg.MaxConns > 0 {
+		poolCfg.MaxConns = dbCfg.MaxConns
 	}
+	poolCfg.MinConns = dbCfg.MinConns
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pgx pool: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("db ping failed: %w", err)
+	}
+
+	var version string
+	if err := pool.QueryRow(ctx, "select version()").Scan(&version); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	sl.Print(ctx, "connected to db", "version", version)
+
+	return pool, nil
 }
@@ -0,0 +1,7 @@
+// Package db embeds sql migrations, so the binary can migrate the database itself (see internal/migrate).
+package db
+
+import "embed"
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
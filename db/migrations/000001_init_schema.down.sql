@@ -0,0 +1,6 @@
+DROP SCHEMA IF EXISTS schedules CASCADE;
+DROP SCHEMA IF EXISTS subjects CASCADE;
+DROP SCHEMA IF EXISTS groups CASCADE;
+DROP SCHEMA IF EXISTS personalities CASCADE;
+DROP SCHEMA IF EXISTS universities CASCADE;
+DROP SCHEMA IF EXISTS users CASCADE;
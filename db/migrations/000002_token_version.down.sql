@@ -0,0 +1,2 @@
+ALTER TABLE users.max_users_data
+    DROP COLUMN IF EXISTS token_version;
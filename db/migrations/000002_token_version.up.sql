@@ -0,0 +1,9 @@
+--
+-- Access-token version: bumped on every role change, access tokens
+-- carrying an older version are rejected by the JWT middleware.
+--
+
+ALTER TABLE users.max_users_data
+    ADD COLUMN IF NOT EXISTS token_version bigint DEFAULT 0 NOT NULL;
+
+COMMENT ON COLUMN users.max_users_data.token_version IS 'access token version, bumped when roles change';
@@ -0,0 +1,19 @@
+--
+-- Key pairs for asymmetric JWT signing (RS256 / EdDSA).
+-- The newest key signs, every non-expired key verifies and is published in JWKS.
+--
+
+CREATE TABLE IF NOT EXISTS users.jwt_keys (
+    kid text NOT NULL,
+    alg text NOT NULL,
+    private_key bytea NOT NULL,
+    public_key bytea NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    CONSTRAINT jwt_keys_pkey PRIMARY KEY (kid)
+);
+
+COMMENT ON COLUMN users.jwt_keys.private_key IS 'PKCS #8 DER';
+COMMENT ON COLUMN users.jwt_keys.public_key IS 'PKIX DER';
+
+CREATE INDEX IF NOT EXISTS jwt_keys_alg_created_at_idx ON users.jwt_keys (alg, created_at DESC);
@@ -0,0 +1,21 @@
+--
+-- Requests made by admins with impersonation ("view as user") tokens.
+--
+
+CREATE TABLE IF NOT EXISTS users.impersonation_audit (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    actor_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    method text NOT NULL,
+    path text NOT NULL,
+    status integer NOT NULL,
+    request_id text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT impersonation_audit_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON COLUMN users.impersonation_audit.actor_id IS 'admin max_users_data.id';
+COMMENT ON COLUMN users.impersonation_audit.user_id IS 'impersonated max_users_data.id';
+
+CREATE INDEX IF NOT EXISTS impersonation_audit_actor_id_created_at_idx ON users.impersonation_audit (actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS impersonation_audit_user_id_created_at_idx ON users.impersonation_audit (user_id, created_at DESC);
@@ -0,0 +1,33 @@
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'universities.universities_data',
+        'universities.faculties',
+        'universities.departments',
+        'universities.university_departments',
+        'universities.courses',
+        'universities.semesters',
+        'universities.events',
+        'groups.course_groups',
+        'groups.elective_groups',
+        'groups.students_elective_groups',
+        'personalities.administrations',
+        'personalities.students',
+        'personalities.teachers',
+        'subjects.university_subjects',
+        'subjects.course_semester_subjects',
+        'subjects.course_group_subjects',
+        'subjects.elective_group_subjects',
+        'schedules.classes',
+        'schedules.rooms',
+        'schedules.groups_schedules',
+        'users.persons_adds'
+    ] LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS audit_log_change ON %s', t);
+    END LOOP;
+END;
+$$;
+
+DROP SCHEMA IF EXISTS audit CASCADE;
@@ -0,0 +1,168 @@
+--
+-- Append-only audit of every change of administrative data.
+--
+-- Rows are written by audit.log_change() trigger. Who made the change is taken from
+-- transaction-local settings audit.actor_id, audit.impersonator_id and audit.request_id,
+-- which the application sets at the beginning of every write transaction.
+--
+
+CREATE SCHEMA IF NOT EXISTS audit;
+
+CREATE TABLE IF NOT EXISTS audit.audit_log (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    actor_id bigint,
+    impersonator_id bigint,
+    action text NOT NULL,
+    entity_type text NOT NULL,
+    entity_id text,
+    university_id bigint,
+    before jsonb,
+    after jsonb,
+    request_id text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT audit_log_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON COLUMN audit.audit_log.actor_id IS 'max_users_data.id of the user who made the change, NULL for system changes';
+COMMENT ON COLUMN audit.audit_log.action IS 'insert | update | delete';
+COMMENT ON COLUMN audit.audit_log.entity_type IS 'schema.table';
+
+CREATE INDEX IF NOT EXISTS audit_log_university_id_id_idx ON audit.audit_log (university_id, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit.audit_log (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_id_idx ON audit.audit_log (actor_id, id DESC);
+
+-- audit_log is append only
+CREATE OR REPLACE FUNCTION audit.forbid_change() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit.audit_log is append-only';
+END;
+$$;
+
+DROP TRIGGER IF EXISTS audit_log_forbid_change ON audit.audit_log;
+CREATE TRIGGER audit_log_forbid_change
+    BEFORE UPDATE OR DELETE ON audit.audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit.forbid_change();
+
+DROP TRIGGER IF EXISTS audit_log_forbid_truncate ON audit.audit_log;
+CREATE TRIGGER audit_log_forbid_truncate
+    BEFORE TRUNCATE ON audit.audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit.forbid_change();
+
+-- university the changed row belongs to, used to scope audit for university admins
+CREATE OR REPLACE FUNCTION audit.university_of(entity text, r jsonb) RETURNS bigint
+    LANGUAGE plpgsql STABLE AS $$
+BEGIN
+    IF r ? 'university_id' THEN
+        RETURN (r->>'university_id')::bigint;
+    END IF;
+
+    CASE entity
+        WHEN 'universities.universities_data' THEN
+            RETURN (r->>'id')::bigint;
+        WHEN 'universities.courses' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_department_id')::bigint);
+        WHEN 'personalities.students' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_deparment_id')::bigint);
+        WHEN 'groups.course_groups' THEN
+            RETURN (SELECT ud.university_id FROM universities.courses c
+                    JOIN universities.university_departments ud ON ud.id = c.university_department_id
+                    WHERE c.id = (r->>'course_id')::bigint);
+        WHEN 'groups.elective_groups' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'groups.students_elective_groups' THEN
+            RETURN (SELECT s.university_id FROM groups.elective_groups eg
+                    JOIN universities.semesters s ON s.id = eg.semester_id
+                    WHERE eg.id = (r->>'elective_group_id')::bigint);
+        WHEN 'subjects.course_semester_subjects' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'subjects.course_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'subjects.elective_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'schedules.groups_schedules' THEN
+            RETURN (SELECT c.university_id FROM schedules.classes c
+                    WHERE c.id = (r->>'class_id')::bigint);
+        WHEN 'users.persons_adds' THEN
+            RETURN (SELECT a.university_id FROM personalities.administrations a
+                    WHERE a.id = (r->>'to_administration_id')::bigint);
+        ELSE
+            RETURN NULL;
+    END CASE;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION audit.log_change() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+DECLARE
+    entity text := TG_TABLE_SCHEMA || '.' || TG_TABLE_NAME;
+    old_row jsonb;
+    new_row jsonb;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND old_row = new_row THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO audit.audit_log (actor_id, impersonator_id, action, entity_type, entity_id, university_id, before, after, request_id)
+    VALUES (
+        NULLIF(current_setting('audit.actor_id', true), '')::bigint,
+        NULLIF(current_setting('audit.impersonator_id', true), '')::bigint,
+        lower(TG_OP),
+        entity,
+        COALESCE(new_row, old_row)->>'id',
+        audit.university_of(entity, COALESCE(new_row, old_row)),
+        old_row,
+        new_row,
+        NULLIF(current_setting('audit.request_id', true), '')
+    );
+
+    RETURN NULL;
+END;
+$$;
+
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'universities.universities_data',
+        'universities.faculties',
+        'universities.departments',
+        'universities.university_departments',
+        'universities.courses',
+        'universities.semesters',
+        'universities.events',
+        'groups.course_groups',
+        'groups.elective_groups',
+        'groups.students_elective_groups',
+        'personalities.administrations',
+        'personalities.students',
+        'personalities.teachers',
+        'subjects.university_subjects',
+        'subjects.course_semester_subjects',
+        'subjects.course_group_subjects',
+        'subjects.elective_group_subjects',
+        'schedules.classes',
+        'schedules.rooms',
+        'schedules.groups_schedules',
+        'users.persons_adds'
+    ] LOOP
+        EXECUTE format('DROP TRIGGER IF EXISTS audit_log_change ON %s', t);
+        EXECUTE format('CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON %s
+                        FOR EACH ROW EXECUTE FUNCTION audit.log_change()', t);
+    END LOOP;
+END;
+$$;
@@ -0,0 +1,2 @@
+DROP FUNCTION IF EXISTS users.rate_limit_take(text, double precision, double precision);
+DROP TABLE IF EXISTS users.rate_limit_buckets;
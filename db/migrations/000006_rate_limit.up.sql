@@ -0,0 +1,36 @@
+--
+-- Token buckets of the rate limiter, shared by all api instances.
+--
+
+CREATE UNLOGGED TABLE IF NOT EXISTS users.rate_limit_buckets (
+    key text NOT NULL,
+    tokens double precision NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    CONSTRAINT rate_limit_buckets_pkey PRIMARY KEY (key)
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_updated_at_idx ON users.rate_limit_buckets (updated_at);
+
+-- users.rate_limit_take refills bucket by rate tokens/sec up to burst and takes one token if there is one.
+-- Returns whether the token was taken and tokens left.
+CREATE OR REPLACE FUNCTION users.rate_limit_take(bucket_key text, rate double precision, burst double precision,
+                                                 OUT allowed boolean, OUT tokens double precision)
+    LANGUAGE plpgsql AS $$
+#variable_conflict use_column
+DECLARE
+    ts timestamp with time zone := clock_timestamp();
+BEGIN
+    INSERT INTO users.rate_limit_buckets AS b (key, tokens, updated_at)
+    VALUES (bucket_key, burst, ts)
+    ON CONFLICT (key) DO UPDATE
+        SET tokens = LEAST(burst, b.tokens + GREATEST(EXTRACT(EPOCH FROM ts - b.updated_at), 0) * rate),
+            updated_at = ts
+    RETURNING b.tokens INTO tokens;
+
+    allowed := tokens >= 1;
+    IF allowed THEN
+        tokens := tokens - 1;
+        UPDATE users.rate_limit_buckets SET tokens = rate_limit_take.tokens WHERE key = bucket_key;
+    END IF;
+END;
+$$;
@@ -0,0 +1,109 @@
+DROP INDEX IF EXISTS users.persons_adds_to_administration_id_idx;
+DROP INDEX IF EXISTS users.refresh_tokens_max_user_id_idx;
+DROP INDEX IF EXISTS universities.university_departments_department_id_idx;
+DROP INDEX IF EXISTS universities.university_departments_faculty_id_idx;
+DROP INDEX IF EXISTS universities.universities_data_city_id_idx;
+DROP INDEX IF EXISTS universities.events_university_id_idx;
+DROP INDEX IF EXISTS universities.semesters_university_id_idx;
+DROP INDEX IF EXISTS universities.courses_university_department_id_idx;
+DROP INDEX IF EXISTS subjects.elective_group_subjects_teacher_id_idx;
+DROP INDEX IF EXISTS subjects.course_semester_subjects_university_subject_id_idx;
+DROP INDEX IF EXISTS subjects.course_semester_subjects_course_id_idx;
+DROP INDEX IF EXISTS subjects.course_group_subjects_teacher_id_idx;
+DROP INDEX IF EXISTS subjects.course_group_subjects_course_semester_subject_id_idx;
+DROP INDEX IF EXISTS schedules.groups_schedules_elective_group_subject_id_idx;
+DROP INDEX IF EXISTS schedules.groups_schedules_course_group_subjet_id_idx;
+DROP INDEX IF EXISTS schedules.groups_schedules_room_id_idx;
+DROP INDEX IF EXISTS schedules.groups_schedules_class_id_day_idx;
+DROP INDEX IF EXISTS personalities.teachers_university_id_idx;
+DROP INDEX IF EXISTS personalities.students_university_department_id_idx;
+DROP INDEX IF EXISTS personalities.students_course_group_id_idx;
+DROP INDEX IF EXISTS personalities.administrations_faculty_id_idx;
+DROP INDEX IF EXISTS personalities.administrations_university_id_idx;
+DROP INDEX IF EXISTS groups.students_elective_groups_elective_group_id_idx;
+DROP INDEX IF EXISTS groups.elective_groups_university_subject_id_idx;
+DROP INDEX IF EXISTS groups.course_groups_course_id_idx;
+
+ALTER TABLE universities.events DROP CONSTRAINT IF EXISTS events_universities_data_id_fk;
+ALTER TABLE universities.semesters DROP CONSTRAINT IF EXISTS semesters_universities_data_id_fk;
+
+DROP INDEX IF EXISTS personalities.administrations_user_university_faculty_ukey;
+
+-- duplicates archived by the up migration are put back
+INSERT INTO personalities.administrations (id, max_user_id, university_id, faculty_id)
+SELECT id, max_user_id, university_id, faculty_id FROM personalities.administrations_archive
+ON CONFLICT DO NOTHING;
+
+INSERT INTO users.persons_adds (id, from_max_user_id, to_administration_id, role_type)
+SELECT pa.id, pa.from_max_user_id, pa.to_administration_id, pa.role_type
+FROM users.persons_adds_archive pa
+WHERE EXISTS (SELECT 1 FROM personalities.administrations a WHERE a.id = pa.to_administration_id)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS users.persons_adds_archive;
+DROP TABLE IF EXISTS personalities.administrations_archive;
+
+-- groups_schedules_pk is not restored: lessons added since then may violate it
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = 'personalities' AND table_name = 'students'
+                 AND column_name = 'university_department_id') THEN
+        ALTER TABLE personalities.students RENAME COLUMN university_department_id TO university_deparment_id;
+    END IF;
+END;
+$$;
+
+-- university the changed row belongs to, used to scope audit for university admins
+CREATE OR REPLACE FUNCTION audit.university_of(entity text, r jsonb) RETURNS bigint
+    LANGUAGE plpgsql STABLE AS $$
+BEGIN
+    IF r ? 'university_id' THEN
+        RETURN (r->>'university_id')::bigint;
+    END IF;
+
+    CASE entity
+        WHEN 'universities.universities_data' THEN
+            RETURN (r->>'id')::bigint;
+        WHEN 'universities.courses' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_department_id')::bigint);
+        WHEN 'personalities.students' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_deparment_id')::bigint);
+        WHEN 'groups.course_groups' THEN
+            RETURN (SELECT ud.university_id FROM universities.courses c
+                    JOIN universities.university_departments ud ON ud.id = c.university_department_id
+                    WHERE c.id = (r->>'course_id')::bigint);
+        WHEN 'groups.elective_groups' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'groups.students_elective_groups' THEN
+            RETURN (SELECT s.university_id FROM groups.elective_groups eg
+                    JOIN universities.semesters s ON s.id = eg.semester_id
+                    WHERE eg.id = (r->>'elective_group_id')::bigint);
+        WHEN 'subjects.course_semester_subjects' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'subjects.course_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'subjects.elective_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'schedules.groups_schedules' THEN
+            RETURN (SELECT c.university_id FROM schedules.classes c
+                    WHERE c.id = (r->>'class_id')::bigint);
+        WHEN 'users.persons_adds' THEN
+            RETURN (SELECT a.university_id FROM personalities.administrations a
+                    WHERE a.id = (r->>'to_administration_id')::bigint);
+        ELSE
+            RETURN NULL;
+    END CASE;
+END;
+$$;
+
+-- events.description stays text: descriptions are not numbers
+
+ALTER TABLE universities.universities_data DROP COLUMN IF EXISTS photo_url;
@@ -0,0 +1,159 @@
+--
+-- Reconciles the init schema with the repositories and adds missing foreign keys and indexes.
+--
+
+-- universities_data.photo_url is read by the university queries but was missing in the dump
+ALTER TABLE universities.universities_data
+    ADD COLUMN IF NOT EXISTS photo_url text;
+
+-- events.description is a text, not a number
+ALTER TABLE universities.events
+    ALTER COLUMN description TYPE text USING description::text;
+
+-- students.university_deparment_id typo: inserts use the right name
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = 'personalities' AND table_name = 'students'
+                 AND column_name = 'university_deparment_id') THEN
+        ALTER TABLE personalities.students RENAME COLUMN university_deparment_id TO university_department_id;
+    END IF;
+END;
+$$;
+
+-- audit.university_of follows the renamed students column
+CREATE OR REPLACE FUNCTION audit.university_of(entity text, r jsonb) RETURNS bigint
+    LANGUAGE plpgsql STABLE AS $$
+BEGIN
+    IF r ? 'university_id' THEN
+        RETURN (r->>'university_id')::bigint;
+    END IF;
+
+    CASE entity
+        WHEN 'universities.universities_data' THEN
+            RETURN (r->>'id')::bigint;
+        WHEN 'universities.courses' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_department_id')::bigint);
+        WHEN 'personalities.students' THEN
+            RETURN (SELECT ud.university_id FROM universities.university_departments ud
+                    WHERE ud.id = (r->>'university_department_id')::bigint);
+        WHEN 'groups.course_groups' THEN
+            RETURN (SELECT ud.university_id FROM universities.courses c
+                    JOIN universities.university_departments ud ON ud.id = c.university_department_id
+                    WHERE c.id = (r->>'course_id')::bigint);
+        WHEN 'groups.elective_groups' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'groups.students_elective_groups' THEN
+            RETURN (SELECT s.university_id FROM groups.elective_groups eg
+                    JOIN universities.semesters s ON s.id = eg.semester_id
+                    WHERE eg.id = (r->>'elective_group_id')::bigint);
+        WHEN 'subjects.course_semester_subjects' THEN
+            RETURN (SELECT s.university_id FROM universities.semesters s
+                    WHERE s.id = (r->>'semester_id')::bigint);
+        WHEN 'subjects.course_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'subjects.elective_group_subjects' THEN
+            RETURN (SELECT t.university_id FROM personalities.teachers t
+                    WHERE t.id = (r->>'teacher_id')::bigint);
+        WHEN 'schedules.groups_schedules' THEN
+            RETURN (SELECT c.university_id FROM schedules.classes c
+                    WHERE c.id = (r->>'class_id')::bigint);
+        WHEN 'users.persons_adds' THEN
+            RETURN (SELECT a.university_id FROM personalities.administrations a
+                    WHERE a.id = (r->>'to_administration_id')::bigint);
+        ELSE
+            RETURN NULL;
+    END CASE;
+END;
+$$;
+
+-- groups_schedules: two "every two week" lessons may share a slot and different rooms are used at the same time,
+-- conflicts are checked by the repository, so (day, class_id, interval) is not unique
+ALTER TABLE schedules.groups_schedules DROP CONSTRAINT IF EXISTS groups_schedules_pk;
+
+-- administrations: inserts rely on ON CONFLICT DO NOTHING, so duplicates have to be removed first.
+-- Access requests are sent to every admin of the university, the kept admin already has them.
+-- Removed rows are moved to archive tables, the down migration puts them back.
+CREATE TABLE IF NOT EXISTS personalities.administrations_archive (
+    LIKE personalities.administrations,
+    archived_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS users.persons_adds_archive (
+    LIKE users.persons_adds,
+    archived_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE TEMPORARY TABLE administrations_duplicates ON COMMIT DROP AS
+SELECT a.id
+FROM personalities.administrations a
+WHERE EXISTS (SELECT 1 FROM personalities.administrations d
+              WHERE d.max_user_id = a.max_user_id
+                AND d.university_id = a.university_id
+                AND d.faculty_id IS NOT DISTINCT FROM a.faculty_id
+                AND d.id < a.id);
+
+WITH moved AS (
+    DELETE FROM users.persons_adds pa
+    USING administrations_duplicates dup
+    WHERE pa.to_administration_id = dup.id
+    RETURNING pa.*
+)
+INSERT INTO users.persons_adds_archive (id, from_max_user_id, to_administration_id, role_type)
+SELECT id, from_max_user_id, to_administration_id, role_type FROM moved;
+
+WITH moved AS (
+    DELETE FROM personalities.administrations a
+    USING administrations_duplicates dup
+    WHERE a.id = dup.id
+    RETURNING a.*
+)
+INSERT INTO personalities.administrations_archive (id, max_user_id, university_id, faculty_id)
+SELECT id, max_user_id, university_id, faculty_id FROM moved;
+
+CREATE UNIQUE INDEX IF NOT EXISTS administrations_user_university_faculty_ukey
+    ON personalities.administrations (max_user_id, university_id, faculty_id) NULLS NOT DISTINCT;
+
+-- missing foreign keys
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'semesters_universities_data_id_fk') THEN
+        ALTER TABLE universities.semesters
+            ADD CONSTRAINT semesters_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'events_universities_data_id_fk') THEN
+        ALTER TABLE universities.events
+            ADD CONSTRAINT events_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id);
+    END IF;
+END;
+$$;
+
+-- indexes on foreign keys not covered by unique constraints
+CREATE INDEX IF NOT EXISTS course_groups_course_id_idx ON groups.course_groups (course_id);
+CREATE INDEX IF NOT EXISTS elective_groups_university_subject_id_idx ON groups.elective_groups (university_subject_id);
+CREATE INDEX IF NOT EXISTS students_elective_groups_elective_group_id_idx ON groups.students_elective_groups (elective_group_id);
+CREATE INDEX IF NOT EXISTS administrations_university_id_idx ON personalities.administrations (university_id);
+CREATE INDEX IF NOT EXISTS administrations_faculty_id_idx ON personalities.administrations (faculty_id);
+CREATE INDEX IF NOT EXISTS students_course_group_id_idx ON personalities.students (course_group_id);
+CREATE INDEX IF NOT EXISTS students_university_department_id_idx ON personalities.students (university_department_id);
+CREATE INDEX IF NOT EXISTS teachers_university_id_idx ON personalities.teachers (university_id);
+CREATE INDEX IF NOT EXISTS groups_schedules_class_id_day_idx ON schedules.groups_schedules (class_id, day);
+CREATE INDEX IF NOT EXISTS groups_schedules_room_id_idx ON schedules.groups_schedules (room_id);
+CREATE INDEX IF NOT EXISTS groups_schedules_course_group_subjet_id_idx ON schedules.groups_schedules (course_group_subjet_id);
+CREATE INDEX IF NOT EXISTS groups_schedules_elective_group_subject_id_idx ON schedules.groups_schedules (elective_group_subject_id);
+CREATE INDEX IF NOT EXISTS course_group_subjects_course_semester_subject_id_idx ON subjects.course_group_subjects (course_semester_subject_id);
+CREATE INDEX IF NOT EXISTS course_group_subjects_teacher_id_idx ON subjects.course_group_subjects (teacher_id);
+CREATE INDEX IF NOT EXISTS course_semester_subjects_course_id_idx ON subjects.course_semester_subjects (course_id);
+CREATE INDEX IF NOT EXISTS course_semester_subjects_university_subject_id_idx ON subjects.course_semester_subjects (university_subject_id);
+CREATE INDEX IF NOT EXISTS elective_group_subjects_teacher_id_idx ON subjects.elective_group_subjects (teacher_id);
+CREATE INDEX IF NOT EXISTS courses_university_department_id_idx ON universities.courses (university_department_id);
+CREATE INDEX IF NOT EXISTS semesters_university_id_idx ON universities.semesters (university_id);
+CREATE INDEX IF NOT EXISTS events_university_id_idx ON universities.events (university_id);
+CREATE INDEX IF NOT EXISTS universities_data_city_id_idx ON universities.universities_data (city_id);
+CREATE INDEX IF NOT EXISTS university_departments_faculty_id_idx ON universities.university_departments (faculty_id);
+CREATE INDEX IF NOT EXISTS university_departments_department_id_idx ON universities.university_departments (department_id);
+CREATE INDEX IF NOT EXISTS refresh_tokens_max_user_id_idx ON users.refresh_tokens (max_user_id);
+CREATE INDEX IF NOT EXISTS persons_adds_to_administration_id_idx ON users.persons_adds (to_administration_id);
@@ -0,0 +1,21 @@
+-- soft deleted rows are removed from unique indexes, restoring constraints fails if they clash with live ones
+DROP INDEX IF EXISTS groups.course_groups_name_course_id_ukey;
+DROP INDEX IF EXISTS universities.courses_start_end_university_department_id;
+DROP INDEX IF EXISTS universities.university_departments_ukey;
+DROP INDEX IF EXISTS universities.faculties_university_name_ukey;
+DROP INDEX IF EXISTS universities.universities_data_short_name_city_ukey;
+DROP INDEX IF EXISTS universities.universities_data_name_city_ukey;
+
+ALTER TABLE groups.course_groups ADD CONSTRAINT course_groups_name_course_id_ukey UNIQUE (name, course_id);
+ALTER TABLE universities.courses
+    ADD CONSTRAINT courses_start_end_university_department_id UNIQUE (start_date, end_date, university_department_id);
+ALTER TABLE universities.university_departments ADD CONSTRAINT university_departments_ukey UNIQUE (university_id, department_id);
+ALTER TABLE universities.faculties ADD CONSTRAINT faculties_university_name_ukey UNIQUE (university_id, name);
+ALTER TABLE universities.universities_data ADD CONSTRAINT universities_data_short_name_city_ukey UNIQUE (short_name, city_id);
+ALTER TABLE universities.universities_data ADD CONSTRAINT universities_data_name_city_ukey UNIQUE (name, city_id);
+
+ALTER TABLE groups.course_groups DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE universities.courses DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE universities.university_departments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE universities.faculties DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE universities.universities_data DROP COLUMN IF EXISTS deleted_at;
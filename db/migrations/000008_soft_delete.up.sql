@@ -0,0 +1,31 @@
+--
+-- Soft delete of the academic hierarchy: universities -> faculties -> university_departments -> courses -> course_groups.
+-- Deleted rows keep deleted_at and stay referenced by graduated students, audit and history.
+--
+
+ALTER TABLE universities.universities_data ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE universities.faculties ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE universities.university_departments ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE universities.courses ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE groups.course_groups ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+
+-- names must be unique among live rows only, so a deleted entity can be created again
+ALTER TABLE universities.universities_data DROP CONSTRAINT IF EXISTS universities_data_name_city_ukey;
+ALTER TABLE universities.universities_data DROP CONSTRAINT IF EXISTS universities_data_short_name_city_ukey;
+ALTER TABLE universities.faculties DROP CONSTRAINT IF EXISTS faculties_university_name_ukey;
+ALTER TABLE universities.university_departments DROP CONSTRAINT IF EXISTS university_departments_ukey;
+ALTER TABLE universities.courses DROP CONSTRAINT IF EXISTS courses_start_end_university_department_id;
+ALTER TABLE groups.course_groups DROP CONSTRAINT IF EXISTS course_groups_name_course_id_ukey;
+
+CREATE UNIQUE INDEX IF NOT EXISTS universities_data_name_city_ukey
+    ON universities.universities_data (name, city_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS universities_data_short_name_city_ukey
+    ON universities.universities_data (short_name, city_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS faculties_university_name_ukey
+    ON universities.faculties (university_id, name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS university_departments_ukey
+    ON universities.university_departments (university_id, department_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS courses_start_end_university_department_id
+    ON universities.courses (start_date, end_date, university_department_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS course_groups_name_course_id_ukey
+    ON groups.course_groups (name, course_id) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE subjects.course_semester_subjects DROP CONSTRAINT IF EXISTS course_semester_subjects_required_hours_check;
@@ -0,0 +1,9 @@
+--
+-- Curriculum: hours of a course subject are positive when set.
+--
+
+ALTER TABLE subjects.course_semester_subjects
+    ADD CONSTRAINT course_semester_subjects_required_hours_check
+    CHECK (required_hours_by_semester IS NULL OR required_hours_by_semester > 0) NOT VALID;
+
+ALTER TABLE subjects.course_semester_subjects VALIDATE CONSTRAINT course_semester_subjects_required_hours_check;
@@ -0,0 +1,18 @@
+ALTER TABLE groups.elective_groups DROP CONSTRAINT IF EXISTS elective_groups_semester_subject_name_ukey;
+-- the baseline constraint is restored only if groups added since then don't violate it
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM groups.elective_groups
+                   GROUP BY semester_id, university_subject_id HAVING count(*) > 1)
+       AND NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'elective_groups_semester_subject_ukey') THEN
+        ALTER TABLE groups.elective_groups
+            ADD CONSTRAINT elective_groups_semester_subject_ukey UNIQUE (semester_id, university_subject_id);
+    END IF;
+END;
+$$;
+
+DROP TABLE IF EXISTS groups.elective_preferences;
+DROP TABLE IF EXISTS groups.elective_windows;
+
+ALTER TABLE groups.elective_groups DROP CONSTRAINT IF EXISTS elective_groups_capacity_check;
+ALTER TABLE groups.elective_groups DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,66 @@
+--
+-- Elective enrollment: capacity of elective groups, selection windows per semester and ranked
+-- preferences of students for lottery windows. Enrollment itself is groups.students_elective_groups.
+--
+
+-- NULL capacity is unlimited
+ALTER TABLE groups.elective_groups ADD COLUMN IF NOT EXISTS capacity integer;
+ALTER TABLE groups.elective_groups DROP CONSTRAINT IF EXISTS elective_groups_capacity_check;
+ALTER TABLE groups.elective_groups ADD CONSTRAINT elective_groups_capacity_check CHECK (capacity IS NULL OR capacity > 0);
+
+CREATE TABLE IF NOT EXISTS groups.elective_windows (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    university_id bigint NOT NULL,
+    semester_id bigint NOT NULL,
+    opens_at timestamp with time zone NOT NULL,
+    closes_at timestamp with time zone NOT NULL,
+    mode text NOT NULL,
+    max_choices integer DEFAULT 1 NOT NULL,
+    drawn_at timestamp with time zone,
+    lottery_seed bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT elective_windows_pkey PRIMARY KEY (id),
+    CONSTRAINT elective_windows_semester_ukey UNIQUE (semester_id),
+    CONSTRAINT elective_windows_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT elective_windows_semesters_id_fk FOREIGN KEY (semester_id) REFERENCES universities.semesters(id),
+    CONSTRAINT elective_windows_period_check CHECK (opens_at < closes_at),
+    CONSTRAINT elective_windows_mode_check CHECK (mode IN ('first_come', 'lottery')),
+    CONSTRAINT elective_windows_max_choices_check CHECK (max_choices > 0)
+);
+
+COMMENT ON COLUMN groups.elective_windows.mode IS 'first_come: enrolled on choice while seats last | lottery: ranked preferences, enrolled by draw after close';
+COMMENT ON COLUMN groups.elective_windows.drawn_at IS 'when the lottery was drawn, NULL before the draw and for first_come windows';
+COMMENT ON COLUMN groups.elective_windows.lottery_seed IS 'seed of the draw, the same seed and preferences give the same result';
+
+CREATE INDEX IF NOT EXISTS elective_windows_university_id_idx ON groups.elective_windows (university_id);
+
+CREATE TABLE IF NOT EXISTS groups.elective_preferences (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    window_id bigint NOT NULL,
+    student_id bigint NOT NULL,
+    elective_group_id bigint NOT NULL,
+    rank integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT elective_preferences_pkey PRIMARY KEY (id),
+    CONSTRAINT elective_preferences_group_ukey UNIQUE (window_id, student_id, elective_group_id),
+    CONSTRAINT elective_preferences_rank_ukey UNIQUE (window_id, student_id, rank),
+    CONSTRAINT elective_preferences_elective_windows_id_fk FOREIGN KEY (window_id) REFERENCES groups.elective_windows(id) ON DELETE CASCADE,
+    CONSTRAINT elective_preferences_students_id_fk FOREIGN KEY (student_id) REFERENCES personalities.students(id),
+    CONSTRAINT elective_preferences_elective_groups_id_fk FOREIGN KEY (elective_group_id) REFERENCES groups.elective_groups(id),
+    CONSTRAINT elective_preferences_rank_check CHECK (rank > 0)
+);
+
+CREATE INDEX IF NOT EXISTS elective_preferences_student_id_idx ON groups.elective_preferences (student_id);
+CREATE INDEX IF NOT EXISTS elective_preferences_elective_group_id_idx ON groups.elective_preferences (elective_group_id);
+
+-- windows are administrative data, university_id column scopes them in the audit log
+DROP TRIGGER IF EXISTS audit_log_change ON groups.elective_windows;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON groups.elective_windows
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
+
+-- several groups of one elective subject in a semester (sections with their own capacity and teacher)
+-- differ by name, the baseline constraint allowed only one group per subject
+ALTER TABLE groups.elective_groups DROP CONSTRAINT IF EXISTS elective_groups_semester_subject_ukey;
+ALTER TABLE groups.elective_groups DROP CONSTRAINT IF EXISTS elective_groups_semester_subject_name_ukey;
+ALTER TABLE groups.elective_groups
+    ADD CONSTRAINT elective_groups_semester_subject_name_ukey UNIQUE (semester_id, university_subject_id, name);
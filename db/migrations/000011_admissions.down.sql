@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS admissions.status_changes;
+DROP TABLE IF EXISTS admissions.applications;
+DROP TABLE IF EXISTS admissions.programs;
+DROP TABLE IF EXISTS admissions.campaigns;
+DROP SCHEMA IF EXISTS admissions;
@@ -0,0 +1,91 @@
+--
+-- Admissions: campaigns of a university with dates, programs of a campaign per university department
+-- with seats, applications of applicants with their status history. Status changes are also
+-- the outbox of notifications, the bot sends undelivered ones.
+--
+
+CREATE SCHEMA IF NOT EXISTS admissions;
+
+CREATE TABLE IF NOT EXISTS admissions.campaigns (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    university_id bigint NOT NULL,
+    name character varying(125) NOT NULL,
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT campaigns_pkey PRIMARY KEY (id),
+    CONSTRAINT campaigns_university_name_ukey UNIQUE (university_id, name),
+    CONSTRAINT campaigns_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT campaigns_period_check CHECK (starts_at < ends_at)
+);
+
+CREATE TABLE IF NOT EXISTS admissions.programs (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    campaign_id bigint NOT NULL,
+    university_department_id bigint NOT NULL,
+    seats integer NOT NULL,
+    CONSTRAINT programs_pkey PRIMARY KEY (id),
+    CONSTRAINT programs_campaign_department_ukey UNIQUE (campaign_id, university_department_id),
+    CONSTRAINT programs_campaigns_id_fk FOREIGN KEY (campaign_id) REFERENCES admissions.campaigns(id),
+    CONSTRAINT programs_university_departments_id_fk FOREIGN KEY (university_department_id) REFERENCES universities.university_departments(id),
+    CONSTRAINT programs_seats_check CHECK (seats > 0)
+);
+
+CREATE INDEX IF NOT EXISTS programs_university_department_id_idx ON admissions.programs (university_department_id);
+
+CREATE TABLE IF NOT EXISTS admissions.applications (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    program_id bigint NOT NULL,
+    max_user_id bigint NOT NULL,
+    score integer,
+    score_verified_by bigint,
+    score_verified_at timestamp with time zone,
+    status text DEFAULT 'submitted' NOT NULL,
+    student_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT applications_pkey PRIMARY KEY (id),
+    CONSTRAINT applications_program_user_ukey UNIQUE (program_id, max_user_id),
+    CONSTRAINT applications_programs_id_fk FOREIGN KEY (program_id) REFERENCES admissions.programs(id),
+    CONSTRAINT applications_max_users_data_id_fk FOREIGN KEY (max_user_id) REFERENCES users.max_users_data(id),
+    CONSTRAINT applications_students_id_fk FOREIGN KEY (student_id) REFERENCES personalities.students(id),
+    CONSTRAINT applications_score_verified_by_fk FOREIGN KEY (score_verified_by) REFERENCES users.max_users_data(id),
+    CONSTRAINT applications_score_check CHECK (score >= 0),
+    CONSTRAINT applications_score_verified_check CHECK ((score IS NULL) = (score_verified_at IS NULL) AND (score IS NULL) = (score_verified_by IS NULL)),
+    CONSTRAINT applications_status_check CHECK (status IN ('submitted', 'under_review', 'accepted', 'rejected', 'waitlisted')),
+    CONSTRAINT applications_student_check CHECK (student_id IS NULL OR status = 'accepted')
+);
+
+COMMENT ON COLUMN admissions.applications.status IS 'submitted -> under_review -> accepted | rejected | waitlisted, waitlisted -> accepted | rejected';
+COMMENT ON COLUMN admissions.applications.score IS 'set by an admin after checking exam results, NULL until then';
+COMMENT ON COLUMN admissions.applications.student_id IS 'student created from the accepted application';
+
+CREATE INDEX IF NOT EXISTS applications_max_user_id_idx ON admissions.applications (max_user_id);
+-- ranked list of a program
+CREATE INDEX IF NOT EXISTS applications_program_rank_idx ON admissions.applications (program_id, score DESC NULLS LAST, created_at);
+
+CREATE TABLE IF NOT EXISTS admissions.status_changes (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    application_id bigint NOT NULL,
+    from_status text,
+    to_status text NOT NULL,
+    comment character varying(1000),
+    changed_by bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    notified_at timestamp with time zone,
+    notify_attempts integer DEFAULT 0 NOT NULL,
+    claimed_until timestamp with time zone,
+    CONSTRAINT status_changes_pkey PRIMARY KEY (id),
+    CONSTRAINT status_changes_applications_id_fk FOREIGN KEY (application_id) REFERENCES admissions.applications(id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN admissions.status_changes.claimed_until IS 'a notifier sends the change until then, other replicas skip it';
+
+CREATE INDEX IF NOT EXISTS status_changes_application_id_idx ON admissions.status_changes (application_id);
+-- outbox of notifications
+CREATE INDEX IF NOT EXISTS status_changes_pending_idx ON admissions.status_changes (id) WHERE notified_at IS NULL;
+
+-- campaigns are administrative data, university_id column scopes them in the audit log
+DROP TRIGGER IF EXISTS audit_log_change ON admissions.campaigns;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON admissions.campaigns
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
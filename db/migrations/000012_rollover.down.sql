@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS schedules.archived_groups_schedules;
+ALTER TABLE universities.courses DROP COLUMN IF EXISTS rollover_id;
+DROP TABLE IF EXISTS universities.rollovers;
+
+DROP INDEX IF EXISTS universities.courses_start_end_university_department_id;
+ALTER TABLE universities.courses DROP COLUMN IF EXISTS year_of_study;
+CREATE UNIQUE INDEX IF NOT EXISTS courses_start_end_university_department_id
+    ON universities.courses (start_date, end_date, university_department_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE universities.university_departments DROP COLUMN IF EXISTS study_years;
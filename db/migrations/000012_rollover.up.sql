@@ -0,0 +1,66 @@
+--
+-- Academic year rollover: courses know their year of study, university departments know how many
+-- years the study lasts. A rollover promotes groups of finished courses to courses of the next year,
+-- graduates students of the final year and moves lessons of finished semesters to the archive.
+--
+
+ALTER TABLE universities.university_departments ADD COLUMN IF NOT EXISTS study_years integer DEFAULT 4 NOT NULL;
+ALTER TABLE universities.university_departments DROP CONSTRAINT IF EXISTS university_departments_study_years_check;
+ALTER TABLE universities.university_departments ADD CONSTRAINT university_departments_study_years_check CHECK (study_years BETWEEN 1 AND 12);
+
+-- NULL year of study is unknown, such courses are skipped by rollovers
+ALTER TABLE universities.courses ADD COLUMN IF NOT EXISTS year_of_study integer;
+ALTER TABLE universities.courses DROP CONSTRAINT IF EXISTS courses_year_of_study_check;
+ALTER TABLE universities.courses ADD CONSTRAINT courses_year_of_study_check CHECK (year_of_study BETWEEN 1 AND 12);
+
+-- courses of different years of a department share dates of the academic year
+DROP INDEX IF EXISTS universities.courses_start_end_university_department_id;
+CREATE UNIQUE INDEX IF NOT EXISTS courses_start_end_university_department_id
+    ON universities.courses (start_date, end_date, university_department_id, year_of_study) NULLS NOT DISTINCT
+    WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS universities.rollovers (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    university_id bigint NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    promoted_groups integer NOT NULL,
+    graduated_students integer NOT NULL,
+    archived_lessons integer NOT NULL,
+    created_by bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT rollovers_pkey PRIMARY KEY (id),
+    CONSTRAINT rollovers_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT rollovers_max_users_data_id_fk FOREIGN KEY (created_by) REFERENCES users.max_users_data(id),
+    CONSTRAINT rollovers_period_check CHECK (start_date < end_date)
+);
+
+COMMENT ON COLUMN universities.rollovers.start_date IS 'start of the academic year the university was rolled over to';
+
+CREATE INDEX IF NOT EXISTS rollovers_university_id_idx ON universities.rollovers (university_id);
+
+-- course rolled over: its groups were promoted or graduated
+ALTER TABLE universities.courses ADD COLUMN IF NOT EXISTS rollover_id bigint;
+ALTER TABLE universities.courses DROP CONSTRAINT IF EXISTS courses_rollovers_id_fk;
+ALTER TABLE universities.courses
+    ADD CONSTRAINT courses_rollovers_id_fk FOREIGN KEY (rollover_id) REFERENCES universities.rollovers(id);
+
+-- lessons of finished semesters, columns of schedules.groups_schedules
+CREATE TABLE IF NOT EXISTS schedules.archived_groups_schedules (
+    id bigint NOT NULL,
+    course_group_subjet_id bigint,
+    elective_group_subject_id bigint,
+    day schedules.day_type NOT NULL,
+    class_id bigint NOT NULL,
+    room_id bigint NOT NULL,
+    "interval" schedules.interval_type NOT NULL,
+    rollover_id bigint NOT NULL,
+    archived_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT archived_groups_schedules_pkey PRIMARY KEY (id),
+    CONSTRAINT archived_groups_schedules_rollovers_id_fk FOREIGN KEY (rollover_id) REFERENCES universities.rollovers(id)
+);
+
+CREATE INDEX IF NOT EXISTS archived_groups_schedules_rollover_id_idx ON schedules.archived_groups_schedules (rollover_id);
+
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON universities.rollovers
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
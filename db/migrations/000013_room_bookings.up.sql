@@ -0,0 +1,41 @@
+--
+-- Room bookings: ad-hoc reservations of a room for a pair of the bell schedule on a date, such as meetings,
+-- exams and student club events. Members of the university request them, admins confirm or reject.
+-- Confirmed bookings and lessons of the semester exclude each other.
+--
+
+CREATE TABLE IF NOT EXISTS schedules.room_bookings (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    university_id bigint NOT NULL,
+    room_id bigint NOT NULL,
+    class_id bigint NOT NULL,
+    date date NOT NULL,
+    purpose text NOT NULL,
+    title character varying(125) NOT NULL,
+    comment character varying(1000),
+    status text DEFAULT 'pending' NOT NULL,
+    requested_by bigint NOT NULL,
+    decided_by bigint,
+    decided_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT room_bookings_pkey PRIMARY KEY (id),
+    CONSTRAINT room_bookings_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT room_bookings_rooms_id_fk FOREIGN KEY (room_id) REFERENCES schedules.rooms(id),
+    CONSTRAINT room_bookings_classes_id_fk FOREIGN KEY (class_id) REFERENCES schedules.classes(id),
+    CONSTRAINT room_bookings_requested_by_fk FOREIGN KEY (requested_by) REFERENCES users.max_users_data(id),
+    CONSTRAINT room_bookings_decided_by_fk FOREIGN KEY (decided_by) REFERENCES users.max_users_data(id),
+    CONSTRAINT room_bookings_purpose_check CHECK (purpose IN ('meeting', 'exam', 'club', 'other')),
+    CONSTRAINT room_bookings_status_check CHECK (status IN ('pending', 'confirmed', 'rejected', 'cancelled'))
+);
+
+COMMENT ON COLUMN schedules.room_bookings.status IS 'pending -> confirmed | rejected | cancelled, confirmed -> cancelled';
+
+-- a room is confirmed for a pair once
+CREATE UNIQUE INDEX IF NOT EXISTS room_bookings_confirmed_ukey ON schedules.room_bookings (room_id, date, class_id) WHERE status = 'confirmed';
+CREATE INDEX IF NOT EXISTS room_bookings_university_date_idx ON schedules.room_bookings (university_id, date);
+CREATE INDEX IF NOT EXISTS room_bookings_requested_by_idx ON schedules.room_bookings (requested_by);
+
+-- bookings are administrative data, university_id column scopes them in the audit log
+DROP TRIGGER IF EXISTS audit_log_change ON schedules.room_bookings;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON schedules.room_bookings
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS schedules.exam_rooms;
+DROP TABLE IF EXISTS schedules.exams;
+DROP TABLE IF EXISTS schedules.exam_sessions;
@@ -0,0 +1,74 @@
+--
+-- Exam sessions: one-off exams and tests of course groups on dates of a semester, in one or more rooms,
+-- with an examiner. Students see exams of published sessions only.
+--
+
+CREATE TABLE IF NOT EXISTS schedules.exam_sessions (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    university_id bigint NOT NULL,
+    semester_id bigint NOT NULL,
+    name character varying(125) NOT NULL,
+    starts_on date NOT NULL,
+    ends_on date NOT NULL,
+    min_gap_days integer DEFAULT 1 NOT NULL,
+    published_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT exam_sessions_pkey PRIMARY KEY (id),
+    CONSTRAINT exam_sessions_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT exam_sessions_semesters_id_fk FOREIGN KEY (semester_id) REFERENCES universities.semesters(id),
+    CONSTRAINT exam_sessions_dates_check CHECK (starts_on <= ends_on),
+    CONSTRAINT exam_sessions_min_gap_days_check CHECK (min_gap_days BETWEEN 1 AND 14)
+);
+
+COMMENT ON COLUMN schedules.exam_sessions.min_gap_days IS 'least number of days between two exams of a group, 1 forbids two exams a day only';
+
+CREATE TABLE IF NOT EXISTS schedules.exams (
+    id bigint GENERATED BY DEFAULT AS IDENTITY,
+    session_id bigint NOT NULL,
+    course_semester_subject_id bigint NOT NULL,
+    course_group_id bigint NOT NULL,
+    examiner_id bigint NOT NULL,
+    kind text NOT NULL,
+    date date NOT NULL,
+    start_time time without time zone NOT NULL,
+    end_time time without time zone NOT NULL,
+    university_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT exams_pkey PRIMARY KEY (id),
+    CONSTRAINT exams_exam_sessions_id_fk FOREIGN KEY (session_id) REFERENCES schedules.exam_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT exams_course_semester_subjects_id_fk FOREIGN KEY (course_semester_subject_id) REFERENCES subjects.course_semester_subjects(id),
+    CONSTRAINT exams_course_groups_id_fk FOREIGN KEY (course_group_id) REFERENCES groups.course_groups(id),
+    CONSTRAINT exams_teachers_id_fk FOREIGN KEY (examiner_id) REFERENCES personalities.teachers(id),
+    CONSTRAINT exams_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id),
+    CONSTRAINT exams_kind_check CHECK (kind IN ('exam', 'test')),
+    CONSTRAINT exams_time_check CHECK (start_time < end_time),
+    -- a group sits an exam or a test of a subject once a session
+    CONSTRAINT exams_session_subject_group_kind_ukey UNIQUE (session_id, course_semester_subject_id, course_group_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS exams_course_group_date_idx ON schedules.exams (course_group_id, date);
+CREATE INDEX IF NOT EXISTS exams_examiner_date_idx ON schedules.exams (examiner_id, date);
+CREATE INDEX IF NOT EXISTS exams_date_idx ON schedules.exams (date);
+
+CREATE TABLE IF NOT EXISTS schedules.exam_rooms (
+    exam_id bigint NOT NULL,
+    room_id bigint NOT NULL,
+    university_id bigint NOT NULL,
+    CONSTRAINT exam_rooms_pkey PRIMARY KEY (exam_id, room_id),
+    CONSTRAINT exam_rooms_exams_id_fk FOREIGN KEY (exam_id) REFERENCES schedules.exams(id) ON DELETE CASCADE,
+    CONSTRAINT exam_rooms_rooms_id_fk FOREIGN KEY (room_id) REFERENCES schedules.rooms(id),
+    CONSTRAINT exam_rooms_universities_data_id_fk FOREIGN KEY (university_id) REFERENCES universities.universities_data(id)
+);
+
+CREATE INDEX IF NOT EXISTS exam_rooms_room_id_idx ON schedules.exam_rooms (room_id);
+
+-- exams are administrative data, university_id columns scope them in the audit log
+DROP TRIGGER IF EXISTS audit_log_change ON schedules.exam_sessions;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON schedules.exam_sessions
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
+DROP TRIGGER IF EXISTS audit_log_change ON schedules.exams;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON schedules.exams
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
+DROP TRIGGER IF EXISTS audit_log_change ON schedules.exam_rooms;
+CREATE TRIGGER audit_log_change AFTER INSERT OR UPDATE OR DELETE ON schedules.exam_rooms
+    FOR EACH ROW EXECUTE FUNCTION audit.log_change();
@@ -15,14 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/courses": {
+        "/.well-known/jwks.json": {
             "get": {
+                "description": "Public keys for verifying access tokens signed with RS256/EdDSA. Keys are identified by kid header of the token. Empty for HS256.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "Active public keys",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_services_auth.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/admissions/applications/{id}/enroll": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all courses for the admin's university. Admin role required.",
+                "description": "Create student of the program's department in a course group of the department from an accepted application",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,46 +50,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
+                ],
+                "summary": "Enroll accepted applicant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Course group",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.EnrollRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all courses for university",
                 "responses": {
                     "200": {
-                        "description": "List of courses",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CourseInfoResponse"
-                            }
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.EnrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Application is not accepted or already enrolled, group is not of the department",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/admissions/applications/{id}/score": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new course for a specific university department. Creates entry in universities.courses. Admin role required.",
+                "description": "The admin enters the score checked against exam results, the application takes its place in the ranked list by it. The score of an accepted application can't change",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,23 +132,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
                 ],
-                "summary": "Create new course",
+                "summary": "Set verified score of application",
                 "parameters": [
                     {
-                        "description": "Course data (start_date, end_date, university_department_id required)",
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Score",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateCourseRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.SetScoreRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: course created successfully",
+                        "description": "status: ok",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -102,40 +164,52 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or missing required fields",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Application is accepted",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/admin/department": {
-            "post": {
+        "/admin/admissions/applications/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new department and link it to a specific faculty and university. Creates entry in universities.departments and universities.university_departments. Admin role required.",
+                "description": "submitted -\u003e under_review -\u003e accepted | rejected | waitlisted, waitlisted -\u003e accepted | rejected. An application is accepted only with a verified score and while the program has free seats. The applicant is notified by the bot",
                 "consumes": [
                     "application/json"
                 ],
@@ -143,23 +217,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
                 ],
-                "summary": "Create new department",
+                "summary": "Change application status",
                 "parameters": [
                     {
-                        "description": "Department data (department_name required, department_code and alias_name optional)",
+                        "type": "integer",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateDepartmentRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ChangeStatusRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: department created successfully",
+                        "description": "status: ok",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -168,76 +249,85 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or missing required fields",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Transition is not allowed, no free seats, score is not verified",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/admin/faculties": {
+        "/admin/admissions/campaigns": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all faculties for the university associated with the authenticated admin user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get admission campaigns of the admin's universities",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
                 ],
-                "summary": "Get all faculties for admin's university",
+                "summary": "Get admission campaigns",
                 "responses": {
                     "200": {
-                        "description": "List of faculties",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse"
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CampaignResponse"
                             }
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
@@ -248,7 +338,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new faculty for the university. Admin role required.",
+                "description": "Create admission campaign of the admin's university, applications are accepted from starts_at till ends_at",
                 "consumes": [
                     "application/json"
                 ],
@@ -256,65 +346,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
                 ],
-                "summary": "Create new faculty",
+                "summary": "Create admission campaign",
                 "parameters": [
                     {
-                        "description": "Faculty data",
+                        "description": "Campaign",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateNewFacultyRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CreateCampaignRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: faculty created successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "University not found, campaign already exists",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/admin/groups": {
-            "post": {
+        "/admin/admissions/campaigns/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new course group for a specific course. Creates entry in groups.course_groups. Admin role required.",
                 "consumes": [
                     "application/json"
                 ],
@@ -322,23 +414,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "admissions"
                 ],
-                "summary": "Create new course group",
+                "summary": "Update admission campaign",
                 "parameters": [
                     {
-                        "description": "Group data (group_name and course_id required)",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campaign",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateGroupRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.UpdateCampaignRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: group created successfully",
+                        "description": "status: ok",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -347,97 +446,110 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or missing required fields",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Campaign with the name already exists",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/admin/personalities/access": {
+        "/admin/admissions/campaigns/{id}/programs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all access requests to join university (student/teacher/administration). Admin role required.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admissions"
                 ],
-                "summary": "Get all access requests for administration",
+                "summary": "Get programs of campaign",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 5,
-                        "description": "Limit of requests, max(50), default(5)",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset, default(0)",
-                        "name": "offset",
-                        "in": "query",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Requests for administration",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AccessRequestResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ProgramResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Current authenticated user sends a request to get a role in a university (student/teacher/administration).",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Admit applicants to a university department of the campaign's university with a number of seats",
                 "consumes": [
                     "application/json"
                 ],
@@ -445,54 +557,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admissions"
                 ],
-                "summary": "Request access to join a university",
+                "summary": "Add program to campaign",
                 "parameters": [
                     {
-                        "description": "Access request",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Program",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.RequestAccessToUniversity"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.AddProgramRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Campaign not found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Department not found, program already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/admissions/programs/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Decline access request for user. Admin role required.",
+                "description": "Change seats of the program, seats can't be less than accepted applications",
                 "consumes": [
                     "application/json"
                 ],
@@ -500,365 +639,552 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admissions"
                 ],
-                "summary": "Reject access request",
+                "summary": "Update program seats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Request ID",
-                        "name": "request_id",
-                        "in": "query",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Seats",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.UpdateProgramRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/admin/personalities/access/accept": {
-            "post": {
-                "description": "Accept Request of user that want to be (student/teacher/administration), for student field university_department_id is required, course_group_id can be skipped. For administrations university_id is required, faculty_id can be skipped",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admissions"
                 ],
-                "summary": "Accept Request for adding in University",
+                "summary": "Delete program",
                 "parameters": [
                     {
-                        "description": "Access request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AcceptAccessRequest"
-                        }
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Program has applications",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/auth/checkToken": {
+        "/admin/admissions/programs/{id}/applications": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Verify if the provided JWT access token is valid",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Applications of the program ranked by verified score, then by submission time. Applications without a verified score go after scored ones, rejected applications have rank 0 and go last",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admissions"
+                ],
+                "summary": "Get ranked list of program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Check JWT token validity",
                 "responses": {
                     "200": {
-                        "description": "status: token is valid",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.RankedApplicationResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user using MAX WebApp init data and return JWT tokens",
-                "consumes": [
-                    "application/x-www-form-urlencoded"
+        "/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Changes of administrative data made in the admin's university, newest first. Every entry has the actor, action (insert/update/delete), entity (schema.table and id) and row before/after the change. Admin role required.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "User login via MAX WebApp",
+                "summary": "Audit log of the admin's university",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit, max(100), default(20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset, default(0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by max_users_data.id of the actor",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "insert",
+                            "update",
+                            "delete"
+                        ],
                         "type": "string",
-                        "description": "Authentication date",
-                        "name": "auth_date",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by action",
+                        "name": "action",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Authentication hash",
-                        "name": "hash",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by entity, schema.table, e.g. schedules.rooms",
+                        "name": "entity_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "User data JSON",
-                        "name": "user",
-                        "in": "formData"
+                        "description": "Filter by entity id",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or after, RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made before, RFC3339",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JWT tokens",
+                        "description": "Audit log page",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_audit.AuditLogResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Invalid init data",
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Refresh access and refresh tokens using a valid refresh token",
-                "consumes": [
-                    "application/json"
+        "/admin/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get bookings of the admin's university ordered by date and pair, pending ones are waiting for a decision",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Refresh JWT tokens",
+                "summary": "Get bookings of university",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_http_handlers.RefreshRequest"
-                        }
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, confirmed, rejected or cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date, 2006-01-02",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date, 2006-01-02",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "New JWT tokens",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.BookingResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Invalid or expired refresh token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/personalities/departments": {
-            "get": {
-                "description": "Get all departments for faculty by faculty ID",
-                "consumes": [
-                    "application/json"
+        "/admin/bookings/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Confirm a pending booking of the admin's university, its pair must still be free of lessons and confirmed bookings",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "bookings"
                 ],
-                "summary": "Get all departments for faculty",
+                "summary": "Confirm booking",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Faculty ID",
-                        "name": "faculty_id",
-                        "in": "query",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Departments",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DepartmentInfoResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameter",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict, booking is not pending or its date has passed",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/personalities/faculty": {
-            "get": {
-                "description": "Get all faculties for university by university ID",
-                "consumes": [
-                    "application/json"
+        "/admin/bookings/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Reject a pending booking of the admin's university",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "bookings"
                 ],
-                "summary": "Get all faculties for university",
+                "summary": "Reject booking",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
-                        "name": "university_id",
-                        "in": "query",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Faculties",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameter",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is not pending",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/personalities/groups": {
+        "/admin/courses": {
             "get": {
-                "description": "Get all course groups for department by department ID",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all courses for the admin's university. Admin role required.",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,52 +1192,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
-                ],
-                "summary": "Get all groups for department",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Department ID",
-                        "name": "department_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "Get all courses for university",
                 "responses": {
                     "200": {
-                        "description": "Groups",
+                        "description": "List of courses",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse"
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CourseInfoResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request parameter",
+                    "401": {
+                        "description": "Unauthorized user",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized user",
+                    "403": {
+                        "description": "Forbidden - user is not admin",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/personalities/student": {
-            "get": {
-                "description": "Get all students for course group by group ID",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new course for a specific university department. Creates entry in universities.courses. Admin role required.",
                 "consumes": [
                     "application/json"
                 ],
@@ -919,278 +1239,281 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admin"
                 ],
-                "summary": "Get all students for group",
+                "summary": "Create new course",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Course Group ID",
-                        "name": "group_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Course data (start_date, end_date, university_department_id required)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateCourseRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Students",
+                        "description": "status: course created successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameter",
+                        "description": "Invalid request body or missing required fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
                         "description": "Unauthorized user",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/personalities/teachers": {
+        "/admin/courses/{id}": {
             "get": {
-                "description": "Get all teachers for university by university ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "personalities"
+                    "admin"
                 ],
-                "summary": "Get all teachers for university",
+                "summary": "Get course",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
-                        "name": "university_id",
-                        "in": "query",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Teachers",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
-                            }
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CourseInfoResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameter",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/personalities/universities": {
-            "get": {
-                "description": "Get all universities where the authenticated user has access (as admin or student)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "personalities"
-                ],
-                "summary": "Get all universities for authenticated person",
-                "responses": {
-                    "200": {
-                        "description": "Universities",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
-                            }
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized user",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/schedules/classes": {
-            "get": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change course dates and year of study and move it to another department of the same university",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "admin"
                 ],
-                "summary": "get classes for university",
+                "summary": "Update course",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
-                        "name": "university_id",
-                        "in": "query",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Course data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateCourseRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.ClassesResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Department not found or course already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             },
-            "post": {
-                "consumes": [
-                    "application/json"
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Soft delete course with its groups",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "admin"
                 ],
-                "summary": "create class (pair) slot",
+                "summary": "Delete course",
                 "parameters": [
                     {
-                        "description": "Class info",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateClassRequest"
-                        }
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "class_id",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/schedules/classes/{class_id}": {
-            "delete": {
-                "tags": [
-                    "schedules"
-                ],
-                "summary": "delete class",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Class ID",
-                        "name": "class_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "ok",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Still referenced by active students or scheduled lessons",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/schedules/lessons": {
-            "post": {
-                "description": "Создать занятие для учебной группы или элективной группы. Учёт конфликтов, лекций и интервалов.",
+        "/admin/curriculum/course-subjects/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -1198,116 +1521,167 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "Create lesson (group schedule entry)",
+                "summary": "Update required hours of course subject",
                 "parameters": [
                     {
-                        "description": "Lesson info",
+                        "type": "integer",
+                        "description": "Course subject ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hours, null to clear",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateLessonRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.UpdateCourseSubjectRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "id",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
-                    "409": {
-                        "description": "Schedule conflict",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/schedules/lessons/{lesson_id}": {
+            },
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "Delete lesson",
+                "summary": "Remove subject from course",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Lesson ID",
-                        "name": "lesson_id",
+                        "description": "Course subject ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid lesson_id",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Teachers are assigned to the subject",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/schedules/rooms": {
+        "/admin/curriculum/courses/{id}/subjects": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "get rooms for university",
+                "summary": "Get subjects of course",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "University ID",
-                        "name": "university_id",
-                        "in": "query",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1316,25 +1690,43 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomsResponse"
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CourseSubjectResponse"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach university subject to a course for a semester with required hours",
                 "consumes": [
                     "application/json"
                 ],
@@ -1342,149 +1734,234 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "create room",
+                "summary": "Add subject to course",
                 "parameters": [
                     {
-                        "description": "Room info",
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subject of course",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateRoomRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AddCourseSubjectRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "id",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Semester or subject not found, subject already added",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/schedules/rooms/{room_id}": {
+        "/admin/curriculum/elective-subjects/{id}": {
             "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "delete room",
+                "summary": "Remove teacher from elective group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Room ID",
-                        "name": "room_id",
+                        "description": "Elective group subject ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Lessons are scheduled for the assignment",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/schedules/users/{user_id}": {
-            "get": {
-                "description": "Возвращает расписание пользователя по max_user_id (и как студента, и как преподавателя).",
+        "/admin/curriculum/electives/{id}/subjects": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returned id is elective_group_subject_id of lessons",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "schedules"
+                    "curriculum"
                 ],
-                "summary": "Get weekly schedule for user",
+                "summary": "Assign teacher to elective group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "MAX user id",
-                        "name": "user_id",
+                        "description": "Elective group ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Assignment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignElectiveTeacherRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.LessonsResponse"
-                            }
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user_id",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Elective group not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Teacher not found or already assigned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/subjects": {
-            "get": {
+        "/admin/curriculum/group-subjects/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all subjects for university by university ID with pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -1492,171 +1969,221 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "curriculum"
                 ],
-                "summary": "Get all subjects by university ID",
+                "summary": "Change teacher of group subject",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 5,
-                        "description": "Limit of requests, max(50), default(5)",
-                        "name": "limit",
-                        "in": "query",
+                        "description": "Group subject ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset, default(0)",
-                        "name": "offset",
-                        "in": "query",
-                        "required": true
+                        "description": "Assignment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.UpdateAssignmentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of subjects",
+                        "description": "status: ok",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_subjects.SubjectsResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Teacher not found or already assigned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new subject for the university. Admin role required.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "curriculum"
                 ],
-                "summary": "Create subject for university",
+                "summary": "Remove teacher from group subject",
                 "parameters": [
                     {
-                        "description": "Subject data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_subjects.CreateSubjectRequest"
-                        }
+                        "type": "integer",
+                        "description": "Group subject ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "status: ok",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Lessons are scheduled for the assignment",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/curriculum/groups/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete subject by subject ID. Admin role required.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get subjects of the group's course with teachers assigned to the group, subjects without teacher have no assignment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "subjects"
+                    "curriculum"
                 ],
-                "summary": "Delete subject",
+                "summary": "Get curriculum of group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Subject ID",
-                        "name": "subject_id",
-                        "in": "query",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CurriculumItemResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/universities/": {
-            "get": {
+        "/admin/curriculum/groups/{id}/subjects": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of all universities with their detailed information",
+                "description": "Assign teacher of the university to teach a course subject of given type to a group. Returned id is course_group_subject_id of lessons.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1664,77 +2191,127 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "curriculum"
+                ],
+                "summary": "Assign teacher to group subject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignTeacherRequest"
+                        }
+                    }
                 ],
-                "summary": "Get all universities",
                 "responses": {
                     "200": {
-                        "description": "List of universities",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
-                            }
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Group not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Subject or teacher not found, subject is elective, already assigned",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - failed to get universities",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/universities/events": {
+        "/admin/curriculum/semesters": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all events for the university associated with the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get semesters of the admin's universities",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "curriculum"
                 ],
-                "summary": "Get all events for user's university",
+                "summary": "Get semesters",
                 "responses": {
                     "200": {
-                        "description": "List of events",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.EventResponse"
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.SemesterResponse"
                             }
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - user not authenticated",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/department": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new event for the university. Admin role required.",
+                "description": "Create a new department and link it to a specific faculty and university. Creates entry in universities.departments and universities.university_departments. Admin role required.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1742,23 +2319,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "admin"
                 ],
-                "summary": "Create new event",
+                "summary": "Create new department",
                 "parameters": [
                     {
-                        "description": "Event data",
+                        "description": "Department data (department_name required, department_code and alias_name optional)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateEventRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateDepartmentRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: event created successfully",
+                        "description": "status: department created successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1769,78 +2346,100 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or missing required fields",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
                         "description": "Unauthorized user",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
                         "description": "Forbidden - user is not admin",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/universities/info": {
+        "/admin/department/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about the university associated with the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get department opened in a faculty by university_department_id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "admin"
+                ],
+                "summary": "Get university department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get university information for current user",
                 "responses": {
                     "200": {
-                        "description": "University information",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniversityDepartmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - user not authenticated",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - failed to get university info",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/universities/semesters": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create or replace semester periods for specific university. Admin role required. This operation will delete all existing semesters for the university and create new ones.",
+                "description": "Change alias name and study years of department and move it to another faculty of the same university",
                 "consumes": [
                     "application/json"
                 ],
@@ -1848,23 +2447,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "admin"
                 ],
-                "summary": "Create new semester periods for university",
+                "summary": "Update university department",
                 "parameters": [
                     {
-                        "description": "Semester periods data",
+                        "type": "integer",
+                        "description": "University department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Department data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateSemestersRequest"
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateDepartmentRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: semesters created successfully",
+                        "description": "status: ok",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1873,475 +2479,6988 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or date format",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized user",
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Forbidden - user is not admin",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Faculty not found",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/user/me": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get detailed information about the authenticated user including roles",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Soft delete department with its courses and groups",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin"
+                ],
+                "summary": "Delete university department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get current user information",
                 "responses": {
                     "200": {
-                        "description": "User information with roles",
+                        "description": "status: ok",
                         "schema": {
-                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UserInfoResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced by active students or scheduled lessons",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/electives/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get elective groups of the admin's universities with enrolled students and free seats",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Get elective groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.GroupResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create elective group of a semester for a university subject, capacity omitted is unlimited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Create elective group",
+                "parameters": [
+                    {
+                        "description": "Elective group",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Semester or subject not found, group already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/electives/groups/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename elective group and change its capacity, capacity can't be less than enrolled students",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Update elective group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Elective group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Elective group",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.UpdateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Delete elective group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Elective group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Students, teachers or preferences reference the group",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/electives/windows": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get selection windows of the admin's universities",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Get selection windows",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.WindowResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create selection window of electives for a semester, first_come enrolls students at once while seats last, lottery collects ranked preferences and enrolls by draw after the window closes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Open selection window",
+                "parameters": [
+                    {
+                        "description": "Selection window",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.CreateWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Semester not found, semester already has a window",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/electives/windows/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change dates and max choices of a selection window, a drawn window can't be changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Update selection window",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Selection window ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Selection window",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.UpdateWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Lottery is already drawn",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/electives/windows/{id}/draw": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enroll students of a closed lottery window by their ranked preferences in random order, the seed is saved to reproduce the draw",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Draw lottery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Selection window ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seed of the draw",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.DrawRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.DrawResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Window is not a lottery, not closed or already drawn",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exam-sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get exam sessions of the admin's university with the number of their exams, the latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Get exam sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.SessionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an exam session of a semester of the admin's university, its dates must be within the semester.\nExams of a group in the session are at least min_gap_days days apart. Students see the session once it is published",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Create exam session",
+                "parameters": [
+                    {
+                        "description": "Exam session",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.CreateSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exam-sessions/{id}/exams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get exams of an exam session of the admin's university ordered by date and time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Get exams of session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.ExamResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule an exam or a test of a course group in a subject of the session's semester on a date of the session.\nA group sits at most one exam a day and its exams are at least min_gap_days days apart. Rooms must be free of exams,\nlessons and confirmed bookings at that time, the examiner must be free of exams and lessons",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Create exam",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exam",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.CreateExamRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict, session, subject, group, examiner or room not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exam-sessions/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish an exam session, its exams appear in timetables of students in the API and the bot. Publishing twice keeps the first date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Publish exam session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exams/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an exam of the admin's university with its rooms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Delete exam",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exam ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exports/{kind}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export university data as CSV (semicolon separated UTF-8 with BOM), XLSX or JSON array, the file is streamed as rows are read.\nColumns by kind:\nrosters: max_user_id, username, first_name, last_name, role, group_id, group, department, year_of_study, start_date, end_date — active students of live groups, the file may be imported back;\nteachers: max_user_id, username, first_name, last_name, role;\ntimetable: group, elective, room, day, pair_number, start_time, end_time, interval, subject, subject_type, teacher — lessons of course and elective groups ordered by group, or by room with the room column first;\naccess_requests: max_user_id, username, first_name, last_name, role, requested_at, status (accepted, pending or rejected now), rejected_at, rejected_by.\nCells starting with =, +, -, @, tab or CR are prefixed with ' in CSV and XLSX, so spreadsheets don't run them as formulas.\nA failure in the middle of the stream cuts the file short.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "rosters, teachers, timetable or access_requests",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default), xlsx or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Course group ID, for rosters and timetable",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Room ID, for timetable",
+                        "name": "room_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timetable order: group (default) or room",
+                        "name": "by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/faculties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all faculties for the university associated with the authenticated admin user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get all faculties for admin's university",
+                "responses": {
+                    "200": {
+                        "description": "List of faculties",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new faculty for the university. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create new faculty",
+                "parameters": [
+                    {
+                        "description": "Faculty data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateNewFacultyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: faculty created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/faculties/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get faculty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Rename faculty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Faculty data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateFacultyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Faculty with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete faculty with its departments, courses and groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete faculty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced by active students or scheduled lessons",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get groups of the admin's university, of one course if course_id is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "course_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new course group for a specific course. Creates entry in groups.course_groups. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create new course group",
+                "parameters": [
+                    {
+                        "description": "Group data (group_name and course_id required)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: group created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rename group and move it to another course of the same university",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Course not found or group already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced by active students or scheduled lessons",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived access token of a user of the admin's university. The token carries act claim with the admin id, every request made with it is written to the impersonation audit, mutating requests are refused. No refresh token is issued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "View as user",
+                "parameters": [
+                    {
+                        "description": "User to act as",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Impersonation token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ImpersonateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is an admin or belongs to a university the caller does not administer",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/imports/{kind}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import CSV (comma or semicolon separated) or XLSX file with a header row into the university, column names are case insensitive.\nColumns by kind:\nrooms: room;\nclasses: pair_number, start_time, end_time (HH:MM);\nsubjects: name;\ngroups: department (code or alias name), start_date, end_date (YYYY-MM-DD), year_of_study (optional), name;\nrosters: max_user_id or username, role (student or teacher, student by default), group or group_id for students, first_name and last_name to create unknown users.\nEvery row is validated, invalid rows are reported and valid rows are applied in one transaction.\nIn create mode rows with existing natural keys are errors, in upsert mode they are updated or left unchanged, so a file may be imported again.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Import file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "rooms, classes, subjects, groups or rosters",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "create (default) or upsert",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file, up to 10 MB and 10000 rows",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_imports.ImportReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or missing columns",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body is larger than 10 MB",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/personalities/access": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all access requests to join university (student/teacher/administration). Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all access requests for administration",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Limit of requests, max(50), default(5)",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset, default(0)",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Requests for administration",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AccessRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Current authenticated user sends a request to get a role in a university (student/teacher/administration).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Request access to join a university",
+                "parameters": [
+                    {
+                        "description": "Access request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.RequestAccessToUniversity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decline access request for user. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Reject access request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "request_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/personalities/access/accept": {
+            "post": {
+                "description": "Accept Request of user that want to be (student/teacher/administration), for student field university_department_id is required, course_group_id can be skipped. For administrations university_id is required, faculty_id can be skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Accept Request for adding in University",
+                "parameters": [
+                    {
+                        "description": "Access request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AcceptAccessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rollovers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the university to the new academic year in one transaction, nothing is changed on failure.\nDoes exactly what the preview reports, courses are rolled over once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Roll over academic year",
+                "parameters": [
+                    {
+                        "description": "New academic year",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.RolloverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Promoted group name is taken in the next year course",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rollovers/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report what the rollover of the university to the new academic year would do, nothing is changed.\nCourses finished before start_date are rolled over: groups are promoted to the next year course, final year students are graduated.\nLessons of semesters finished before start_date are archived. Finished courses without year_of_study are skipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Preview academic year rollover",
+                "parameters": [
+                    {
+                        "description": "New academic year",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.RolloverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Promoted group name is taken in the next year course",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/timetables/{kind}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "PDF with the week grid of a course group, a teacher or a room: days are columns, pairs of the bell schedule are rows,\ncells have subject, type, teacher, groups and room. Cells with every two week lessons are split into odd and even weeks,\nthe first created lesson of a group in the pair goes on odd weeks. Parity is not stored, so the split is nominal and the PDF says so.\nAdmin of the university is required.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Printable timetable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group, teacher or room",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "course_groups.id, teachers.id or rooms.id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Group, teacher or room not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/universities/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get university administrated by the current admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Update university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "University data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateUniversityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "University with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete university with its faculties, departments, courses and groups",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced by active students or scheduled lessons",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admissions/applications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get applications of the user with status, place in the ranked list of the program and status history",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admissions"
+                ],
+                "summary": "Get my applications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ApplicationResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply to a program of an open campaign, one application per program. The score is entered by the admin after checking exam results",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admissions"
+                ],
+                "summary": "Submit application",
+                "parameters": [
+                    {
+                        "description": "Application",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ApplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Program not found, campaign is closed, already applied",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admissions/campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get campaigns accepting applications now with their programs: faculty, department, seats and applications",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admissions"
+                ],
+                "summary": "Get open admission campaigns",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CampaignProgramsResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/checkToken": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Verify if the provided JWT access token is valid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Check JWT token validity",
+                "responses": {
+                    "200": {
+                        "description": "status: token is valid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/dev/login": {
+            "post": {
+                "description": "Issue tokens for the fixture user or for given max_users_data.id. Available only with server.is_devel and auth.dev_login in non-production builds, otherwise 404.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Development login without MAX init data",
+                "parameters": [
+                    {
+                        "description": "User ID, fixture user if empty",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DevLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JWT tokens",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Dev login disabled or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user using MAX WebApp init data and return JWT tokens",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "User login via MAX WebApp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authentication date, unix time. Older than auth.init_data_max_age is rejected",
+                        "name": "auth_date",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authentication hash",
+                        "name": "hash",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "WebApp session ID, every init data is accepted only once",
+                        "name": "query_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User data JSON",
+                        "name": "user",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JWT tokens",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid init data",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End all sessions of the user: refresh tokens are deleted and access tokens issued so far stop working on every instance.\nNot available to impersonation tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Impersonation token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Refresh access and refresh tokens using a valid refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh JWT tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New JWT tokens",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get bookings requested by the user with their status, the latest dates first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Get my bookings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.BookingResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book a room for a pair of the bell schedule on a date for a meeting, an exam or a club event. Admins, teachers and active students\nof the room's university may book. Bookings of admins are confirmed at once, others are pending until an admin decides.\nThe pair must be free of lessons of the semester covering the date, every two week lessons included, and of confirmed bookings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Book room",
+                "parameters": [
+                    {
+                        "description": "Booking",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.CreateBookingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the university",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict, room or pair not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a pending or confirmed booking requested by the user, admins of the university may cancel any of its bookings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Cancel booking",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is already rejected or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/electives/windows": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get selection windows of current and future semesters of the student's universities",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Get my selection windows",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.WindowResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/electives/windows/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get electives offered to the student's course in the window with free seats, the student's choices and lessons clashing with the student's group schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Get my selection window",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Selection window ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.StudentWindowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a student of the window's university",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/electives/windows/{id}/choices": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the student's choices in an open window. In a first_come window the student is enrolled at once if seats are left, in a lottery window choices are preferences in the given order. Clashes with the student's group schedule are returned as warnings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "electives"
+                ],
+                "summary": "Choose electives",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Selection window ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Chosen elective groups",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ChoicesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ChoicesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Too many, duplicate or not offered electives",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a student of the window's university",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Window is closed, group is full",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the exam timetable of the student: exams of the student's groups in published sessions that are not over, ordered by date and time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exams"
+                ],
+                "summary": "Get my exams",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.StudentExamResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns 200 while the process serves HTTP, dependencies are not checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/departments": {
+            "get": {
+                "description": "Get all departments for faculty by faculty ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all departments for faculty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Faculty ID",
+                        "name": "faculty_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Departments",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DepartmentInfoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/faculty": {
+            "get": {
+                "description": "Get all faculties for university by university ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all faculties for university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Faculties",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/groups": {
+            "get": {
+                "description": "Get all course groups for department by department ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all groups for department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Department ID",
+                        "name": "department_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Groups",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/student": {
+            "get": {
+                "description": "Get all students for course group by group ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all students for group",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course Group ID",
+                        "name": "group_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Students",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/teachers": {
+            "get": {
+                "description": "Get all teachers for university by university ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all teachers for university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teachers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/personalities/universities": {
+            "get": {
+                "description": "Get all universities where the authenticated user has access (as admin or student)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "personalities"
+                ],
+                "summary": "Get all universities for authenticated person",
+                "responses": {
+                    "200": {
+                        "description": "Universities",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Pings the database and reports bot status. Returns 503 if the database is unavailable, the bot doesn't affect readiness",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/classes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "get classes for university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.ClassesResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "create class (pair) slot",
+                "parameters": [
+                    {
+                        "description": "Class info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "class_id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/classes/{class_id}": {
+            "delete": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "delete class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/lessons": {
+            "post": {
+                "description": "Создать занятие для учебной группы или элективной группы. Учёт конфликтов, лекций и интервалов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Create lesson (group schedule entry)",
+                "parameters": [
+                    {
+                        "description": "Lesson info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateLessonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/lessons/{lesson_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Delete lesson",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Lesson ID",
+                        "name": "lesson_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid lesson_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/rooms": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "get rooms for university",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomsResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "create room",
+                "parameters": [
+                    {
+                        "description": "Room info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "id",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/rooms/occupancy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rooms of the university with pairs they are taken in the week of date from monday to sunday: lessons of semesters covering\nthe day and pending or confirmed bookings. Every two week lessons are listed on every week. Rooms without slots are free all week.\nOnly admins, teachers and active students of the university see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Room occupancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Any date of the week as 2006-01-02, today by default",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomOccupancyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member of the university",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/rooms/{room_id}": {
+            "delete": {
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "delete room",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "room_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/users/{user_id}": {
+            "get": {
+                "description": "Возвращает расписание пользователя по max_user_id (и как студента, и как преподавателя).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Get weekly schedule for user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "MAX user id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.LessonsResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all subjects for university by university ID with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Get all subjects by university ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Limit of requests, max(50), default(5)",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset, default(0)",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of subjects",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_subjects.SubjectsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new subject for the university. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Create subject for university",
+                "parameters": [
+                    {
+                        "description": "Subject data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_subjects.CreateSubjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete subject by subject ID. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subjects"
+                ],
+                "summary": "Delete subject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subject ID",
+                        "name": "subject_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/universities/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of all universities with their detailed information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "universities"
+                ],
+                "summary": "Get all universities",
+                "responses": {
+                    "200": {
+                        "description": "List of universities",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - failed to get universities",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/universities/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all events for the university associated with the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "universities"
+                ],
+                "summary": "Get all events for user's university",
+                "responses": {
+                    "200": {
+                        "description": "List of events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.EventResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new event for the university. Admin role required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "universities"
+                ],
+                "summary": "Create new event",
+                "parameters": [
+                    {
+                        "description": "Event data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: event created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/universities/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about the university associated with the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "universities"
+                ],
+                "summary": "Get university information for current user",
+                "responses": {
+                    "200": {
+                        "description": "University information",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - user not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - failed to get university info",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/universities/semesters": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace semester periods for specific university. Admin role required. This operation will delete all existing semesters for the university and create new ones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "universities"
+                ],
+                "summary": "Create new semester periods for university",
+                "parameters": [
+                    {
+                        "description": "Semester periods data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateSemestersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: semesters created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or date format",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - user is not admin",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get detailed information about the authenticated user including roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get current user information",
+                "responses": {
+                    "200": {
+                        "description": "User information with roles",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UserInfoResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized user",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CourseInfoResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "university_department_id": {
+                    "type": "integer"
+                },
+                "year_of_study": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateCourseRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date",
+                "university_department_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "university_department_id": {
+                    "type": "integer"
+                },
+                "year_of_study": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateDepartmentRequest": {
+            "type": "object",
+            "required": [
+                "department_name",
+                "faculty_id",
+                "university_id"
+            ],
+            "properties": {
+                "alias_name": {
+                    "type": "string",
+                    "maxLength": 125
+                },
+                "department_code": {
+                    "type": "string",
+                    "maxLength": 125
+                },
+                "department_name": {
+                    "type": "string",
+                    "maxLength": 125
+                },
+                "faculty_id": {
+                    "type": "integer"
+                },
+                "university_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateEventRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "photo_url",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateGroupRequest": {
+            "type": "object",
+            "required": [
+                "course_id",
+                "group_name"
+            ],
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "group_name": {
+                    "type": "string",
+                    "maxLength": 125
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateNewFacultyRequest": {
+            "type": "object",
+            "required": [
+                "faculty_name"
+            ],
+            "properties": {
+                "faculty_name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "FITIP"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateSemestersRequest": {
+            "type": "object",
+            "required": [
+                "periods",
+                "uni_id"
+            ],
+            "properties": {
+                "periods": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.SemesterPeriod"
+                    }
+                },
+                "uni_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DepartmentInfoResponse": {
+            "type": "object",
+            "required": [
+                "code",
+                "department_name",
+                "faculty_name",
+                "id"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "09.03.02"
+                },
+                "department_name": {
+                    "type": "string",
+                    "example": "Sowtware Engineering"
+                },
+                "faculty_name": {
+                    "type": "string",
+                    "example": "FITIP"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DevLoginRequest": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.EventResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "university_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "department_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ImpersonateResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-01-01T12:15:00Z"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                },
+                "user_roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "bot": {
+                    "type": "string",
+                    "example": "running"
+                },
+                "database": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.SemesterPeriod": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2006-12-23T00:00:00Z"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2005-12-23T00:00:00Z"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse": {
+            "type": "object",
+            "required": [
+                "city",
+                "id",
+                "uni_name"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Saint-Petersburg"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "One of the leading Russian universities in the field of information technology, optical design, and engineering."
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://itmo.ru/images/itmo.jpg"
+                },
+                "site_url": {
+                    "type": "string",
+                    "example": "https://itmo.ru"
+                },
+                "uni_name": {
+                    "type": "string",
+                    "example": "ITMO University"
+                },
+                "uni_short_name": {
+                    "type": "string",
+                    "example": "ITMO"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniversityDepartmentResponse": {
+            "type": "object",
+            "properties": {
+                "alias_name": {
+                    "type": "string",
+                    "example": "SE"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "09.03.04"
+                },
+                "department_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "department_name": {
+                    "type": "string",
+                    "example": "Software Engineering"
+                },
+                "faculty_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "study_years": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateCourseRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date",
+                "university_department_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2029-06-30"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "university_department_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "year_of_study": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateDepartmentRequest": {
+            "type": "object",
+            "required": [
+                "faculty_id"
+            ],
+            "properties": {
+                "alias_name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "SE"
+                },
+                "faculty_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "study_years": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateFacultyRequest": {
+            "type": "object",
+            "required": [
+                "faculty_name"
+            ],
+            "properties": {
+                "faculty_name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "FITIP"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateGroupRequest": {
+            "type": "object",
+            "required": [
+                "course_id",
+                "group_name"
+            ],
+            "properties": {
+                "course_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "group_name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "M3101"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UpdateUniversityRequest": {
+            "type": "object",
+            "required": [
+                "uni_name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "One of the leading Russian universities."
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://itmo.ru/images/itmo.jpg"
+                },
+                "site_url": {
+                    "type": "string",
+                    "example": "https://itmo.ru"
+                },
+                "uni_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "ITMO University"
+                },
+                "uni_short_name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "ITMO"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User": {
+            "type": "object",
+            "required": [
+                "first_name",
+                "id"
+            ],
+            "properties": {
+                "first_name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "language_code": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://example.com/photo.jpg"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UserInfoResponse": {
+            "type": "object",
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                },
+                "user_roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.AddProgramRequest": {
+            "type": "object",
+            "required": [
+                "seats",
+                "university_department_id"
+            ],
+            "properties": {
+                "seats": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "university_department_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CampaignResponse"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-06-21T10:00:00+03:00"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.StatusChangeResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 77
+                },
+                "program": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ProgramResponse"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 27
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 271
+                },
+                "status": {
+                    "type": "string",
+                    "example": "waitlisted"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ApplyRequest": {
+            "type": "object",
+            "required": [
+                "program_id"
+            ],
+            "properties": {
+                "program_id": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CampaignProgramsResponse": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-07-25T18:00:00+03:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "is_open": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bachelor 2026"
+                },
+                "programs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ProgramResponse"
+                    }
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-06-20T00:00:00+03:00"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CampaignResponse": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-07-25T18:00:00+03:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "is_open": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bachelor 2026"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-06-20T00:00:00+03:00"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ChangeStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Documents are checked"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "under_review"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.CreateCampaignRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "name",
+                "starts_at",
+                "university_id"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-07-25T18:00:00+03:00"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Bachelor 2026"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-06-20T00:00:00+03:00"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.EnrollRequest": {
+            "type": "object",
+            "required": [
+                "course_group_id"
+            ],
+            "properties": {
+                "course_group_id": {
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.EnrollResponse": {
+            "type": "object",
+            "properties": {
+                "student_id": {
+                    "type": "integer",
+                    "example": 301
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.ProgramResponse": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "applications": {
+                    "type": "integer",
+                    "example": 140
+                },
+                "campaign_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "department_name": {
+                    "type": "string",
+                    "example": "Information systems and technologies"
+                },
+                "faculty_name": {
+                    "type": "string",
+                    "example": "Faculty of Software Engineering"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "seats": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "university_department_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.RankedApplicationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-06-21T10:00:00+03:00"
+                },
+                "first_name": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 77
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Petrov"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 271
+                },
+                "status": {
+                    "type": "string",
+                    "example": "under_review"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 301
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.SetScoreRequest": {
+            "type": "object",
+            "properties": {
+                "score": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 271
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.StatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Documents are checked"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-06-22T12:00:00+03:00"
+                },
+                "from_status": {
+                    "type": "string",
+                    "example": "submitted"
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "under_review"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.UpdateCampaignRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "name",
+                "starts_at"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-07-25T18:00:00+03:00"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Bachelor 2026"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-06-20T00:00:00+03:00"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_admissions.UpdateProgramRequest": {
+            "type": "object",
+            "required": [
+                "seats"
+            ],
+            "properties": {
+                "seats": {
+                    "type": "integer",
+                    "example": 30
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_audit.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_audit.Entry"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_audit.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01T12:00:00Z"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string",
+                    "example": "schedules.rooms"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "impersonator_id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.BookingResponse": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Need a projector"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-30T09:00:00+03:00"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-11-12"
+                },
+                "decided_at": {
+                    "type": "string",
+                    "example": "2026-11-01T12:00:00+03:00"
+                },
+                "decided_by": {
+                    "type": "integer",
+                    "example": 987654321
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "12:10"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "club"
+                },
+                "requested_by": {
+                    "type": "integer",
+                    "example": 123456789
+                },
+                "requester_first_name": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "requester_last_name": {
+                    "type": "string",
+                    "example": "Petrov"
+                },
+                "room": {
+                    "type": "string",
+                    "example": "A-101"
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "10:40"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chess club"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.CreateBookingRequest": {
+            "type": "object",
+            "required": [
+                "class_id",
+                "date",
+                "purpose",
+                "room_id",
+                "title"
+            ],
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Need a projector"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-11-12"
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "club"
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Chess club"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_bookings.CreateBookingResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AddCourseSubjectRequest": {
+            "type": "object",
+            "required": [
+                "semester_id",
+                "university_subject_id"
+            ],
+            "properties": {
+                "is_elective": {
+                    "type": "boolean"
+                },
+                "required_hours": {
+                    "type": "integer",
+                    "example": 72
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "university_subject_id": {
+                    "type": "integer",
+                    "example": 17
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignElectiveTeacherRequest": {
+            "type": "object",
+            "required": [
+                "subject_type",
+                "teacher_user_id"
+            ],
+            "properties": {
+                "subject_type": {
+                    "type": "string",
+                    "example": "seminar"
+                },
+                "teacher_user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignTeacherRequest": {
+            "type": "object",
+            "required": [
+                "course_subject_id",
+                "subject_type",
+                "teacher_user_id"
+            ],
+            "properties": {
+                "course_subject_id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "subject_type": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "teacher_user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 55
+                },
+                "subject_type": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "teacher": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.TeacherResponse"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CourseSubjectResponse": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "is_elective": {
+                    "type": "boolean"
+                },
+                "required_hours": {
+                    "type": "integer",
+                    "example": 72
+                },
+                "semester_end": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "semester_start": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Linear algebra"
+                },
+                "university_subject_id": {
+                    "type": "integer",
+                    "example": 17
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CreatedResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 55
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CurriculumItemResponse": {
+            "type": "object",
+            "properties": {
+                "assignment": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.AssignmentResponse"
+                },
+                "subject": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.CourseSubjectResponse"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.SemesterResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-01-31"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.TeacherResponse": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string",
+                    "example": "Ivan"
+                },
+                "last_name": {
+                    "type": "string",
+                    "example": "Petrov"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.UpdateAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "subject_type",
+                "teacher_user_id"
+            ],
+            "properties": {
+                "subject_type": {
+                    "type": "string",
+                    "example": "practice"
+                },
+                "teacher_user_id": {
+                    "type": "integer",
+                    "example": 123456789
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_curriculum.UpdateCourseSubjectRequest": {
+            "type": "object",
+            "properties": {
+                "required_hours": {
+                    "type": "integer",
+                    "example": 72
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ChoicesRequest": {
+            "type": "object",
+            "properties": {
+                "elective_group_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        15
+                    ]
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ChoicesResponse": {
+            "type": "object",
+            "properties": {
+                "clashes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ClashResponse"
+                    }
+                },
+                "enrolled": {
+                    "description": "Enrolled are groups the student is enrolled in",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     },
-                    "401": {
-                        "description": "Unauthorized user",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
+                    "example": [
+                        12
+                    ]
+                },
+                "preferences": {
+                    "description": "Preferences are ranked choices of a lottery window waiting for the draw",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
+                    "example": [
+                        15,
+                        12
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "echo.HTTPError": {
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ClashResponse": {
             "type": "object",
             "properties": {
-                "message": {}
+                "day": {
+                    "type": "string",
+                    "example": "monday"
+                },
+                "elective_group_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "elective_interval": {
+                    "type": "string",
+                    "example": "every week"
+                },
+                "elective_subject": {
+                    "type": "string",
+                    "example": "Machine learning"
+                },
+                "group_interval": {
+                    "type": "string",
+                    "example": "every two week"
+                },
+                "group_subject": {
+                    "type": "string",
+                    "example": "Linear algebra"
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 3
+                }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CourseInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.CreateGroupRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "semester_id",
+                "university_subject_id"
+            ],
             "properties": {
-                "end_date": {
-                    "type": "string"
+                "capacity": {
+                    "type": "integer",
+                    "example": 30
                 },
-                "id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Machine learning, group 1"
                 },
-                "start_date": {
-                    "type": "string"
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "university_department_id": {
-                    "type": "integer"
+                "university_subject_id": {
+                    "type": "integer",
+                    "example": 17
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateCourseRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.CreateWindowRequest": {
             "type": "object",
             "required": [
-                "end_date",
-                "start_date",
-                "university_department_id"
+                "closes_at",
+                "max_choices",
+                "mode",
+                "opens_at",
+                "semester_id"
             ],
             "properties": {
-                "end_date": {
-                    "type": "string"
+                "closes_at": {
+                    "type": "string",
+                    "example": "2025-08-27T23:59:00+03:00"
                 },
-                "start_date": {
-                    "type": "string"
+                "max_choices": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "university_department_id": {
-                    "type": "integer"
+                "mode": {
+                    "type": "string",
+                    "example": "first_come"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2025-08-20T09:00:00+03:00"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateDepartmentRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.DrawRequest": {
+            "type": "object",
+            "properties": {
+                "seed": {
+                    "description": "Seed of the draw, a random one is used if omitted",
+                    "type": "integer",
+                    "example": 8731502846
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.DrawResponse": {
+            "type": "object",
+            "properties": {
+                "enrolled": {
+                    "type": "integer",
+                    "example": 118
+                },
+                "window": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.WindowResponse"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "enrolled": {
+                    "type": "integer",
+                    "example": 27
+                },
+                "free_seats": {
+                    "description": "FreeSeats is omitted for groups without capacity",
+                    "type": "integer",
+                    "example": 3
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Machine learning, group 1"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Machine learning"
+                },
+                "university_subject_id": {
+                    "type": "integer",
+                    "example": 17
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.StudentWindowResponse": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ChoicesResponse"
+                },
+                "clashes": {
+                    "description": "Clashes are for all groups of the window, to warn before choosing",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.ClashResponse"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.GroupResponse"
+                    }
+                },
+                "window": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.WindowResponse"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.UpdateGroupRequest": {
             "type": "object",
             "required": [
-                "department_name",
-                "faculty_id",
-                "university_id"
+                "name"
             ],
             "properties": {
-                "alias_name": {
-                    "type": "string"
+                "capacity": {
+                    "type": "integer",
+                    "example": 30
                 },
-                "department_code": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Machine learning, group 1"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.UpdateWindowRequest": {
+            "type": "object",
+            "required": [
+                "closes_at",
+                "max_choices",
+                "opens_at"
+            ],
+            "properties": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "2025-08-27T23:59:00+03:00"
                 },
-                "department_name": {
-                    "type": "string"
+                "max_choices": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "faculty_id": {
-                    "type": "integer"
+                "opens_at": {
+                    "type": "string",
+                    "example": "2025-08-20T09:00:00+03:00"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_electives.WindowResponse": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "2025-08-27T23:59:00+03:00"
+                },
+                "drawn_at": {
+                    "type": "string",
+                    "example": "2025-08-28T10:00:00+03:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "is_open": {
+                    "type": "boolean"
+                },
+                "lottery_seed": {
+                    "description": "LotterySeed reproduces the draw, it is set once the lottery is drawn",
+                    "type": "integer",
+                    "example": 8731502846
+                },
+                "max_choices": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "lottery"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "2025-08-20T09:00:00+03:00"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
                 },
                 "university_id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateEventRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.CreateExamRequest": {
             "type": "object",
             "required": [
-                "description",
-                "photo_url",
-                "title"
+                "course_group_id",
+                "course_semester_subject_id",
+                "date",
+                "end_time",
+                "examiner_user_id",
+                "kind",
+                "room_ids",
+                "start_time"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "course_group_id": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "photo_url": {
-                    "type": "string"
+                "course_semester_subject_id": {
+                    "type": "integer",
+                    "example": 12
                 },
-                "title": {
-                    "type": "string"
+                "date": {
+                    "type": "string",
+                    "example": "2027-01-14"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "12:00"
+                },
+                "examiner_user_id": {
+                    "type": "integer",
+                    "example": 987654321
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "exam"
+                },
+                "room_ids": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        4
+                    ]
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "09:00"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateGroupRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.CreateSessionRequest": {
             "type": "object",
             "required": [
-                "course_id",
-                "group_name"
+                "ends_on",
+                "name",
+                "semester_id",
+                "starts_on"
             ],
             "properties": {
-                "course_id": {
-                    "type": "integer"
+                "ends_on": {
+                    "type": "string",
+                    "example": "2027-01-31"
                 },
-                "group_name": {
-                    "type": "string"
+                "min_gap_days": {
+                    "type": "integer",
+                    "maximum": 14,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 125,
+                    "example": "Winter session 2026/2027"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "starts_on": {
+                    "type": "string",
+                    "example": "2027-01-11"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateNewFacultyRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.ExamResponse": {
+            "type": "object",
+            "properties": {
+                "course_group_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "course_semester_subject_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2027-01-14"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "thursday"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "12:00"
+                },
+                "examiner_first_name": {
+                    "type": "string",
+                    "example": "Anna"
+                },
+                "examiner_last_name": {
+                    "type": "string",
+                    "example": "Smirnova"
+                },
+                "examiner_user_id": {
+                    "type": "integer",
+                    "example": 987654321
+                },
+                "group": {
+                    "type": "string",
+                    "example": "CS-101"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "exam"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.ExamRoom"
+                    }
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Mathematical analysis"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.ExamRoom": {
             "type": "object",
-            "required": [
-                "faculty_name"
-            ],
             "properties": {
-                "faculty_name": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "room": {
                     "type": "string",
-                    "example": "FITIP"
+                    "example": "A-101"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.CreateSemestersRequest": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.SessionResponse": {
             "type": "object",
-            "required": [
-                "periods",
-                "uni_id"
-            ],
             "properties": {
-                "periods": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.SemesterPeriod"
-                    }
+                "ends_on": {
+                    "type": "string",
+                    "example": "2027-01-31"
                 },
-                "uni_id": {
-                    "type": "integer"
+                "exams": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "min_gap_days": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Winter session 2026/2027"
+                },
+                "published": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2026-12-20T12:00:00+03:00"
+                },
+                "semester_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "starts_on": {
+                    "type": "string",
+                    "example": "2027-01-11"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.DepartmentInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.StudentExamResponse": {
             "type": "object",
-            "required": [
-                "code",
-                "department_name",
-                "faculty_name",
-                "id"
-            ],
             "properties": {
-                "code": {
+                "course_group_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "course_semester_subject_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "date": {
                     "type": "string",
-                    "example": "09.03.02"
+                    "example": "2027-01-14"
                 },
-                "department_name": {
+                "day": {
                     "type": "string",
-                    "example": "Sowtware Engineering"
+                    "example": "thursday"
                 },
-                "faculty_name": {
+                "end_time": {
                     "type": "string",
-                    "example": "FITIP"
+                    "example": "12:00"
+                },
+                "examiner_first_name": {
+                    "type": "string",
+                    "example": "Anna"
+                },
+                "examiner_last_name": {
+                    "type": "string",
+                    "example": "Smirnova"
+                },
+                "examiner_user_id": {
+                    "type": "integer",
+                    "example": 987654321
+                },
+                "group": {
+                    "type": "string",
+                    "example": "CS-101"
                 },
                 "id": {
                     "type": "integer",
-                    "example": 123456789
+                    "example": 21
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "exam"
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_exams.ExamRoom"
+                    }
+                },
+                "session": {
+                    "type": "string",
+                    "example": "Winter session 2026/2027"
+                },
+                "session_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Mathematical analysis"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.EventResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_imports.ImportReport": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "created": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "id": {
-                    "type": "integer"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_imports.RowErrorResponse"
+                    }
                 },
-                "photo_url": {
-                    "type": "string"
+                "failed": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "title": {
-                    "type": "string"
+                "kind": {
+                    "type": "string",
+                    "example": "rooms"
                 },
-                "university_id": {
-                    "type": "integer"
+                "mode": {
+                    "type": "string",
+                    "example": "upsert"
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "unchanged": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.FacultyInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_imports.RowErrorResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
+                "column": {
+                    "type": "string",
+                    "example": "pair_number"
                 },
-                "name": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "already exists"
                 },
-                "university_name": {
-                    "type": "string"
+                "row": {
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.GroupInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AcceptAccessRequest": {
             "type": "object",
+            "required": [
+                "role",
+                "user_id"
+            ],
             "properties": {
-                "code": {
-                    "type": "string"
+                "course_group_id": {
+                    "type": "integer"
                 },
-                "course_id": {
+                "faculty_id": {
                     "type": "integer"
                 },
-                "department_name": {
-                    "type": "string"
+                "role": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
                 },
-                "id": {
+                "university_department_id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "university_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.LoginResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AccessRequestResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "user": {
-                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
-                },
-                "user_roles": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "object",
+                        "properties": {
+                            "first_name": {
+                                "type": "string"
+                            },
+                            "last_name": {
+                                "type": "string"
+                            },
+                            "request_id": {
+                                "type": "integer"
+                            },
+                            "role": {
+                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
+                            },
+                            "user_id": {
+                                "type": "integer"
+                            },
+                            "username": {
+                                "type": "string"
+                            }
+                        }
                     }
+                },
+                "has_more": {
+                    "type": "boolean"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.SemesterPeriod": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.RequestAccessToUniversity": {
             "type": "object",
             "required": [
-                "end_date",
-                "start_date"
+                "role",
+                "university_id"
             ],
             "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "2006-12-23T00:00:00Z"
+                "role": {
+                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
                 },
-                "start_date": {
-                    "type": "string",
-                    "example": "2005-12-23T00:00:00Z"
+                "university_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UniInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.CourseResponse": {
             "type": "object",
-            "required": [
-                "city",
-                "id",
-                "uni_name"
-            ],
             "properties": {
-                "city": {
+                "action": {
                     "type": "string",
-                    "example": "Saint-Petersburg"
+                    "example": "promoted"
                 },
-                "description": {
+                "department_name": {
                     "type": "string",
-                    "example": "One of the leading Russian universities in the field of information technology, optical design, and engineering."
+                    "example": "Information systems and technologies"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.GroupResponse"
+                    }
                 },
                 "id": {
                     "type": "integer",
-                    "example": 123456789
+                    "example": 7
                 },
-                "photo_url": {
-                    "type": "string",
-                    "example": "https://itmo.ru/images/itmo.jpg"
+                "new_course_created": {
+                    "type": "boolean"
                 },
-                "site_url": {
-                    "type": "string",
-                    "example": "https://itmo.ru"
+                "new_course_id": {
+                    "type": "integer",
+                    "example": 31
                 },
-                "uni_name": {
-                    "type": "string",
-                    "example": "ITMO University"
+                "study_years": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "uni_short_name": {
-                    "type": "string",
-                    "example": "ITMO"
+                "university_department_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "year_of_study": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.GroupRename": {
             "type": "object",
             "required": [
-                "first_name",
-                "id"
+                "group_id",
+                "name"
             ],
             "properties": {
-                "first_name": {
-                    "type": "string",
-                    "example": "John"
-                },
-                "id": {
+                "group_id": {
                     "type": "integer",
-                    "example": 123456789
+                    "example": 14
                 },
-                "language_code": {
+                "name": {
                     "type": "string",
-                    "example": "ru"
+                    "maxLength": 125,
+                    "example": "IS-21"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.GroupResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 14
                 },
-                "last_name": {
+                "name": {
                     "type": "string",
-                    "example": "Doe"
+                    "example": "IS-11"
                 },
-                "photo_url": {
+                "new_name": {
                     "type": "string",
-                    "example": "https://example.com/photo.jpg"
+                    "example": "IS-21"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "johndoe"
+                "students": {
+                    "type": "integer",
+                    "example": 25
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.UserInfoResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.ReportResponse": {
             "type": "object",
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_http_dto.User"
+                "archived_lessons": {
+                    "type": "integer",
+                    "example": 340
                 },
-                "user_roles": {
+                "courses": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.CourseResponse"
                     }
-                }
-            }
-        },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AcceptAccessRequest": {
-            "type": "object",
-            "required": [
-                "role",
-                "user_id"
-            ],
-            "properties": {
-                "course_group_id": {
-                    "type": "integer"
                 },
-                "faculty_id": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "role": {
-                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
+                "end_date": {
+                    "type": "string",
+                    "example": "2027-06-30"
                 },
-                "university_department_id": {
-                    "type": "integer"
+                "graduated_students": {
+                    "type": "integer",
+                    "example": 87
                 },
-                "university_id": {
-                    "type": "integer"
+                "id": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "user_id": {
-                    "type": "integer"
+                "promoted_groups": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.SkippedCourseResponse"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.AccessRequestResponse": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.RolloverRequest": {
             "type": "object",
+            "required": [
+                "end_date",
+                "start_date",
+                "university_id"
+            ],
             "properties": {
-                "data": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2027-06-30"
+                },
+                "renames": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "properties": {
-                            "first_name": {
-                                "type": "string"
-                            },
-                            "last_name": {
-                                "type": "string"
-                            },
-                            "request_id": {
-                                "type": "integer"
-                            },
-                            "role": {
-                                "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
-                            },
-                            "user_id": {
-                                "type": "integer"
-                            },
-                            "username": {
-                                "type": "string"
-                            }
-                        }
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.GroupRename"
                     }
                 },
-                "has_more": {
-                    "type": "boolean"
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-09-01"
+                },
+                "university_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_personalities.RequestAccessToUniversity": {
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_rollover.SkippedCourseResponse": {
             "type": "object",
             "properties": {
-                "role": {
-                    "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_repository_personalities.RoleType"
+                "course_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "university_id": {
-                    "type": "integer"
+                "department_name": {
+                    "type": "string",
+                    "example": "Information systems and technologies"
+                },
+                "university_department_id": {
+                    "type": "integer",
+                    "example": 5
                 }
             }
         },
@@ -2367,12 +9486,20 @@ const docTemplate = `{
         },
         "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateClassRequest": {
             "type": "object",
+            "required": [
+                "end_time",
+                "pair_number",
+                "start_time",
+                "university_id"
+            ],
             "properties": {
                 "end_time": {
                     "type": "string"
                 },
                 "pair_number": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 14,
+                    "minimum": 1
                 },
                 "start_time": {
                     "type": "string"
@@ -2384,12 +9511,19 @@ const docTemplate = `{
         },
         "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateLessonRequest": {
             "type": "object",
+            "required": [
+                "class_id",
+                "day",
+                "interval",
+                "room_id"
+            ],
             "properties": {
                 "class_id": {
                     "description": "schedules.classes.id",
                     "type": "integer"
                 },
                 "course_group_subject_id": {
+                    "description": "exactly one of course_group_subject_id and elective_group_subject_id",
                     "type": "integer"
                 },
                 "day": {
@@ -2411,9 +9545,14 @@ const docTemplate = `{
         },
         "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.CreateRoomRequest": {
             "type": "object",
+            "required": [
+                "room",
+                "university_id"
+            ],
             "properties": {
                 "room": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 125
                 },
                 "university_id": {
                     "type": "integer"
@@ -2483,6 +9622,131 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupancyBooking": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "club"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "confirmed"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Chess club"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupancyLesson": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "IS-21"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "every week"
+                },
+                "lesson_id": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "Databases"
+                },
+                "subject_type": {
+                    "type": "string",
+                    "example": "lecture"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupiedSlot": {
+            "type": "object",
+            "properties": {
+                "bookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupancyBooking"
+                    }
+                },
+                "class_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-11-12"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "thursday"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "12:10"
+                },
+                "lessons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupancyLesson"
+                    }
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "10:40"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomOccupancy": {
+            "type": "object",
+            "properties": {
+                "room": {
+                    "type": "string",
+                    "example": "A-101"
+                },
+                "room_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.OccupiedSlot"
+                    }
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomOccupancyResponse": {
+            "type": "object",
+            "properties": {
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomOccupancy"
+                    }
+                },
+                "week_end": {
+                    "type": "string",
+                    "example": "2026-11-15"
+                },
+                "week_start": {
+                    "type": "string",
+                    "example": "2026-11-09"
+                }
+            }
+        },
         "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_schedules.RoomsResponse": {
             "type": "object",
             "properties": {
@@ -2499,9 +9763,14 @@ const docTemplate = `{
         },
         "github_com_max-main-team_backend_hackaton_MAX_internal_models_http_subjects.CreateSubjectRequest": {
             "type": "object",
+            "required": [
+                "name",
+                "university_id"
+            ],
             "properties": {
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 125
                 },
                 "university_id": {
                     "type": "integer"
@@ -2543,6 +9812,94 @@ const docTemplate = `{
                 "Admin"
             ]
         },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_services.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "min"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "pair_number"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be at least 1"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_services_auth.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_max-main-team_backend_hackaton_MAX_internal_services_auth.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_services_auth.JWK"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers.APIError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_http_handlers.APIErrorBody"
+                }
+            }
+        },
+        "internal_http_handlers.APIErrorBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_max-main-team_backend_hackaton_MAX_internal_services.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "entity not found"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "1700000000000000000"
+                }
+            }
+        },
         "internal_http_handlers.RefreshRequest": {
             "type": "object",
             "required": [
@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	nethttp "net/http"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
@@ -10,10 +13,13 @@ import (
 	"github.com/max-main-team/backend_hackaton_MAX/internal/bot"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/handlers"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/ratelimit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services/auth"
-	"github.com/vmkteam/embedlog"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const (
@@ -21,32 +27,58 @@ const (
 )
 
 type App struct {
-	sl      embedlog.Logger
+	sl      logging.Logger
 	appName string
 	cfg     cfg.Config
 	db      *pgxpool.Pool
 	echo    *echo.Echo
 	bot     *bot.Bot
 
-	jwtService       *auth.JWTService
-	userHandler      *handlers.UserHandler
-	authHandler      *handlers.AuthHandler
-	uniHandler       *handlers.UniHandler
-	personsHandler   *handlers.PersonalitiesHandler
-	facultiesHandler *handlers.FaculHandler
-	subjectsHandler  *handlers.SubjectHandler
-	schedulesHandler *handlers.SchedulesHandler
+	jwtService        *auth.JWTService
+	tokenVersions     *auth.TokenVersionCache
+	keyring           *auth.Keyring
+	devLogin          *auth.DevLogin
+	userHandler       *handlers.UserHandler
+	authHandler       *handlers.AuthHandler
+	uniHandler        *handlers.UniHandler
+	personsHandler    *handlers.PersonalitiesHandler
+	facultiesHandler  *handlers.FaculHandler
+	subjectsHandler   *handlers.SubjectHandler
+	schedulesHandler  *handlers.SchedulesHandler
+	hierarchyHandler  *handlers.HierarchyHandler
+	curriculumHandler *handlers.CurriculumHandler
+	electivesHandler  *handlers.ElectivesHandler
+	admissionsHandler *handlers.AdmissionsHandler
+	rolloverHandler   *handlers.RolloverHandler
+	importHandler     *handlers.ImportHandler
+	exportHandler     *handlers.ExportHandler
+	bookingsHandler   *handlers.BookingsHandler
+	examsHandler      *handlers.ExamsHandler
+
+	impersonationHandler *handlers.ImpersonationHandler
+	impersonationRepo    repositories.ImpersonationRepository
+	admissionsRepo       repositories.AdmissionsRepository
+	auditHandler         *handlers.AuditHandler
+	healthHandler        *handlers.HealthHandler
+	requireAdmin         echo.MiddlewareFunc
+
+	limiter        *ratelimit.Limiter
+	rateLimitStore *ratelimit.PostgresStore
 }
 
-func New(appName string, slogger embedlog.Logger, c cfg.Config, db *pgxpool.Pool) *App {
+func New(appName string, slogger logging.Logger, c cfg.Config, db *pgxpool.Pool) (*App, error) {
 	a := &App{
 		appName: appName,
 		cfg:     c,
 		db:      db,
 		sl:      slogger,
 	}
-	a.initDependencies()
+	if err := a.initDependencies(); err != nil {
+		return nil, err
+	}
 	a.echo = http.NewRouter(a.sl,
+		a.appName,
+		a.cfg.Server,
 		a.userHandler,
 		a.authHandler,
 		a.jwtService,
@@ -54,15 +86,30 @@ func New(appName string, slogger embedlog.Logger, c cfg.Config, db *pgxpool.Pool
 		a.personsHandler,
 		a.facultiesHandler,
 		a.subjectsHandler,
-		a.schedulesHandler)
-	return a
+		a.schedulesHandler,
+		a.hierarchyHandler,
+		a.curriculumHandler,
+		a.electivesHandler,
+		a.admissionsHandler,
+		a.rolloverHandler,
+		a.importHandler,
+		a.exportHandler,
+		a.bookingsHandler,
+		a.examsHandler,
+		a.impersonationHandler,
+		a.impersonationRepo,
+		a.auditHandler,
+		a.healthHandler,
+		a.requireAdmin,
+		a.limiter)
+	return a, nil
 }
 
 type Dependencies struct {
 	UserHandler *handlers.UserHandler
 }
 
-func (a *App) initDependencies() {
+func (a *App) initDependencies() error {
 
 	// init repositories
 	userRepo := repositories.NewUserRepository(a.db)
@@ -72,15 +119,60 @@ func (a *App) initDependencies() {
 	faculRepo := repositories.NewFaculRepository(a.db)
 	subjectsRepo := repositories.NewSubjectRepo(a.db)
 	schedsRepo := repositories.NewScheduleRepo(a.db)
+	hierarchyRepo := repositories.NewHierarchyRepository(a.db)
+	curriculumRepo := repositories.NewCurriculumRepository(a.db)
+	electivesRepo := repositories.NewElectivesRepository(a.db)
+	a.admissionsRepo = repositories.NewAdmissionsRepository(a.db)
+	rolloverRepo := repositories.NewRolloverRepository(a.db)
+	importRepo := repositories.NewImportRepository(a.db)
+	exportRepo := repositories.NewExportRepository(a.db)
+	bookingsRepo := repositories.NewBookingsRepository(a.db)
+	examsRepo := repositories.NewExamsRepository(a.db)
+	tokenVersionRepo := repositories.NewTokenVersionRepository(a.db)
+	jwtKeysRepo := repositories.NewJWTKeysRepository(a.db)
+	a.impersonationRepo = repositories.NewImpersonationRepository(a.db)
+	auditRepo := repositories.NewAuditRepository(a.db)
 
 	// init services
 	userService := services.NewUserService(userRepo)
-	a.jwtService = auth.NewJWTService(a.cfg)
+	a.tokenVersions = auth.NewTokenVersionCache(tokenVersionRepo, a.sl)
+	if a.cfg.AuthConfig.SigningAlg != auth.AlgHS256 {
+		keyring, err := auth.NewKeyring(jwtKeysRepo, a.sl, a.cfg.AuthConfig.SigningAlg,
+			time.Duration(a.cfg.AuthConfig.KeyRotation)*time.Hour,
+			time.Duration(a.cfg.AuthConfig.KeyOverlap)*time.Hour,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create jwt keyring: %w", err)
+		}
+		if err := keyring.Load(context.Background()); err != nil {
+			return fmt.Errorf("failed to load jwt keys: %w", err)
+		}
+		a.keyring = keyring
+	}
+	a.jwtService = auth.NewJWTService(a.cfg, a.tokenVersions, a.keyring)
+	devLogin, err := auth.NewDevLogin(a.cfg, userRepo, a.jwtService)
+	if err != nil {
+		return err
+	}
+	if devLogin != nil {
+		a.sl.Print(context.Background(), "DEV LOGIN ENABLED: tokens are issued without MAX init data")
+	}
+	a.devLogin = devLogin
 	uniService := services.NewUniService(uniRepo)
 	faculService := services.NewFaculService(faculRepo)
 	personService := services.NewPersonalitiesService(personsRepo)
 	subjectsService := services.NewSubjectService(subjectsRepo)
 	schedsService := services.NewSchedulesService(schedsRepo)
+	hierarchyService := services.NewHierarchyService(hierarchyRepo)
+	curriculumService := services.NewCurriculumService(curriculumRepo)
+	electivesService := services.NewElectivesService(electivesRepo)
+	admissionsService := services.NewAdmissionsService(a.admissionsRepo)
+	rolloverService := services.NewRolloverService(rolloverRepo)
+	importService := services.NewImportService(importRepo)
+	exportService := services.NewExportService(exportRepo)
+	bookingsService := services.NewBookingsService(bookingsRepo)
+	examsService := services.NewExamsService(examsRepo)
+	auditService := services.NewAuditService(auditRepo)
 
 	// init handlers
 	a.userHandler = handlers.NewUserHandler(userService, a.sl)
@@ -88,7 +180,12 @@ func (a *App) initDependencies() {
 		a.jwtService,
 		userRepo,
 		refreshRepo,
-		a.cfg.APIKeys[api_key_bot],
+		auth.NewInitDataValidator(
+			a.cfg.APIKeys[api_key_bot],
+			time.Duration(a.cfg.AuthConfig.InitDataMaxAge)*time.Second,
+		),
+		a.devLogin,
+		handlers.RefreshCookie{Secure: a.cfg.Server.CookieSecure, SameSite: a.cfg.Server.SameSite()},
 	)
 
 	a.uniHandler = handlers.NewUniHandler(uniService, userService, a.sl)
@@ -97,14 +194,30 @@ func (a *App) initDependencies() {
 	a.facultiesHandler = handlers.NewFaculHandler(faculService, userService, a.sl)
 	a.subjectsHandler = handlers.NewSubjectHandler(subjectsService, userService, a.sl)
 	a.schedulesHandler = handlers.NewSchedulesHandler(schedsService, userService, a.sl)
+	a.hierarchyHandler = handlers.NewHierarchyHandler(hierarchyService, userService, a.sl)
+	a.curriculumHandler = handlers.NewCurriculumHandler(curriculumService, userService, a.sl)
+	a.electivesHandler = handlers.NewElectivesHandler(electivesService, userService, a.sl)
+	a.admissionsHandler = handlers.NewAdmissionsHandler(admissionsService, userService, a.sl)
+	a.rolloverHandler = handlers.NewRolloverHandler(rolloverService, userService, a.sl)
+	a.importHandler = handlers.NewImportHandler(importService, userService, a.sl)
+	a.exportHandler = handlers.NewExportHandler(exportService, userService, a.sl)
+	a.bookingsHandler = handlers.NewBookingsHandler(bookingsService, userService, a.sl)
+	a.examsHandler = handlers.NewExamsHandler(examsService, userService, a.sl)
+	a.impersonationHandler = handlers.NewImpersonationHandler(a.jwtService, a.impersonationRepo, userService, a.sl)
+	a.auditHandler = handlers.NewAuditHandler(auditService, uniService, a.sl)
+	a.requireAdmin = handlers.RequireAdmin(userService)
 
-	if a.jwtService == nil {
-		panic("jwt service is nil")
+	// init rate limiter
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if a.cfg.RateLimit.Store == "postgres" {
+		a.rateLimitStore = ratelimit.NewPostgresStore(repositories.NewRateLimitRepository(a.db), a.sl)
+		store = a.rateLimitStore
 	}
+	a.limiter = ratelimit.New(store, a.cfg.RateLimit)
 
 	// init bot
 	if botToken, ok := a.cfg.APIKeys[api_key_bot]; ok && botToken != "" {
-		maxBot, err := bot.New(botToken, a.sl)
+		maxBot, err := bot.New(botToken, examsService, a.sl)
 		if err != nil {
 			a.sl.Errorf("Failed to create bot: %v", err)
 		} else {
@@ -114,20 +227,79 @@ func (a *App) initDependencies() {
 	} else {
 		a.sl.Print(context.Background(), "Bot token not found in config, bot will not be started")
 	}
+
+	// init health checks and metrics, bot may be nil
+	a.healthHandler = handlers.NewHealthHandler(a.db, a.bot.Status, a.sl)
+	prometheus.MustRegister(metrics.NewPoolCollector(a.db))
+	return nil
 }
+
+// DevToken returns an access token of user without MAX init data, see auth.DevLogin.
+func (a *App) DevToken(ctx context.Context, userID int64) (string, error) {
+	if a.devLogin == nil {
+		return "", fmt.Errorf("dev login is disabled, set server.is_devel and auth.dev_login")
+	}
+	return a.devLogin.AccessToken(ctx, userID)
+}
+
+// Run starts the HTTP server, bot and background workers and blocks until ctx is done
+// (SIGTERM) or one of them fails. Then everything is stopped gracefully, see lifecycle.
 func (a *App) Run(ctx context.Context) error {
-	// Запускаем бота в отдельной горутине, если он инициализирован
+	lc := newLifecycle(a.sl, time.Duration(a.cfg.Server.ShutdownTimeout)*time.Second)
+
+	// Слушаем изменения версий токенов (смена ролей) из Postgres
+	lc.add("token_versions", worker(a.tokenVersions.Run))
+
+	if a.keyring != nil {
+		lc.add("keyring", worker(a.keyring.Run))
+	}
+
+	if a.rateLimitStore != nil {
+		lc.add("rate_limit_store", worker(a.rateLimitStore.Run))
+	}
+
+	// Бот не останавливает приложение при ошибке: API продолжает работать без него
 	if a.bot != nil {
-		go func() {
-			a.sl.Print(ctx, "Starting bot goroutine...")
+		// без бота изменения статусов заявлений копятся и будут отправлены после его запуска
+		lc.add("admissions_notifier", worker(services.NewAdmissionsNotifier(a.admissionsRepo, a.bot, a.sl).Run))
+		lc.add("bot", func(ctx context.Context) error {
 			if err := a.bot.Start(ctx); err != nil {
 				a.sl.Errorf("Bot stopped with error: %v", err)
 			}
-		}()
-		a.sl.Print(ctx, "Bot started successfully")
+			return nil
+		})
 	}
 
+	lc.add("http", a.serveHTTP)
+
+	return lc.run(ctx)
+}
+
+// serveHTTP serves until ctx is done, then stops accepting connections and waits for in-flight requests.
+func (a *App) serveHTTP(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", a.cfg.Server.Host, a.cfg.Server.Port)
 	a.sl.Print(ctx, "starting server", "addr", addr)
-	return a.echo.Start(addr)
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- a.echo.Start(addr)
+	}()
+
+	select {
+	case err := <-errc:
+		// сервер не поднялся (например, порт занят)
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(a.cfg.Server.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := a.echo.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown http server: %w", err)
+	}
+	if err := <-errc; err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+)
+
+// component is a long running part of the application: HTTP server, bot, background workers.
+// run blocks until ctx is done (returns nil) or the component fails.
+type component struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// lifecycle runs components together: when ctx is cancelled (SIGTERM) or any component fails,
+// all of them get cancelled and lifecycle waits for them to finish, at most shutdownTimeout.
+type lifecycle struct {
+	logger          logging.Logger
+	shutdownTimeout time.Duration
+	components      []component
+}
+
+func newLifecycle(logger logging.Logger, shutdownTimeout time.Duration) *lifecycle {
+	return &lifecycle{logger: logger, shutdownTimeout: shutdownTimeout}
+}
+
+func (l *lifecycle) add(name string, run func(ctx context.Context) error) {
+	l.components = append(l.components, component{name: name, run: run})
+}
+
+// worker adapts Run(ctx) methods of background workers that stop only with ctx.
+func worker(run func(ctx context.Context)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		run(ctx)
+		return nil
+	}
+}
+
+// run starts all components and returns after every one of them stopped.
+// Returns the first failure of a component, nil on a normal shutdown.
+func (l *lifecycle) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	for _, c := range l.components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			l.logger.Print(ctx, "component started", "component", c.name)
+			err := c.run(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				l.logger.Errorf("[lifecycle] component %s failed: %v", c.name, err)
+				errOnce.Do(func() { firstErr = fmt.Errorf("%s: %w", c.name, err) })
+				cancel()
+				return
+			}
+			l.logger.Print(context.Background(), "component stopped", "component", c.name)
+		}()
+	}
+
+	<-ctx.Done()
+	l.logger.Print(context.Background(), "shutting down", "timeout", l.shutdownTimeout.String())
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(l.shutdownTimeout):
+		return fmt.Errorf("components did not stop in %s", l.shutdownTimeout)
+	}
+
+	return firstErr
+}
@@ -3,18 +3,28 @@ package bot
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
-	"github.com/vmkteam/embedlog"
 )
 
 type Bot struct {
 	api    *maxbot.Api
-	logger embedlog.Logger
+	exams  ExamTimetables
+	logger logging.Logger
 	token  string
+
+	running atomic.Bool
 }
 
-func New(token string, logger embedlog.Logger) (*Bot, error) {
+const (
+	StatusDisabled = "disabled"
+	StatusRunning  = "running"
+	StatusStopped  = "stopped"
+)
+
+func New(token string, exams ExamTimetables, logger logging.Logger) (*Bot, error) {
 	api, err := maxbot.New(token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create bot: %w", err)
@@ -24,6 +34,7 @@ func New(token string, logger embedlog.Logger) (*Bot, error) {
 
 	return &Bot{
 		api:    api,
+		exams:  exams,
 		logger: logger,
 		token:  token,
 	}, nil
@@ -32,6 +43,9 @@ func New(token string, logger embedlog.Logger) (*Bot, error) {
 func (b *Bot) Start(ctx context.Context) error {
 	b.logger.Print(ctx, "Starting bot...")
 
+	b.running.Store(true)
+	defer b.running.Store(false)
+
 	updates := b.api.GetUpdates(ctx)
 
 	for {
@@ -48,3 +62,14 @@ func (b *Bot) Start(ctx context.Context) error {
 		}
 	}
 }
+
+// Status returns StatusRunning while Start polls updates, StatusStopped otherwise.
+func (b *Bot) Status() string {
+	if b == nil {
+		return StatusDisabled
+	}
+	if b.running.Load() {
+		return StatusRunning
+	}
+	return StatusStopped
+}
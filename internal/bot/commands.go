@@ -3,8 +3,12 @@ package bot
 import (
 	"context"
 
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
 	"github.com/max-messenger/max-bot-api-client-go/schemes"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (b *Bot) handleStartCommand(ctx context.Context, messageUpdate *schemes.MessageCreatedUpdate) {
@@ -22,7 +26,7 @@ func (b *Bot) handleStartCommand(ctx context.Context, messageUpdate *schemes.Mes
 		SetChat(chatID).
 		SetText("Привет")
 
-	resp, err := b.api.Messages.Send(ctx, msg)
+	resp, err := b.send(ctx, "start", chatID, msg)
 	if err != nil {
 		b.logger.Errorf("Failed to send message: %v (chat_id=%d, user_id=%d)", err, chatID, userID)
 		return
@@ -58,7 +62,7 @@ https://github.com/max-main-team`
 		SetChat(chatID).
 		SetText(welcomeText)
 
-	resp, err := b.api.Messages.Send(ctx, msg)
+	resp, err := b.send(ctx, "welcome", chatID, msg)
 	if err != nil {
 		b.logger.Errorf("Failed to send welcome message: %v (chat_id=%d)", err, chatID)
 		return
@@ -69,3 +73,18 @@ https://github.com/max-main-team`
 		"response", resp,
 	)
 }
+
+// send sends msg within a span and counts failures by kind of the message.
+func (b *Bot) send(ctx context.Context, kind string, chatID int64, msg *maxbot.Message) (resp string, err error) {
+	ctx, span := tracing.Start(ctx, "bot.messages.send", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("bot.message", kind),
+		attribute.Int64("bot.chat_id", chatID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	resp, err = b.api.Messages.Send(ctx, msg)
+	if err != nil {
+		metrics.BotSendFailures.WithLabelValues(kind).Inc()
+	}
+	return resp, err
+}
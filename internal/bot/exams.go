@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/exams"
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"github.com/max-messenger/max-bot-api-client-go/schemes"
+)
+
+// ExamTimetables returns exam timetables of students, see services.ExamsService.
+type ExamTimetables interface {
+	GetStudentExams(ctx context.Context, userID int64) ([]exams.StudentExamResponse, error)
+}
+
+var weekdays = [...]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"}
+
+var examKinds = map[string]string{
+	"exam": "Экзамен",
+	"test": "Зачёт",
+}
+
+// handleExamsCommand replies with exams of published sessions of the sender's groups.
+func (b *Bot) handleExamsCommand(ctx context.Context, messageUpdate *schemes.MessageCreatedUpdate) {
+	chatID := messageUpdate.Message.Recipient.ChatId
+	userID := messageUpdate.Message.Sender.UserId
+
+	var text string
+	result, err := b.exams.GetStudentExams(ctx, userID)
+	switch {
+	case err != nil:
+		b.logger.Errorf("Failed to get exams: %v (user_id=%d)", err, userID)
+		text = "Не удалось получить расписание экзаменов, попробуйте позже."
+	case len(result) == 0:
+		text = "Опубликованных экзаменов у вас нет."
+	default:
+		text = formatExams(result)
+	}
+
+	msg := maxbot.NewMessage().
+		SetChat(chatID).
+		SetText(text)
+
+	if _, err := b.send(ctx, "exams", chatID, msg); err != nil {
+		b.logger.Errorf("Failed to send exams: %v (chat_id=%d, user_id=%d)", err, chatID, userID)
+	}
+}
+
+// formatExams lists exams ordered by date under names of their sessions.
+func formatExams(result []exams.StudentExamResponse) string {
+	var sb strings.Builder
+	session := ""
+	for _, e := range result {
+		if e.Session != session {
+			if session != "" {
+				sb.WriteString("\n")
+			}
+			session = e.Session
+			sb.WriteString(session + "\n")
+		}
+
+		date := e.Date
+		if d, err := time.Parse(time.DateOnly, e.Date); err == nil {
+			date = weekdays[d.Weekday()] + " " + d.Format("02.01.2006")
+		}
+		rooms := make([]string, 0, len(e.Rooms))
+		for _, r := range e.Rooms {
+			rooms = append(rooms, r.Room)
+		}
+		examiner := e.ExaminerFirstName
+		if e.ExaminerLastName != nil {
+			examiner += " " + *e.ExaminerLastName
+		}
+
+		fmt.Fprintf(&sb, "\n%s, %s–%s\n", date, e.StartTime, e.EndTime)
+		fmt.Fprintf(&sb, "%s: %s\n", examKinds[e.Kind], e.Subject)
+		fmt.Fprintf(&sb, "Аудитории: %s\n", strings.Join(rooms, ", "))
+		fmt.Fprintf(&sb, "Экзаменатор: %s\n", examiner)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -4,11 +4,22 @@ import (
 	"context"
 	"strings"
 
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
 	"github.com/max-messenger/max-bot-api-client-go/schemes"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func (b *Bot) handleUpdate(ctx context.Context, update schemes.UpdateInterface) {
 	updateType := update.GetUpdateType()
+	metrics.BotUpdates.WithLabelValues(string(updateType)).Inc()
+
+	ctx, span := tracing.Start(ctx, "bot.update", trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(
+		attribute.String("bot.update_type", string(updateType)),
+		attribute.Int64("bot.chat_id", update.GetChatID()),
+	))
+	defer span.End()
 
 	b.logger.Print(ctx, "Received update",
 		"type", updateType,
@@ -71,6 +82,8 @@ func (b *Bot) handleCommand(ctx context.Context, messageUpdate *schemes.MessageC
 	switch command {
 	case "start":
 		b.handleStartCommand(ctx, messageUpdate)
+	case "exams":
+		b.handleExamsCommand(ctx, messageUpdate)
 	default:
 		b.logger.Print(ctx, "Unknown command", "command", command)
 	}
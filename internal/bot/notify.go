@@ -0,0 +1,29 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
+	maxbot "github.com/max-messenger/max-bot-api-client-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NotifyUser sends text to the dialog of the bot with MAX user userID.
+func (b *Bot) NotifyUser(ctx context.Context, userID int64, text string) (err error) {
+	ctx, span := tracing.Start(ctx, "bot.messages.send", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("bot.message", "notification"),
+		attribute.Int64("bot.user_id", userID),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	msg := maxbot.NewMessage().
+		SetUser(userID).
+		SetText(text)
+
+	if _, err = b.api.Messages.Send(ctx, msg); err != nil {
+		metrics.BotSendFailures.WithLabelValues("notification").Inc()
+	}
+	return err
+}
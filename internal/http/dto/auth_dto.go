@@ -6,6 +6,19 @@ type LoginResponse struct {
 	UserRoles   []string `json:"user_roles"`
 }
 
+type DevLoginRequest struct {
+	UserID int64 `json:"user_id" validate:"gte=0" example:"123456789"`
+}
+
+type ImpersonateRequest struct {
+	UserID int64 `json:"user_id" validate:"required,gt=0" example:"123456789"`
+}
+
+type ImpersonateResponse struct {
+	AccessToken string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt   string `json:"expires_at" example:"2025-01-01T12:15:00Z"`
+}
+
 type WebAppInitData struct {
 	QueryID    string `json:"query_id" validate:"required" example:"unique_session_id"`
 	AuthDate   int    `json:"auth_date" validate:"required" example:"1633038072"`
@@ -1,9 +1,10 @@
 package dto
 
 type CreateCourseRequest struct {
-	StartDate            string `json:"start_date" validate:"required"`
-	EndDate              string `json:"end_date" validate:"required"`
-	UniversityDepartment int64  `json:"university_department_id" validate:"required"`
+	StartDate            string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate              string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	UniversityDepartment int64  `json:"university_department_id" validate:"required,gt=0"`
+	YearOfStudy          *int   `json:"year_of_study,omitempty" validate:"omitempty,min=1,max=12"`
 }
 
 type CourseInfoResponse struct {
@@ -11,4 +12,12 @@ type CourseInfoResponse struct {
 	StartDate            string `json:"start_date"`
 	EndDate              string `json:"end_date"`
 	UniversityDepartment int64  `json:"university_department_id"`
+	YearOfStudy          *int   `json:"year_of_study,omitempty" example:"1"`
+}
+
+type UpdateCourseRequest struct {
+	StartDate            string `json:"start_date" validate:"required,datetime=2006-01-02" example:"2025-09-01"`
+	EndDate              string `json:"end_date" validate:"required,datetime=2006-01-02" example:"2029-06-30"`
+	UniversityDepartment int64  `json:"university_department_id" validate:"required,gt=0" example:"12"`
+	YearOfStudy          *int   `json:"year_of_study,omitempty" validate:"omitempty,min=1,max=12" example:"1"`
 }
@@ -8,9 +8,30 @@ type DepartmentInfoResponse struct {
 }
 
 type CreateDepartmentRequest struct {
-	DepartmentName string `json:"department_name" validate:"required"`
-	DepartmentCode string `json:"department_code"`
-	AliasName      string `json:"alias_name"`
-	FacultyID      int64  `json:"faculty_id" validate:"required"`
-	UniversityID   int64  `json:"university_id" validate:"required"`
+	DepartmentName string `json:"department_name" validate:"required,max=125"`
+	DepartmentCode string `json:"department_code" validate:"max=125"`
+	AliasName      string `json:"alias_name" validate:"max=125"`
+	FacultyID      int64  `json:"faculty_id" validate:"required,gt=0"`
+	UniversityID   int64  `json:"university_id" validate:"required,gt=0"`
+}
+
+// UniversityDepartmentResponse is a department opened in a faculty, its id is university_department_id
+// used by courses and students.
+type UniversityDepartmentResponse struct {
+	ID           int64  `json:"id" example:"12"`
+	DepartmentID int64  `json:"department_id" example:"3"`
+	Name         string `json:"department_name" example:"Software Engineering"`
+	Code         string `json:"code" example:"09.03.04"`
+	AliasName    string `json:"alias_name" example:"SE"`
+	FacultyID    int64  `json:"faculty_id" example:"5"`
+	UniversityID int64  `json:"university_id" example:"1"`
+	StudyYears   int    `json:"study_years" example:"4"`
+}
+
+// UpdateDepartmentRequest renames department in the university and moves it to another faculty,
+// StudyYears is left unchanged when omitted.
+type UpdateDepartmentRequest struct {
+	AliasName  string `json:"alias_name" validate:"max=125" example:"SE"`
+	FacultyID  int64  `json:"faculty_id" validate:"required,gt=0" example:"5"`
+	StudyYears *int   `json:"study_years,omitempty" validate:"omitempty,min=1,max=12" example:"4"`
 }
@@ -3,7 +3,7 @@ package dto
 type CreateEventRequest struct {
 	Title       string `json:"title" validate:"required"`
 	Description string `json:"description" validate:"required"`
-	PhotoUrl    string `json:"photo_url" validate:"required"`
+	PhotoUrl    string `json:"photo_url" validate:"required,url"`
 }
 
 type EventResponse struct {
@@ -1,7 +1,7 @@
 package dto
 
 type CreateNewFacultyRequest struct {
-	Name string `json:"faculty_name" validate:"required" example:"FITIP"`
+	Name string `json:"faculty_name" validate:"required,max=125" example:"FITIP"`
 }
 
 type FacultyInfoResponse struct {
@@ -9,3 +9,7 @@ type FacultyInfoResponse struct {
 	Name           string `json:"name"`
 	UniversityName string `json:"university_name"`
 }
+
+type UpdateFacultyRequest struct {
+	Name string `json:"faculty_name" validate:"required,max=125" example:"FITIP"`
+}
@@ -9,6 +9,11 @@ type GroupInfoResponse struct {
 }
 
 type CreateGroupRequest struct {
-	GroupName string `json:"group_name" validate:"required"`
-	CourseID  int64  `json:"course_id" validate:"required"`
+	GroupName string `json:"group_name" validate:"required,max=125"`
+	CourseID  int64  `json:"course_id" validate:"required,gt=0"`
+}
+
+type UpdateGroupRequest struct {
+	GroupName string `json:"group_name" validate:"required,max=125" example:"M3101"`
+	CourseID  int64  `json:"course_id" validate:"required,gt=0" example:"7"`
 }
@@ -0,0 +1,11 @@
+package dto
+
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"`
+}
+
+type ReadinessResponse struct {
+	Status   string `json:"status" example:"ok"`
+	Database string `json:"database" example:"ok"`
+	Bot      string `json:"bot" example:"running"`
+}
@@ -11,11 +11,19 @@ type UniInfoResponse struct {
 }
 
 type SemesterPeriod struct {
-	StartDate string `json:"start_date" validate:"required" example:"2005-12-23T00:00:00Z"`
-	EndDate   string `json:"end_date" validate:"required" example:"2006-12-23T00:00:00Z"`
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2005-12-23T00:00:00Z"`
+	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2006-12-23T00:00:00Z"`
 }
 
 type CreateSemestersRequest struct {
-	ID      int              `json:"uni_id" validate:"required"`
-	Periods []SemesterPeriod `json:"periods" validate:"required,dive"`
+	ID      int              `json:"uni_id" validate:"required,gt=0"`
+	Periods []SemesterPeriod `json:"periods" validate:"required,min=1,dive"`
+}
+
+type UpdateUniversityRequest struct {
+	Name        string `json:"uni_name" validate:"required,max=255" example:"ITMO University"`
+	ShortName   string `json:"uni_short_name" validate:"max=125" example:"ITMO"`
+	SiteUrl     string `json:"site_url" validate:"omitempty,url" example:"https://itmo.ru"`
+	Description string `json:"description" example:"One of the leading Russian universities."`
+	PhotoUrl    string `json:"photo_url" validate:"omitempty,url" example:"https://itmo.ru/images/itmo.jpg"`
 }
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/handlers"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// errorHandler renders every error as handlers.APIError.
+// Domain errors (services.Error, also as echo.HTTPError internal) and db errors are mapped to their status,
+// unknown errors become 500 without details.
+func errorHandler() echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		status, body := errorBody(services.FromDB(err))
+		body.RequestID = c.Response().Header().Get(echo.HeaderXRequestID)
+
+		log := logging.FromContext(c.Request().Context())
+		if status >= http.StatusInternalServerError {
+			log.Errorf("[HTTPErrorHandler] %s %s: %v", c.Request().Method, c.Request().URL.Path, err)
+		}
+
+		var respErr error
+		if c.Request().Method == http.MethodHead {
+			respErr = c.NoContent(status)
+		} else {
+			respErr = c.JSON(status, handlers.APIError{Error: body})
+		}
+		if respErr != nil {
+			log.Errorf("[HTTPErrorHandler] failed to write error response: %v", respErr)
+		}
+	}
+}
+
+func errorBody(err error) (int, handlers.APIErrorBody) {
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Status, handlers.APIErrorBody{
+			Code:    domainErr.Code,
+			Message: domainErr.Message,
+			Details: domainErr.Fields,
+		}
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		message := http.StatusText(he.Code)
+		if m, ok := he.Message.(string); ok && m != "" {
+			message = m
+		} else if he.Message != nil {
+			message = fmt.Sprint(he.Message)
+		}
+		return he.Code, handlers.APIErrorBody{Code: codeByStatus(he.Code), Message: message}
+	}
+
+	return http.StatusInternalServerError, handlers.APIErrorBody{
+		Code:    services.CodeInternal,
+		Message: http.StatusText(http.StatusInternalServerError),
+	}
+}
+
+func codeByStatus(status int) services.ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return services.CodeBadRequest
+	case http.StatusUnauthorized:
+		return services.CodeUnauthorized
+	case http.StatusForbidden:
+		return services.CodeForbidden
+	case http.StatusNotFound:
+		return services.CodeNotFound
+	case http.StatusConflict:
+		return services.CodeConflict
+	case http.StatusTooManyRequests:
+		return services.CodeTooManyRequests
+	}
+	if status >= http.StatusInternalServerError {
+		return services.CodeInternal
+	}
+	return services.ErrorCode(strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"))
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// adminKey keeps the user checked by requireAdmin, so the middleware and the handler read roles once.
+const adminKey = "admin"
+
+// RequireAdmin lets through only users with the admin role. Handlers still scope data
+// to universities of the admin, the middleware only closes the whole group for other users.
+func RequireAdmin(userServ *services.UserService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, err := requireAdmin(c, userServ); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// requireAdmin returns the current user if it has the admin role.
+func requireAdmin(c echo.Context, userServ *services.UserService) (*models.User, error) {
+	if user, ok := c.Get(adminKey).(*models.User); ok {
+		return user, nil
+	}
+
+	log := logging.FromContext(c.Request().Context())
+
+	currentUser, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[requireAdmin] user not found in context")
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	roles, err := userServ.GetUserRolesByID(c.Request().Context(), currentUser.ID)
+	if err != nil {
+		log.Errorf("[requireAdmin] GetUserRolesByID error: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "failed to get roles").SetInternal(err)
+	}
+
+	if !slices.Contains(roles.Roles, "admin") {
+		log.Errorf("[requireAdmin] permission denied for user id %d", currentUser.ID)
+		return nil, echo.NewHTTPError(http.StatusForbidden, "permission denied. need role admin")
+	}
+
+	c.Set(adminKey, currentUser)
+	return currentUser, nil
+}
+
+// adminAndID checks admin role and parses :id path param.
+func adminAndID(c echo.Context, userServ *services.UserService) (*models.User, int64, error) {
+	user, err := requireAdmin(c, userServ)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return user, id, nil
+}
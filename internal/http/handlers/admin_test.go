@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+	"github.com/vmkteam/embedlog"
+)
+
+type rolesRepo struct {
+	roles map[int64][]string
+	calls int
+}
+
+func (r *rolesRepo) GetUserByID(context.Context, int64) (*models.User, error) { return nil, nil }
+func (r *rolesRepo) CreateNewUser(context.Context, *models.User) error        { return nil }
+func (r *rolesRepo) UpdateUser(context.Context, *models.User) error           { return nil }
+
+func (r *rolesRepo) GetUserRolesByID(_ context.Context, id int64) (*models.UserRoles, error) {
+	r.calls++
+	return &models.UserRoles{Roles: r.roles[id]}, nil
+}
+
+func TestRequireAdmin(t *testing.T) {
+	repo := &rolesRepo{roles: map[int64][]string{1: {"admin"}, 2: {"student"}}}
+	userServ := services.NewUserService(repo)
+
+	e := echo.New()
+	admin := e.Group("/admin", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := logging.WithContext(c.Request().Context(), logging.New(embedlog.NewDevLogger()))
+			c.SetRequest(c.Request().WithContext(ctx))
+			if id := c.Request().Header.Get("X-User"); id != "" {
+				c.Set("user", &models.User{ID: map[string]int64{"1": 1, "2": 2}[id]})
+			}
+			return next(c)
+		}
+	}, RequireAdmin(userServ))
+	admin.GET("/x", func(c echo.Context) error {
+		// the handler check reuses the result of the middleware
+		if _, err := requireAdmin(c, userServ); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name  string
+		user  string
+		want  int
+		calls int
+	}{
+		{"admin", "1", http.StatusOK, 1},
+		{"not admin", "2", http.StatusForbidden, 1},
+		{"anonymous", "", http.StatusUnauthorized, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.calls = 0
+			req := httptest.NewRequest(http.MethodGet, "/admin/x", nil)
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if repo.calls != tt.calls {
+				t.Errorf("roles read %d times, want %d", repo.calls, tt.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,558 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/admissions"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// AdmissionsHandler serves admission campaigns to admins and applications to applicants.
+// Any authenticated MAX user may apply, they become a student when an accepted application is enrolled.
+type AdmissionsHandler struct {
+	admissionsServ *services.AdmissionsService
+	userServ       *services.UserService
+	logger         logging.Logger
+}
+
+func NewAdmissionsHandler(admissionsServ *services.AdmissionsService, userServ *services.UserService, logger logging.Logger) *AdmissionsHandler {
+	return &AdmissionsHandler{
+		admissionsServ: admissionsServ,
+		userServ:       userServ,
+		logger:         logger,
+	}
+}
+
+// CreateCampaign godoc
+// @Summary      Create admission campaign
+// @Description  Create admission campaign of the admin's university, applications are accepted from starts_at till ends_at
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        request  body      admissions.CreateCampaignRequest  true  "Campaign"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      409      {object}  APIError  "University not found, campaign already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/campaigns [post]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) CreateCampaign(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateCampaign] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.CreateCampaignRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateCampaign] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateCampaign] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.admissionsServ.CreateCampaign(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[CreateCampaign] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create campaign").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetCampaigns godoc
+// @Summary      Get admission campaigns
+// @Description  Get admission campaigns of the admin's universities
+// @Tags         admissions
+// @Produce      json
+// @Success      200  {array}   admissions.CampaignResponse
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/admissions/campaigns [get]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) GetCampaigns(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetCampaigns] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	campaigns, err := h.admissionsServ.GetCampaigns(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetCampaigns] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get campaigns").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, campaigns)
+}
+
+// UpdateCampaign godoc
+// @Summary      Update admission campaign
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                               true  "Campaign ID"
+// @Param        request  body      admissions.UpdateCampaignRequest  true  "Campaign"
+// @Success      200      {object}  map[string]string                 "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Campaign with the name already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/campaigns/{id} [put]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) UpdateCampaign(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateCampaign] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.UpdateCampaignRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateCampaign] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateCampaign] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.admissionsServ.UpdateCampaign(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateCampaign] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update campaign").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// AddProgram godoc
+// @Summary      Add program to campaign
+// @Description  Admit applicants to a university department of the campaign's university with a number of seats
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "Campaign ID"
+// @Param        request  body      admissions.AddProgramRequest  true  "Program"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError  "Campaign not found"
+// @Failure      409      {object}  APIError  "Department not found, program already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/campaigns/{id}/programs [post]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) AddProgram(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[AddProgram] called")
+
+	user, campaignID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.AddProgramRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[AddProgram] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[AddProgram] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.admissionsServ.AddProgram(c.Request().Context(), user.ID, campaignID, req)
+	if err != nil {
+		log.Errorf("[AddProgram] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to add program").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetPrograms godoc
+// @Summary      Get programs of campaign
+// @Tags         admissions
+// @Produce      json
+// @Param        id   path      int  true  "Campaign ID"
+// @Success      200  {array}   admissions.ProgramResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/admissions/campaigns/{id}/programs [get]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) GetPrograms(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetPrograms] called")
+
+	user, campaignID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	programs, err := h.admissionsServ.GetPrograms(c.Request().Context(), user.ID, campaignID)
+	if err != nil {
+		log.Errorf("[GetPrograms] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get programs").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, programs)
+}
+
+// UpdateProgram godoc
+// @Summary      Update program seats
+// @Description  Change seats of the program, seats can't be less than accepted applications
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                              true  "Program ID"
+// @Param        request  body      admissions.UpdateProgramRequest  true  "Seats"
+// @Success      200      {object}  map[string]string                "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/programs/{id} [put]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) UpdateProgram(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateProgram] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.UpdateProgramRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateProgram] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateProgram] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.admissionsServ.UpdateProgram(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateProgram] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update program").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteProgram godoc
+// @Summary      Delete program
+// @Tags         admissions
+// @Produce      json
+// @Param        id   path      int                true  "Program ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Program has applications"
+// @Failure      500  {object}  APIError
+// @Router       /admin/admissions/programs/{id} [delete]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) DeleteProgram(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteProgram] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.admissionsServ.DeleteProgram(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteProgram] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete program").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetRankedList godoc
+// @Summary      Get ranked list of program
+// @Description  Applications of the program ranked by verified score, then by submission time. Applications without a verified score go after scored ones, rejected applications have rank 0 and go last
+// @Tags         admissions
+// @Produce      json
+// @Param        id   path      int  true  "Program ID"
+// @Success      200  {array}   admissions.RankedApplicationResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/admissions/programs/{id}/applications [get]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) GetRankedList(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetRankedList] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	applications, err := h.admissionsServ.GetRankedList(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetRankedList] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get ranked list").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, applications)
+}
+
+// SetScore godoc
+// @Summary      Set verified score of application
+// @Description  The admin enters the score checked against exam results, the application takes its place in the ranked list by it. The score of an accepted application can't change
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                         true  "Application ID"
+// @Param        request  body      admissions.SetScoreRequest  true  "Score"
+// @Success      200      {object}  map[string]string           "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Application is accepted"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/applications/{id}/score [put]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) SetScore(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[SetScore] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.SetScoreRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[SetScore] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[SetScore] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.admissionsServ.SetScore(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[SetScore] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to set score").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ChangeStatus godoc
+// @Summary      Change application status
+// @Description  submitted -> under_review -> accepted | rejected | waitlisted, waitlisted -> accepted | rejected. An application is accepted only with a verified score and while the program has free seats. The applicant is notified by the bot
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                             true  "Application ID"
+// @Param        request  body      admissions.ChangeStatusRequest  true  "New status"
+// @Success      200      {object}  map[string]string               "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Transition is not allowed, no free seats, score is not verified"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/applications/{id}/status [put]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) ChangeStatus(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[ChangeApplicationStatus] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.ChangeStatusRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[ChangeApplicationStatus] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[ChangeApplicationStatus] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.admissionsServ.ChangeStatus(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[ChangeApplicationStatus] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to change application status").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Enroll godoc
+// @Summary      Enroll accepted applicant
+// @Description  Create student of the program's department in a course group of the department from an accepted application
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Application ID"
+// @Param        request  body      admissions.EnrollRequest  true  "Course group"
+// @Success      200      {object}  admissions.EnrollResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Application is not accepted or already enrolled, group is not of the department"
+// @Failure      500      {object}  APIError
+// @Router       /admin/admissions/applications/{id}/enroll [post]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) Enroll(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[EnrollApplicant] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req admissions.EnrollRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[EnrollApplicant] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[EnrollApplicant] invalid request: %v", err)
+		return err
+	}
+
+	response, err := h.admissionsServ.Enroll(c.Request().Context(), user.ID, id, req)
+	if err != nil {
+		log.Errorf("[EnrollApplicant] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to enroll applicant").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetOpenCampaigns godoc
+// @Summary      Get open admission campaigns
+// @Description  Get campaigns accepting applications now with their programs: faculty, department, seats and applications
+// @Tags         admissions
+// @Produce      json
+// @Param        university_id  query     int  false  "University ID"
+// @Success      200            {array}   admissions.CampaignProgramsResponse
+// @Failure      400            {object}  APIError
+// @Failure      401            {object}  APIError
+// @Failure      500            {object}  APIError
+// @Router       /admissions/campaigns [get]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) GetOpenCampaigns(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetOpenCampaigns] called")
+
+	universityID, err := queryID(c, "university_id")
+	if err != nil {
+		return err
+	}
+
+	campaigns, err := h.admissionsServ.GetOpenCampaigns(c.Request().Context(), universityID)
+	if err != nil {
+		log.Errorf("[GetOpenCampaigns] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get campaigns").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, campaigns)
+}
+
+// Apply godoc
+// @Summary      Submit application
+// @Description  Apply to a program of an open campaign, one application per program. The score is entered by the admin after checking exam results
+// @Tags         admissions
+// @Accept       json
+// @Produce      json
+// @Param        request  body      admissions.ApplyRequest  true  "Application"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      409      {object}  APIError  "Program not found, campaign is closed, already applied"
+// @Failure      500      {object}  APIError
+// @Router       /admissions/applications [post]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) Apply(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[Apply] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[Apply] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	var req admissions.ApplyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[Apply] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[Apply] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.admissionsServ.Apply(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[Apply] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to submit application").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetApplications godoc
+// @Summary      Get my applications
+// @Description  Get applications of the user with status, place in the ranked list of the program and status history
+// @Tags         admissions
+// @Produce      json
+// @Success      200  {array}   admissions.ApplicationResponse
+// @Failure      401  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admissions/applications [get]
+// @Security     BearerAuth
+func (h *AdmissionsHandler) GetApplications(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetApplications] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetApplications] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	applications, err := h.admissionsServ.GetApplications(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetApplications] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get applications").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, applications)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/audit"
+	auditrepo "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/audit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+const (
+	auditDefaultLimit = 20
+	auditMaxLimit     = 100
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+	uniService   *services.UniService
+	logger       logging.Logger
+}
+
+func NewAuditHandler(auditService *services.AuditService, uniService *services.UniService, logger logging.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		uniService:   uniService,
+		logger:       logger,
+	}
+}
+
+// GetAuditLog godoc
+// @Summary      Audit log of the admin's university
+// @Description  Changes of administrative data made in the admin's university, newest first. Every entry has the actor, action (insert/update/delete), entity (schema.table and id) and row before/after the change. Admin role required.
+// @Tags         admin
+// @Produce      json
+// @Param        limit        query     int     false  "Limit, max(100), default(20)"
+// @Param        offset       query     int     false  "Offset, default(0)"
+// @Param        actor_id     query     int     false  "Filter by max_users_data.id of the actor"
+// @Param        action       query     string  false  "Filter by action" Enums(insert, update, delete)
+// @Param        entity_type  query     string  false  "Filter by entity, schema.table, e.g. schedules.rooms"
+// @Param        entity_id    query     string  false  "Filter by entity id"
+// @Param        from         query     string  false  "Changes made at or after, RFC3339"
+// @Param        to           query     string  false  "Changes made before, RFC3339"
+// @Success      200          {object}  audit.AuditLogResponse  "Audit log page"
+// @Failure      400          {object}  APIError                "Invalid query parameters"
+// @Failure      401          {object}  APIError                "Unauthorized user"
+// @Failure      403          {object}  APIError                "Forbidden - user is not admin"
+// @Failure      500          {object}  APIError                "Internal server error"
+// @Router       /admin/audit [get]
+// @Security     BearerAuth
+func (h *AuditHandler) GetAuditLog(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	ctx := c.Request().Context()
+
+	log.Print(c.Request().Context(), "[GetAuditLog] GetAuditLog called")
+
+	currentUser, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetAuditLog] User not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	uni, err := h.uniService.GetInfoAboutUni(ctx, currentUser.ID)
+	if err != nil {
+		log.Errorf("[GetAuditLog] failed to get admin university: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university").SetInternal(err)
+	}
+
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		log.Errorf("[GetAuditLog] invalid query: %v", err)
+		return err
+	}
+	filter.UniversityID = int64(uni.ID)
+
+	var response *audit.AuditLogResponse
+	response, err = h.auditService.GetAuditLog(ctx, filter)
+	if err != nil {
+		log.Errorf("[GetAuditLog] failed to get audit log: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get audit log").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func parseAuditFilter(c echo.Context) (auditrepo.Filter, error) {
+	params := c.QueryParams()
+	filter := auditrepo.Filter{Limit: auditDefaultLimit}
+
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || limit <= 0 {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "invalid limit")
+		}
+		filter.Limit = min(limit, auditMaxLimit)
+	}
+
+	if v := params.Get("offset"); v != "" {
+		offset, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || offset < 0 {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "invalid offset")
+		}
+		filter.Offset = offset
+	}
+
+	if v := params.Get("actor_id"); v != "" {
+		actorID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "invalid actor_id")
+		}
+		filter.ActorID = &actorID
+	}
+
+	if v := params.Get("action"); v != "" {
+		if v != "insert" && v != "update" && v != "delete" {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "invalid action")
+		}
+		filter.Action = &v
+	}
+
+	if v := params.Get("entity_type"); v != "" {
+		filter.EntityType = &v
+	}
+
+	if v := params.Get("entity_id"); v != "" {
+		filter.EntityID = &v
+	}
+
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		v := params.Get(name)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "invalid "+name+", need RFC3339")
+		}
+		*dst = &t
+	}
+
+	return filter, nil
+}
@@ -1,48 +1,51 @@
 package handlers
 
 import (
-	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"sort"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services/auth"
-	"github.com/vmkteam/embedlog"
 )
 
-var secure bool = true
+// RefreshCookie sets attributes of the refresh_token cookie.
+type RefreshCookie struct {
+	Secure   bool
+	SameSite http.SameSite
+}
 
 type AuthHandler struct {
-	jwtService  *auth.JWTService
-	userRepo    repositories.UserRepository
-	refreshRepo repositories.RefreshTokenRepository
-	botToken    string
+	jwtService    *auth.JWTService
+	userRepo      repositories.UserRepository
+	refreshRepo   repositories.RefreshTokenRepository
+	initData      *auth.InitDataValidator
+	devLogin      *auth.DevLogin
+	refreshCookie RefreshCookie
 }
 
 func NewAuthHandler(
 	jwt *auth.JWTService,
 	uRepo repositories.UserRepository,
 	rRepo repositories.RefreshTokenRepository,
-	bToken string,
+	initData *auth.InitDataValidator,
+	devLogin *auth.DevLogin,
+	refreshCookie RefreshCookie,
 ) *AuthHandler {
 	return &AuthHandler{
-		jwtService:  jwt,
-		userRepo:    uRepo,
-		refreshRepo: rRepo,
-		botToken:    bToken,
+		jwtService:    jwt,
+		userRepo:      uRepo,
+		refreshRepo:   rRepo,
+		initData:      initData,
+		devLogin:      devLogin,
+		refreshCookie: refreshCookie,
 	}
 }
 
@@ -61,16 +64,18 @@ type RefreshRequest struct {
 // @Tags         auth
 // @Accept       x-www-form-urlencoded
 // @Produce      json
-// @Param        auth_date  formData  string  true  "Authentication date"
+// @Param        auth_date  formData  string  true  "Authentication date, unix time. Older than auth.init_data_max_age is rejected"
 // @Param        hash       formData  string  true  "Authentication hash"
+// @Param        query_id   formData  string  false "WebApp session ID, every init data is accepted only once"
 // @Param        user       formData  string  false "User data JSON"
 // @Success      200        {object}  dto.LoginResponse  "JWT tokens"
-// @Failure      400        {object}  echo.HTTPError     "Invalid request data"
-// @Failure      401        {object}  echo.HTTPError     "Invalid init data"
-// @Failure      500        {object}  echo.HTTPError     "Internal server error"
+// @Failure      400        {object}  APIError           "Invalid request data"
+// @Failure      401        {object}  APIError           "Invalid init data"
+// @Failure      429        {object}  APIError           "Too many requests, see Retry-After"
+// @Failure      500        {object}  APIError           "Internal server error"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
 	if err := c.Request().ParseForm(); err != nil {
@@ -84,7 +89,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	userStr := c.Request().FormValue("user")
 
 	if authDate == "" || hash == "" {
-		log.Errorf("[Login] Missing required fields: auth_date=%s, hash=%s", authDate, hash)
+		log.Errorf("[Login] Missing required fields: auth_date=%s, hash_present=%t", authDate, hash != "")
 		return echo.NewHTTPError(http.StatusBadRequest, "Missing required fields")
 	}
 
@@ -103,9 +108,16 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		}
 	}
 
-	if !h.validateMAXData(c.Request().Form, hash) {
-		log.Errorf("[Login] Invalid MAX WebApp data")
-		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid init data")
+	if err := h.initData.Validate(c.Request().Form); err != nil {
+		log.Errorf("[Login] Invalid MAX WebApp data: %v", err)
+		switch {
+		case errors.Is(err, auth.ErrInitDataExpired):
+			return echo.NewHTTPError(http.StatusUnauthorized, "Init data expired")
+		case errors.Is(err, auth.ErrInitDataReplay):
+			return echo.NewHTTPError(http.StatusUnauthorized, "Init data already used")
+		default:
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid init data")
+		}
 	}
 
 	var user *models.User
@@ -128,12 +140,13 @@ func (h *AuthHandler) Login(c echo.Context) error {
 			err := h.userRepo.CreateNewUser(ctx, newUser)
 			if err != nil {
 				log.Errorf("[Login] Failed to create new user. err: %v", err)
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create new user")
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create new user").SetInternal(err)
 			}
 			user = newUser
 			log.Printf("[Login] Created new user with ID: %d", user.ID)
 		} else {
-			return echo.NewHTTPError(http.StatusUnauthorized, "Failed to find user. err: "+err.Error())
+			log.Errorf("[Login] Failed to find user. err: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find user").SetInternal(err)
 		}
 	} else {
 		// Пользователь существует - обновляем его данные
@@ -146,12 +159,44 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		err = h.userRepo.UpdateUser(ctx, user)
 		if err != nil {
 			log.Errorf("[Login] Failed to update user data. err: %v", err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update user data")
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update user data").SetInternal(err)
 		}
 		log.Printf("[Login] Updated user data for ID: %d", user.ID)
 	}
 
+	access, err := h.startSession(c, user)
+	if err != nil {
+		log.Errorf("[Login] %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error").SetInternal(err)
+	}
+
+	userRoles, err := h.userRepo.GetUserRolesByID(ctx, user.ID)
+	if err != nil {
+		log.Errorf("[Login] Failed find user roles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed find user roles").SetInternal(err)
+	}
+
+	responseUser := dto.User{
+		ID:        userData.ID,
+		FirstName: userData.FirstName,
+		LastName:  userData.LastName,
+		Username:  NewString(userData.Username),
+		PhotoURL:  NewString(userData.PhotoURL),
+	}
+
+	log.Printf("[Login] User id: %d logged in successfully", userData.ID)
+
+	return c.JSON(http.StatusOK, dto.LoginResponse{
+		AccessToken: access,
+		User:        responseUser,
+		UserRoles:   userRoles.Roles,
+	})
+}
+
+// startSession issues access token and sets refresh token cookie.
+func (h *AuthHandler) startSession(c echo.Context, user *models.User) (string, error) {
 	access, refresh, err := h.jwtService.GenerateTokenPair(
+		c.Request().Context(),
 		int(user.ID),
 		user.LastActivityTime,
 		user.FirstName,
@@ -163,8 +208,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		user.IsBot,
 	)
 	if err != nil {
-		log.Errorf("[Login] Token generation error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error")
+		return "", fmt.Errorf("token generation error: %w", err)
 	}
 
 	expires := time.Now().Add(h.jwtService.RefreshExpiry())
@@ -177,8 +221,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	}
 
 	if err := h.refreshRepo.Save(rt); err != nil {
-		log.Printf("[Login] Refresh token save error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error")
+		return "", fmt.Errorf("refresh token save error: %w", err)
 	}
 
 	c.SetCookie(&http.Cookie{
@@ -186,31 +229,75 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		Value:    refresh,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   secure,
-		SameSite: http.SameSiteNoneMode,
+		Secure:   h.refreshCookie.Secure,
+		SameSite: h.refreshCookie.SameSite,
 		Expires:  expires,
 	})
 
-	userRoles, err := h.userRepo.GetUserRolesByID(ctx, user.ID)
+	return access, nil
+}
+
+// DevLogin godoc
+// @Summary      Development login without MAX init data
+// @Description  Issue tokens for the fixture user or for given max_users_data.id. Available only with server.is_devel and auth.dev_login in non-production builds, otherwise 404.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.DevLoginRequest  false  "User ID, fixture user if empty"
+// @Success      200      {object}  dto.LoginResponse    "JWT tokens"
+// @Failure      400      {object}  APIError             "Invalid request body"
+// @Failure      404      {object}  APIError             "Dev login disabled or user not found"
+// @Failure      500      {object}  APIError             "Internal server error"
+// @Router       /auth/dev/login [post]
+func (h *AuthHandler) DevLogin(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	ctx := c.Request().Context()
+
+	if h.devLogin == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Not Found")
+	}
+
+	var req dto.DevLoginRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[DevLogin] Failed to bind request: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[DevLogin] invalid request: %v", err)
+		return err
+	}
+
+	user, err := h.devLogin.User(ctx, req.UserID)
 	if err != nil {
-		log.Errorf("[Login] Failed find user roles: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed find user roles")
+		log.Errorf("[DevLogin] Failed to get user: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").SetInternal(err)
 	}
 
-	responseUser := dto.User{
-		ID:        userData.ID,
-		FirstName: userData.FirstName,
-		LastName:  userData.LastName,
-		Username:  NewString(userData.Username),
-		PhotoURL:  NewString(userData.PhotoURL),
+	access, err := h.startSession(c, user)
+	if err != nil {
+		log.Errorf("[DevLogin] %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error").SetInternal(err)
+	}
+
+	userRoles, err := h.userRepo.GetUserRolesByID(ctx, user.ID)
+	if err != nil {
+		log.Errorf("[DevLogin] Failed find user roles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed find user roles").SetInternal(err)
 	}
 
-	log.Printf("[Login] User id: %d, name: %v logged in successfully. AccessToken: %v  ", userData.ID, userData.FirstName, access)
+	log.Printf("[DevLogin] User id: %d logged in without init data", user.ID)
 
 	return c.JSON(http.StatusOK, dto.LoginResponse{
 		AccessToken: access,
-		User:        responseUser,
-		UserRoles:   userRoles.Roles,
+		User: dto.User{
+			ID:        int(user.ID),
+			FirstName: user.FirstName,
+			LastName:  NewString(user.LastName),
+			Username:  NewString(user.UserName),
+			PhotoURL:  NewString(user.AvatarUrl),
+		},
+		UserRoles: userRoles.Roles,
 	})
 }
 
@@ -221,36 +308,6 @@ func NewString(str *string) string {
 	return *str
 }
 
-func (h *AuthHandler) validateMAXData(form url.Values, receivedHash string) bool {
-
-	var dataCheckStrings []string
-
-	for key, values := range form {
-
-		if key == "hash" {
-			continue
-		}
-
-		if len(values) > 0 {
-			dataCheckStrings = append(dataCheckStrings, fmt.Sprintf("%s=%s", key, values[0]))
-		}
-	}
-
-	sort.Strings(dataCheckStrings)
-
-	dataCheckString := strings.Join(dataCheckStrings, "\n")
-
-	mac := hmac.New(sha256.New, []byte("WebAppData"))
-	mac.Write([]byte(h.botToken))
-	secretKey := mac.Sum(nil)
-
-	mac = hmac.New(sha256.New, secretKey)
-	mac.Write([]byte(dataCheckString))
-	calculatedHash := hex.EncodeToString(mac.Sum(nil))
-
-	return calculatedHash == receivedHash
-}
-
 // Refresh godoc
 // @Summary      Refresh JWT tokens
 // @Description  Refresh access and refresh tokens using a valid refresh token
@@ -259,18 +316,19 @@ func (h *AuthHandler) validateMAXData(form url.Values, receivedHash string) bool
 // @Produce      json
 // @Param        request  body      RefreshRequest      true  "Refresh token"
 // @Success      200      {object}  dto.LoginResponse   "New JWT tokens"
-// @Failure      400      {object}  echo.HTTPError      "Invalid request body"
-// @Failure      401      {object}  echo.HTTPError      "Invalid or expired refresh token"
-// @Failure      500      {object}  echo.HTTPError      "Internal server error"
+// @Failure      400      {object}  APIError            "Invalid request body"
+// @Failure      401      {object}  APIError            "Invalid or expired refresh token"
+// @Failure      429      {object}  APIError            "Too many requests, see Retry-After"
+// @Failure      500      {object}  APIError            "Internal server error"
 // @Router       /auth/refresh [post]
 func (h *AuthHandler) Refresh(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
 	cookie, err := c.Cookie("refresh_token")
 	if err != nil {
 		log.Errorf("[Refresh] Refresh cookie error. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Refresh cookie error")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Refresh cookie error").SetInternal(err)
 	}
 	refreshRaw := cookie.Value
 	rt, err := h.refreshRepo.Find(refreshRaw)
@@ -292,10 +350,11 @@ func (h *AuthHandler) Refresh(c echo.Context) error {
 	user, err := h.userRepo.GetUserByID(ctx, int64(rt.UserID))
 	if err != nil {
 		log.Errorf("[Refresh] User lookup error: %d", rt.UserID)
-		return echo.NewHTTPError(http.StatusInternalServerError, "System error")
+		return echo.NewHTTPError(http.StatusInternalServerError, "System error").SetInternal(err)
 	}
 
 	access, refresh, err := h.jwtService.GenerateTokenPair(
+		ctx,
 		int(user.ID),
 		user.LastActivityTime,
 		user.FirstName,
@@ -308,7 +367,7 @@ func (h *AuthHandler) Refresh(c echo.Context) error {
 	)
 	if err != nil {
 		log.Errorf("[Refresh] Token generation error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error").SetInternal(err)
 	}
 
 	expires := time.Now().Add(h.jwtService.RefreshExpiry())
@@ -322,15 +381,15 @@ func (h *AuthHandler) Refresh(c echo.Context) error {
 
 	if err := h.refreshRepo.Save(newRT); err != nil {
 		log.Printf("[Refresh] Refresh token save error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Authentication error").SetInternal(err)
 	}
 	c.SetCookie(&http.Cookie{
 		Name:     "refresh_token",
 		Value:    refresh,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   secure,
-		SameSite: http.SameSiteNoneMode,
+		Secure:   h.refreshCookie.Secure,
+		SameSite: h.refreshCookie.SameSite,
 		Expires:  expires,
 	})
 	return c.JSON(http.StatusOK, dto.LoginResponse{
@@ -338,6 +397,64 @@ func (h *AuthHandler) Refresh(c echo.Context) error {
 	})
 }
 
+// Logout godoc
+// @Summary      Logout
+// @Description  End all sessions of the user: refresh tokens are deleted and access tokens issued so far stop working on every instance.
+// @Description  Not available to impersonation tokens
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      401  {object}  APIError           "Unauthorized - invalid or missing token"
+// @Failure      403  {object}  APIError           "Impersonation token"
+// @Failure      500  {object}  APIError           "Internal server error"
+// @Router       /auth/logout [post]
+// @Security     BearerAuth
+func (h *AuthHandler) Logout(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	ctx := c.Request().Context()
+
+	user := auth.GetUserFromContext(c)
+	if user == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+	// an admin acting as the user must not end the user's own sessions
+	if _, ok := auth.GetActorFromContext(c); ok {
+		return echo.NewHTTPError(http.StatusForbidden, "logout is not available to impersonation tokens")
+	}
+
+	if err := h.refreshRepo.DeleteByUser(int(user.ID)); err != nil {
+		log.Errorf("[Logout] refresh tokens delete error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to logout").SetInternal(err)
+	}
+	if err := h.jwtService.RevokeUserTokens(ctx, user.ID); err != nil {
+		log.Errorf("[Logout] revoke tokens error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to logout").SetInternal(err)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.refreshCookie.Secure,
+		SameSite: h.refreshCookie.SameSite,
+		MaxAge:   -1,
+	})
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// JWKS godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys for verifying access tokens signed with RS256/EdDSA. Keys are identified by kid header of the token. Empty for HS256.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  auth.JWKS  "Active public keys"
+// @Router       /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
+
 type TokenCheckResponse struct {
 	AccessToken  any
 	RefreshToken any
@@ -361,14 +478,14 @@ type UserInfo struct {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  map[string]string  "status: token is valid"
-// @Failure      401  {object}  echo.HTTPError     "Unauthorized - invalid or missing token"
-// @Failure      500  {object}  echo.HTTPError     "Internal server error"
+// @Failure      401  {object}  APIError           "Unauthorized - invalid or missing token"
+// @Failure      500  {object}  APIError           "Internal server error"
 // @Router       /auth/checkToken [get]
 // @Security     BearerAuth
 func (h *AuthHandler) CheckToken(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[CheckToken] CheckToken called")
+	log.Print(c.Request().Context(), "[CheckToken] CheckToken called")
 	user := auth.GetUserFromContext(c)
 	if user == nil {
 		log.Printf("User not found in context")
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/bookings"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// BookingsHandler serves room bookings: teachers and students of a university request them, admins decide.
+type BookingsHandler struct {
+	bookingsServ *services.BookingsService
+	userServ     *services.UserService
+	logger       logging.Logger
+}
+
+func NewBookingsHandler(bookingsServ *services.BookingsService, userServ *services.UserService, logger logging.Logger) *BookingsHandler {
+	return &BookingsHandler{
+		bookingsServ: bookingsServ,
+		userServ:     userServ,
+		logger:       logger,
+	}
+}
+
+// CreateBooking godoc
+// @Summary      Book room
+// @Description  Book a room for a pair of the bell schedule on a date for a meeting, an exam or a club event. Admins, teachers and active students
+// @Description  of the room's university may book. Bookings of admins are confirmed at once, others are pending until an admin decides.
+// @Description  The pair must be free of lessons of the semester covering the date, every two week lessons included, and of confirmed bookings
+// @Tags         bookings
+// @Accept       json
+// @Produce      json
+// @Param        request  body      bookings.CreateBookingRequest  true  "Booking"
+// @Success      200      {object}  bookings.CreateBookingResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError  "Not a member of the university"
+// @Failure      409      {object}  APIError  "Schedule conflict, room or pair not found"
+// @Failure      500      {object}  APIError
+// @Router       /bookings [post]
+// @Security     BearerAuth
+func (h *BookingsHandler) CreateBooking(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateBooking] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[CreateBooking] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	var req bookings.CreateBookingRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateBooking] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateBooking] invalid request: %v", err)
+		return err
+	}
+
+	booking, err := h.bookingsServ.CreateBooking(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[CreateBooking] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create booking").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, booking)
+}
+
+// GetMyBookings godoc
+// @Summary      Get my bookings
+// @Description  Get bookings requested by the user with their status, the latest dates first
+// @Tags         bookings
+// @Produce      json
+// @Success      200  {array}   bookings.BookingResponse
+// @Failure      401  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /bookings [get]
+// @Security     BearerAuth
+func (h *BookingsHandler) GetMyBookings(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetMyBookings] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetMyBookings] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	result, err := h.bookingsServ.GetUserBookings(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetMyBookings] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get bookings").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// CancelBooking godoc
+// @Summary      Cancel booking
+// @Description  Cancel a pending or confirmed booking requested by the user, admins of the university may cancel any of its bookings
+// @Tags         bookings
+// @Produce      json
+// @Param        id   path      int                true  "Booking ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Booking is already rejected or cancelled"
+// @Failure      500  {object}  APIError
+// @Router       /bookings/{id}/cancel [post]
+// @Security     BearerAuth
+func (h *BookingsHandler) CancelBooking(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CancelBooking] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[CancelBooking] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	if err := h.bookingsServ.Cancel(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[CancelBooking] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to cancel booking").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetBookings godoc
+// @Summary      Get bookings of university
+// @Description  Get bookings of the admin's university ordered by date and pair, pending ones are waiting for a decision
+// @Tags         bookings
+// @Produce      json
+// @Param        university_id  query     int     true   "University ID"
+// @Param        status         query     string  false  "pending, confirmed, rejected or cancelled"
+// @Param        room_id        query     int     false  "Room ID"
+// @Param        from           query     string  false  "First date, 2006-01-02"
+// @Param        to             query     string  false  "Last date, 2006-01-02"
+// @Success      200            {array}   bookings.BookingResponse
+// @Failure      400            {object}  APIError
+// @Failure      401            {object}  APIError
+// @Failure      403            {object}  APIError
+// @Failure      404            {object}  APIError  "University not found"
+// @Failure      500            {object}  APIError
+// @Router       /admin/bookings [get]
+// @Security     BearerAuth
+func (h *BookingsHandler) GetBookings(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetBookings] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req bookings.BookingsRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[GetBookings] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[GetBookings] invalid request: %v", err)
+		return err
+	}
+
+	result, err := h.bookingsServ.GetBookings(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[GetBookings] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get bookings").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ConfirmBooking godoc
+// @Summary      Confirm booking
+// @Description  Confirm a pending booking of the admin's university, its pair must still be free of lessons and confirmed bookings
+// @Tags         bookings
+// @Produce      json
+// @Param        id   path      int                true  "Booking ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Schedule conflict, booking is not pending or its date has passed"
+// @Failure      500  {object}  APIError
+// @Router       /admin/bookings/{id}/confirm [post]
+// @Security     BearerAuth
+func (h *BookingsHandler) ConfirmBooking(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[ConfirmBooking] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.bookingsServ.Confirm(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[ConfirmBooking] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to confirm booking").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// RejectBooking godoc
+// @Summary      Reject booking
+// @Description  Reject a pending booking of the admin's university
+// @Tags         bookings
+// @Produce      json
+// @Param        id   path      int                true  "Booking ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Booking is not pending"
+// @Failure      500  {object}  APIError
+// @Router       /admin/bookings/{id}/reject [post]
+// @Security     BearerAuth
+func (h *BookingsHandler) RejectBooking(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[RejectBooking] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.bookingsServ.Reject(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[RejectBooking] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to reject booking").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
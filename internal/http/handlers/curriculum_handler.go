@@ -0,0 +1,456 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// CurriculumHandler serves the curriculum of the admin's university: subjects of courses by semester
+// and teachers assigned to course and elective groups. Ids of assignments are used by lessons.
+type CurriculumHandler struct {
+	curriculumServ *services.CurriculumService
+	userServ       *services.UserService
+	logger         logging.Logger
+}
+
+func NewCurriculumHandler(curriculumServ *services.CurriculumService, userServ *services.UserService, logger logging.Logger) *CurriculumHandler {
+	return &CurriculumHandler{
+		curriculumServ: curriculumServ,
+		userServ:       userServ,
+		logger:         logger,
+	}
+}
+
+// GetSemesters godoc
+// @Summary      Get semesters
+// @Description  Get semesters of the admin's universities
+// @Tags         curriculum
+// @Produce      json
+// @Success      200  {array}   curriculum.SemesterResponse
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/curriculum/semesters [get]
+// @Security     BearerAuth
+func (h *CurriculumHandler) GetSemesters(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetSemesters] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	semesters, err := h.curriculumServ.GetSemesters(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetSemesters] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get semesters").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, semesters)
+}
+
+// AddCourseSubject godoc
+// @Summary      Add subject to course
+// @Description  Attach university subject to a course for a semester with required hours
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                true  "Course ID"
+// @Param        request  body      curriculum.AddCourseSubjectRequest  true  "Subject of course"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError  "Course not found"
+// @Failure      409      {object}  APIError  "Semester or subject not found, subject already added"
+// @Failure      500      {object}  APIError
+// @Router       /admin/curriculum/courses/{id}/subjects [post]
+// @Security     BearerAuth
+func (h *CurriculumHandler) AddCourseSubject(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[AddCourseSubject] called")
+
+	user, courseID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req curriculum.AddCourseSubjectRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[AddCourseSubject] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[AddCourseSubject] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.curriculumServ.AddCourseSubject(c.Request().Context(), user.ID, courseID, req)
+	if err != nil {
+		log.Errorf("[AddCourseSubject] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to add subject to course").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetCourseSubjects godoc
+// @Summary      Get subjects of course
+// @Tags         curriculum
+// @Produce      json
+// @Param        id           path      int  true   "Course ID"
+// @Param        semester_id  query     int  false  "Semester ID"
+// @Success      200          {array}   curriculum.CourseSubjectResponse
+// @Failure      400          {object}  APIError
+// @Failure      401          {object}  APIError
+// @Failure      403          {object}  APIError
+// @Failure      500          {object}  APIError
+// @Router       /admin/curriculum/courses/{id}/subjects [get]
+// @Security     BearerAuth
+func (h *CurriculumHandler) GetCourseSubjects(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetCourseSubjects] called")
+
+	user, courseID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	semesterID, err := queryID(c, "semester_id")
+	if err != nil {
+		return err
+	}
+
+	subjects, err := h.curriculumServ.GetCourseSubjects(c.Request().Context(), user.ID, courseID, semesterID)
+	if err != nil {
+		log.Errorf("[GetCourseSubjects] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get subjects of course").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, subjects)
+}
+
+// UpdateCourseSubject godoc
+// @Summary      Update required hours of course subject
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                   true  "Course subject ID"
+// @Param        request  body      curriculum.UpdateCourseSubjectRequest  true  "Hours, null to clear"
+// @Success      200      {object}  map[string]string                     "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      500      {object}  APIError
+// @Router       /admin/curriculum/course-subjects/{id} [put]
+// @Security     BearerAuth
+func (h *CurriculumHandler) UpdateCourseSubject(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateCourseSubject] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req curriculum.UpdateCourseSubjectRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateCourseSubject] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateCourseSubject] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.curriculumServ.UpdateCourseSubject(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateCourseSubject] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update course subject").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteCourseSubject godoc
+// @Summary      Remove subject from course
+// @Tags         curriculum
+// @Produce      json
+// @Param        id   path      int                true  "Course subject ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Teachers are assigned to the subject"
+// @Failure      500  {object}  APIError
+// @Router       /admin/curriculum/course-subjects/{id} [delete]
+// @Security     BearerAuth
+func (h *CurriculumHandler) DeleteCourseSubject(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteCourseSubject] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.curriculumServ.DeleteCourseSubject(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteCourseSubject] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete course subject").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetGroupCurriculum godoc
+// @Summary      Get curriculum of group
+// @Description  Get subjects of the group's course with teachers assigned to the group, subjects without teacher have no assignment
+// @Tags         curriculum
+// @Produce      json
+// @Param        id           path      int  true   "Group ID"
+// @Param        semester_id  query     int  false  "Semester ID"
+// @Success      200          {array}   curriculum.CurriculumItemResponse
+// @Failure      400          {object}  APIError
+// @Failure      401          {object}  APIError
+// @Failure      403          {object}  APIError
+// @Failure      500          {object}  APIError
+// @Router       /admin/curriculum/groups/{id} [get]
+// @Security     BearerAuth
+func (h *CurriculumHandler) GetGroupCurriculum(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetGroupCurriculum] called")
+
+	user, groupID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	semesterID, err := queryID(c, "semester_id")
+	if err != nil {
+		return err
+	}
+
+	items, err := h.curriculumServ.GetGroupCurriculum(c.Request().Context(), user.ID, groupID, semesterID)
+	if err != nil {
+		log.Errorf("[GetGroupCurriculum] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get curriculum of group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, items)
+}
+
+// AssignGroupTeacher godoc
+// @Summary      Assign teacher to group subject
+// @Description  Assign teacher of the university to teach a course subject of given type to a group. Returned id is course_group_subject_id of lessons.
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                             true  "Group ID"
+// @Param        request  body      curriculum.AssignTeacherRequest  true  "Assignment"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError  "Group not found"
+// @Failure      409      {object}  APIError  "Subject or teacher not found, subject is elective, already assigned"
+// @Failure      500      {object}  APIError
+// @Router       /admin/curriculum/groups/{id}/subjects [post]
+// @Security     BearerAuth
+func (h *CurriculumHandler) AssignGroupTeacher(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[AssignGroupTeacher] called")
+
+	user, groupID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req curriculum.AssignTeacherRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[AssignGroupTeacher] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[AssignGroupTeacher] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.curriculumServ.AssignGroupSubject(c.Request().Context(), user.ID, groupID, req)
+	if err != nil {
+		log.Errorf("[AssignGroupTeacher] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to assign teacher").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// UpdateGroupTeacher godoc
+// @Summary      Change teacher of group subject
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                true  "Group subject ID"
+// @Param        request  body      curriculum.UpdateAssignmentRequest  true  "Assignment"
+// @Success      200      {object}  map[string]string                  "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Teacher not found or already assigned"
+// @Failure      500      {object}  APIError
+// @Router       /admin/curriculum/group-subjects/{id} [put]
+// @Security     BearerAuth
+func (h *CurriculumHandler) UpdateGroupTeacher(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateGroupTeacher] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req curriculum.UpdateAssignmentRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateGroupTeacher] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateGroupTeacher] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.curriculumServ.UpdateGroupSubject(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateGroupTeacher] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to change teacher").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteGroupTeacher godoc
+// @Summary      Remove teacher from group subject
+// @Tags         curriculum
+// @Produce      json
+// @Param        id   path      int                true  "Group subject ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Lessons are scheduled for the assignment"
+// @Failure      500  {object}  APIError
+// @Router       /admin/curriculum/group-subjects/{id} [delete]
+// @Security     BearerAuth
+func (h *CurriculumHandler) DeleteGroupTeacher(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteGroupTeacher] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.curriculumServ.DeleteGroupSubject(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteGroupTeacher] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to remove teacher").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// AssignElectiveTeacher godoc
+// @Summary      Assign teacher to elective group
+// @Description  Returned id is elective_group_subject_id of lessons
+// @Tags         curriculum
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                     true  "Elective group ID"
+// @Param        request  body      curriculum.AssignElectiveTeacherRequest  true  "Assignment"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError  "Elective group not found"
+// @Failure      409      {object}  APIError  "Teacher not found or already assigned"
+// @Failure      500      {object}  APIError
+// @Router       /admin/curriculum/electives/{id}/subjects [post]
+// @Security     BearerAuth
+func (h *CurriculumHandler) AssignElectiveTeacher(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[AssignElectiveTeacher] called")
+
+	user, electiveGroupID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req curriculum.AssignElectiveTeacherRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[AssignElectiveTeacher] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[AssignElectiveTeacher] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.curriculumServ.AssignElectiveSubject(c.Request().Context(), user.ID, electiveGroupID, req)
+	if err != nil {
+		log.Errorf("[AssignElectiveTeacher] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to assign teacher").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// DeleteElectiveTeacher godoc
+// @Summary      Remove teacher from elective group
+// @Tags         curriculum
+// @Produce      json
+// @Param        id   path      int                true  "Elective group subject ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Lessons are scheduled for the assignment"
+// @Failure      500  {object}  APIError
+// @Router       /admin/curriculum/elective-subjects/{id} [delete]
+// @Security     BearerAuth
+func (h *CurriculumHandler) DeleteElectiveTeacher(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteElectiveTeacher] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.curriculumServ.DeleteElectiveSubject(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteElectiveTeacher] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to remove teacher").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// queryID parses optional positive id from query param name, 0 if it is not set.
+func queryID(c echo.Context, name string) (int64, error) {
+	s := c.QueryParam(name)
+	if s == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "invalid "+name)
+	}
+	return id, nil
+}
@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/electives"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// ElectivesHandler serves elective groups and selection windows to admins and elective choice to students.
+type ElectivesHandler struct {
+	electivesServ *services.ElectivesService
+	userServ      *services.UserService
+	logger        logging.Logger
+}
+
+func NewElectivesHandler(electivesServ *services.ElectivesService, userServ *services.UserService, logger logging.Logger) *ElectivesHandler {
+	return &ElectivesHandler{
+		electivesServ: electivesServ,
+		userServ:      userServ,
+		logger:        logger,
+	}
+}
+
+// CreateGroup godoc
+// @Summary      Create elective group
+// @Description  Create elective group of a semester for a university subject, capacity omitted is unlimited
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        request  body      electives.CreateGroupRequest  true  "Elective group"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      409      {object}  APIError  "Semester or subject not found, group already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/electives/groups [post]
+// @Security     BearerAuth
+func (h *ElectivesHandler) CreateGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateElectiveGroup] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req electives.CreateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateElectiveGroup] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateElectiveGroup] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.electivesServ.CreateGroup(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[CreateElectiveGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create elective group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetGroups godoc
+// @Summary      Get elective groups
+// @Description  Get elective groups of the admin's universities with enrolled students and free seats
+// @Tags         electives
+// @Produce      json
+// @Param        semester_id  query     int  false  "Semester ID"
+// @Success      200          {array}   electives.GroupResponse
+// @Failure      400          {object}  APIError
+// @Failure      401          {object}  APIError
+// @Failure      403          {object}  APIError
+// @Failure      500          {object}  APIError
+// @Router       /admin/electives/groups [get]
+// @Security     BearerAuth
+func (h *ElectivesHandler) GetGroups(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetElectiveGroups] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	semesterID, err := queryID(c, "semester_id")
+	if err != nil {
+		return err
+	}
+
+	groups, err := h.electivesServ.GetGroups(c.Request().Context(), user.ID, semesterID)
+	if err != nil {
+		log.Errorf("[GetElectiveGroups] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get elective groups").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+// UpdateGroup godoc
+// @Summary      Update elective group
+// @Description  Rename elective group and change its capacity, capacity can't be less than enrolled students
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "Elective group ID"
+// @Param        request  body      electives.UpdateGroupRequest  true  "Elective group"
+// @Success      200      {object}  map[string]string             "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      500      {object}  APIError
+// @Router       /admin/electives/groups/{id} [put]
+// @Security     BearerAuth
+func (h *ElectivesHandler) UpdateGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateElectiveGroup] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req electives.UpdateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateElectiveGroup] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateElectiveGroup] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.electivesServ.UpdateGroup(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateElectiveGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update elective group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteGroup godoc
+// @Summary      Delete elective group
+// @Tags         electives
+// @Produce      json
+// @Param        id   path      int                true  "Elective group ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Students, teachers or preferences reference the group"
+// @Failure      500  {object}  APIError
+// @Router       /admin/electives/groups/{id} [delete]
+// @Security     BearerAuth
+func (h *ElectivesHandler) DeleteGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteElectiveGroup] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.electivesServ.DeleteGroup(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteElectiveGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete elective group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// CreateWindow godoc
+// @Summary      Open selection window
+// @Description  Create selection window of electives for a semester, first_come enrolls students at once while seats last, lottery collects ranked preferences and enrolls by draw after the window closes
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        request  body      electives.CreateWindowRequest  true  "Selection window"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      409      {object}  APIError  "Semester not found, semester already has a window"
+// @Failure      500      {object}  APIError
+// @Router       /admin/electives/windows [post]
+// @Security     BearerAuth
+func (h *ElectivesHandler) CreateWindow(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateElectiveWindow] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req electives.CreateWindowRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateElectiveWindow] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateElectiveWindow] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.electivesServ.CreateWindow(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[CreateElectiveWindow] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create selection window").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetWindows godoc
+// @Summary      Get selection windows
+// @Description  Get selection windows of the admin's universities
+// @Tags         electives
+// @Produce      json
+// @Success      200  {array}   electives.WindowResponse
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/electives/windows [get]
+// @Security     BearerAuth
+func (h *ElectivesHandler) GetWindows(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetElectiveWindows] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	windows, err := h.electivesServ.GetWindows(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetElectiveWindows] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get selection windows").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, windows)
+}
+
+// UpdateWindow godoc
+// @Summary      Update selection window
+// @Description  Change dates and max choices of a selection window, a drawn window can't be changed
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                            true  "Selection window ID"
+// @Param        request  body      electives.UpdateWindowRequest  true  "Selection window"
+// @Success      200      {object}  map[string]string              "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Lottery is already drawn"
+// @Failure      500      {object}  APIError
+// @Router       /admin/electives/windows/{id} [put]
+// @Security     BearerAuth
+func (h *ElectivesHandler) UpdateWindow(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateElectiveWindow] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req electives.UpdateWindowRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateElectiveWindow] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateElectiveWindow] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.electivesServ.UpdateWindow(c.Request().Context(), user.ID, id, req); err != nil {
+		log.Errorf("[UpdateElectiveWindow] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update selection window").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DrawLottery godoc
+// @Summary      Draw lottery
+// @Description  Enroll students of a closed lottery window by their ranked preferences in random order, the seed is saved to reproduce the draw
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                    true   "Selection window ID"
+// @Param        request  body      electives.DrawRequest  false  "Seed of the draw"
+// @Success      200      {object}  electives.DrawResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Window is not a lottery, not closed or already drawn"
+// @Failure      500      {object}  APIError
+// @Router       /admin/electives/windows/{id}/draw [post]
+// @Security     BearerAuth
+func (h *ElectivesHandler) DrawLottery(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DrawLottery] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req electives.DrawRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			log.Errorf("[DrawLottery] invalid request data. err: %v", err)
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+		}
+	}
+
+	response, err := h.electivesServ.DrawLottery(c.Request().Context(), user.ID, id, req)
+	if err != nil {
+		log.Errorf("[DrawLottery] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to draw lottery").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetStudentWindows godoc
+// @Summary      Get my selection windows
+// @Description  Get selection windows of current and future semesters of the student's universities
+// @Tags         electives
+// @Produce      json
+// @Success      200  {array}   electives.WindowResponse
+// @Failure      401  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /electives/windows [get]
+// @Security     BearerAuth
+func (h *ElectivesHandler) GetStudentWindows(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetStudentWindows] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetStudentWindows] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	windows, err := h.electivesServ.GetStudentWindows(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetStudentWindows] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get selection windows").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, windows)
+}
+
+// GetStudentWindow godoc
+// @Summary      Get my selection window
+// @Description  Get electives offered to the student's course in the window with free seats, the student's choices and lessons clashing with the student's group schedule
+// @Tags         electives
+// @Produce      json
+// @Param        id   path      int  true  "Selection window ID"
+// @Success      200  {object}  electives.StudentWindowResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError  "Not a student of the window's university"
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /electives/windows/{id} [get]
+// @Security     BearerAuth
+func (h *ElectivesHandler) GetStudentWindow(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetStudentWindow] called")
+
+	user, id, err := userAndID(c)
+	if err != nil {
+		return err
+	}
+
+	window, err := h.electivesServ.GetStudentWindow(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetStudentWindow] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get selection window").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, window)
+}
+
+// SetChoices godoc
+// @Summary      Choose electives
+// @Description  Replace the student's choices in an open window. In a first_come window the student is enrolled at once if seats are left, in a lottery window choices are preferences in the given order. Clashes with the student's group schedule are returned as warnings
+// @Tags         electives
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Selection window ID"
+// @Param        request  body      electives.ChoicesRequest  true  "Chosen elective groups"
+// @Success      200      {object}  electives.ChoicesResponse
+// @Failure      400      {object}  APIError  "Too many, duplicate or not offered electives"
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError  "Not a student of the window's university"
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Window is closed, group is full"
+// @Failure      500      {object}  APIError
+// @Router       /electives/windows/{id}/choices [put]
+// @Security     BearerAuth
+func (h *ElectivesHandler) SetChoices(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[SetElectiveChoices] called")
+
+	user, id, err := userAndID(c)
+	if err != nil {
+		return err
+	}
+
+	var req electives.ChoicesRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[SetElectiveChoices] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[SetElectiveChoices] invalid request: %v", err)
+		return err
+	}
+
+	choices, err := h.electivesServ.SetChoices(c.Request().Context(), user.ID, id, req)
+	if err != nil {
+		log.Errorf("[SetElectiveChoices] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to save choices").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, choices)
+}
+
+// userAndID returns authenticated user and parses :id path param, access is checked by the service.
+func userAndID(c echo.Context) (*models.User, int64, error) {
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		return nil, 0, echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return nil, 0, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return user, id, nil
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import "github.com/max-main-team/backend_hackaton_MAX/internal/services"
+
+// APIError is the body of every error response.
+type APIError struct {
+	Error APIErrorBody `json:"error"`
+}
+
+type APIErrorBody struct {
+	Code      services.ErrorCode    `json:"code" swaggertype:"string" example:"not_found"`
+	Message   string                `json:"message" example:"entity not found"`
+	Details   []services.FieldError `json:"details,omitempty"`
+	RequestID string                `json:"request_id,omitempty" example:"1700000000000000000"`
+}
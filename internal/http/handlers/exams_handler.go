@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/exams"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// ExamsHandler serves exam sessions: admins schedule exams and tests of groups, students get their exam timetable.
+type ExamsHandler struct {
+	examsServ *services.ExamsService
+	userServ  *services.UserService
+	logger    logging.Logger
+}
+
+func NewExamsHandler(examsServ *services.ExamsService, userServ *services.UserService, logger logging.Logger) *ExamsHandler {
+	return &ExamsHandler{
+		examsServ: examsServ,
+		userServ:  userServ,
+		logger:    logger,
+	}
+}
+
+// CreateSession godoc
+// @Summary      Create exam session
+// @Description  Create an exam session of a semester of the admin's university, its dates must be within the semester.
+// @Description  Exams of a group in the session are at least min_gap_days days apart. Students see the session once it is published
+// @Tags         exams
+// @Accept       json
+// @Produce      json
+// @Param        request  body      exams.CreateSessionRequest  true  "Exam session"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      409      {object}  APIError  "Semester not found"
+// @Failure      500      {object}  APIError
+// @Router       /admin/exam-sessions [post]
+// @Security     BearerAuth
+func (h *ExamsHandler) CreateSession(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateSession] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req exams.CreateSessionRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateSession] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateSession] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.examsServ.CreateSession(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[CreateSession] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create exam session").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetSessions godoc
+// @Summary      Get exam sessions
+// @Description  Get exam sessions of the admin's university with the number of their exams, the latest first
+// @Tags         exams
+// @Produce      json
+// @Param        university_id  query     int  true  "University ID"
+// @Success      200            {array}   exams.SessionResponse
+// @Failure      400            {object}  APIError
+// @Failure      401            {object}  APIError
+// @Failure      403            {object}  APIError
+// @Failure      500            {object}  APIError
+// @Router       /admin/exam-sessions [get]
+// @Security     BearerAuth
+func (h *ExamsHandler) GetSessions(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetSessions] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req exams.SessionsRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[GetSessions] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[GetSessions] invalid request: %v", err)
+		return err
+	}
+
+	result, err := h.examsServ.GetSessions(c.Request().Context(), user.ID, req.UniversityID)
+	if err != nil {
+		log.Errorf("[GetSessions] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get exam sessions").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// PublishSession godoc
+// @Summary      Publish exam session
+// @Description  Publish an exam session, its exams appear in timetables of students in the API and the bot. Publishing twice keeps the first date
+// @Tags         exams
+// @Produce      json
+// @Param        id   path      int                true  "Exam session ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/exam-sessions/{id}/publish [post]
+// @Security     BearerAuth
+func (h *ExamsHandler) PublishSession(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[PublishSession] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.examsServ.PublishSession(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[PublishSession] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to publish exam session").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// CreateExam godoc
+// @Summary      Create exam
+// @Description  Schedule an exam or a test of a course group in a subject of the session's semester on a date of the session.
+// @Description  A group sits at most one exam a day and its exams are at least min_gap_days days apart. Rooms must be free of exams,
+// @Description  lessons and confirmed bookings at that time, the examiner must be free of exams and lessons
+// @Tags         exams
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "Exam session ID"
+// @Param        request  body      exams.CreateExamRequest  true  "Exam"
+// @Success      200      {object}  curriculum.CreatedResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      409      {object}  APIError  "Schedule conflict, session, subject, group, examiner or room not found"
+// @Failure      500      {object}  APIError
+// @Router       /admin/exam-sessions/{id}/exams [post]
+// @Security     BearerAuth
+func (h *ExamsHandler) CreateExam(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateExam] called")
+
+	user, sessionID, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req exams.CreateExamRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[CreateExam] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateExam] invalid request: %v", err)
+		return err
+	}
+
+	id, err := h.examsServ.CreateExam(c.Request().Context(), user.ID, sessionID, req)
+	if err != nil {
+		log.Errorf("[CreateExam] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create exam").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, curriculum.CreatedResponse{ID: id})
+}
+
+// GetSessionExams godoc
+// @Summary      Get exams of session
+// @Description  Get exams of an exam session of the admin's university ordered by date and time
+// @Tags         exams
+// @Produce      json
+// @Param        id   path      int  true  "Exam session ID"
+// @Success      200  {array}   exams.ExamResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/exam-sessions/{id}/exams [get]
+// @Security     BearerAuth
+func (h *ExamsHandler) GetSessionExams(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetSessionExams] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.examsServ.GetSessionExams(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetSessionExams] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get exams").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteExam godoc
+// @Summary      Delete exam
+// @Description  Delete an exam of the admin's university with its rooms
+// @Tags         exams
+// @Produce      json
+// @Param        id   path      int                true  "Exam ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/exams/{id} [delete]
+// @Security     BearerAuth
+func (h *ExamsHandler) DeleteExam(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteExam] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.examsServ.DeleteExam(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteExam] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete exam").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetMyExams godoc
+// @Summary      Get my exams
+// @Description  Get the exam timetable of the student: exams of the student's groups in published sessions that are not over, ordered by date and time
+// @Tags         exams
+// @Produce      json
+// @Success      200  {array}   exams.StudentExamResponse
+// @Failure      401  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /exams [get]
+// @Security     BearerAuth
+func (h *ExamsHandler) GetMyExams(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetMyExams] called")
+
+	user, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetMyExams] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	result, err := h.examsServ.GetStudentExams(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Errorf("[GetMyExams] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get exams").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
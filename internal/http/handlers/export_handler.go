@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/exports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// exportWriteTimeout replaces server.write_timeout for exports, a large file streams longer than a usual response.
+const exportWriteTimeout = 10 * time.Minute
+
+// ExportHandler serves files with university data for reporting to admins.
+type ExportHandler struct {
+	exportServ *services.ExportService
+	userServ   *services.UserService
+	logger     logging.Logger
+}
+
+func NewExportHandler(exportServ *services.ExportService, userServ *services.UserService, logger logging.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportServ: exportServ,
+		userServ:   userServ,
+		logger:     logger,
+	}
+}
+
+// Export godoc
+// @Summary      Export file
+// @Description  Export university data as CSV (semicolon separated UTF-8 with BOM), XLSX or JSON array, the file is streamed as rows are read.
+// @Description  Columns by kind:
+// @Description  rosters: max_user_id, username, first_name, last_name, role, group_id, group, department, year_of_study, start_date, end_date — active students of live groups, the file may be imported back;
+// @Description  teachers: max_user_id, username, first_name, last_name, role;
+// @Description  timetable: group, elective, room, day, pair_number, start_time, end_time, interval, subject, subject_type, teacher — lessons of course and elective groups ordered by group, or by room with the room column first;
+// @Description  access_requests: max_user_id, username, first_name, last_name, role, requested_at, status (accepted, pending or rejected now), rejected_at, rejected_by.
+// @Description  Cells starting with =, +, -, @, tab or CR are prefixed with ' in CSV and XLSX, so spreadsheets don't run them as formulas.
+// @Description  A failure in the middle of the stream cuts the file short.
+// @Tags         admin
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        kind           path      string  true   "rosters, teachers, timetable or access_requests"
+// @Param        university_id  query     int     true   "University ID"
+// @Param        format         query     string  false  "csv (default), xlsx or json"
+// @Param        group_id       query     int     false  "Course group ID, for rosters and timetable"
+// @Param        room_id        query     int     false  "Room ID, for timetable"
+// @Param        by             query     string  false  "Timetable order: group (default) or room"
+// @Success      200            {file}    file
+// @Failure      400            {object}  APIError  "Invalid parameters"
+// @Failure      401            {object}  APIError
+// @Failure      403            {object}  APIError
+// @Failure      404            {object}  APIError  "University not found"
+// @Failure      500            {object}  APIError
+// @Router       /admin/exports/{kind} [get]
+// @Security     BearerAuth
+func (h *ExportHandler) Export(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[Export] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req exports.ExportRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[Export] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[Export] invalid request: %v", err)
+		return err
+	}
+
+	// the server deadline is set when the request is read, extend it before the first byte
+	deadline := time.Now().Add(exportWriteTimeout)
+	if err := http.NewResponseController(c.Response().Writer).SetWriteDeadline(deadline); err != nil {
+		log.Errorf("[Export] failed to extend write deadline: %v", err)
+	}
+
+	open := func(contentType, filename string) io.Writer {
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, contentType)
+		res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		res.WriteHeader(http.StatusOK)
+		return res
+	}
+
+	err = h.exportServ.Export(c.Request().Context(), user.ID, req, open)
+	if err != nil {
+		if c.Response().Committed {
+			// headers are sent, the error handler skips the response and the file is cut short
+			log.Errorf("[Export] export interrupted: %v", err)
+			return err
+		}
+		log.Errorf("[Export] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to export").SetInternal(err)
+	}
+	return nil
+}
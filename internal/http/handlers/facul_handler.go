@@ -1,23 +1,22 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type FaculHandler struct {
 	faculService *services.FaculService
 	userService  *services.UserService
-	logger       embedlog.Logger
+	logger       logging.Logger
 }
 
-func NewFaculHandler(faculService *services.FaculService, userService *services.UserService, logger embedlog.Logger) *FaculHandler {
+func NewFaculHandler(faculService *services.FaculService, userService *services.UserService, logger logging.Logger) *FaculHandler {
 	return &FaculHandler{
 		faculService: faculService,
 		logger:       logger,
@@ -33,23 +32,28 @@ func NewFaculHandler(faculService *services.FaculService, userService *services.
 // @Produce      json
 // @Param        request  body      dto.CreateNewFacultyRequest  true  "Faculty data"
 // @Success      200      {object}  map[string]string            "status: faculty created successfully"
-// @Failure      400      {object}  echo.HTTPError               "Invalid request body"
-// @Failure      401      {object}  echo.HTTPError               "Unauthorized user"
-// @Failure      403      {object}  echo.HTTPError               "Forbidden - user is not admin"
-// @Failure      500      {object}  echo.HTTPError               "Internal server error"
+// @Failure      400      {object}  APIError                     "Invalid request body"
+// @Failure      401      {object}  APIError                     "Unauthorized user"
+// @Failure      403      {object}  APIError                     "Forbidden - user is not admin"
+// @Failure      500      {object}  APIError                     "Internal server error"
 // @Router       /admin/faculties [post]
 // @Security     BearerAuth
 func (f *FaculHandler) CreateNewFaculty(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
-	log.Print(context.Background(), "[CreateNewFaculty] CreateNewFaculty called")
+	log.Print(c.Request().Context(), "[CreateNewFaculty] CreateNewFaculty called")
 
 	var req dto.CreateNewFacultyRequest
 	err := c.Bind(&req)
 	if err != nil {
-		log.Errorf("invalid request data. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "invalid request data")
+		log.Errorf("[CreateNewFaculty] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateNewFaculty] invalid request: %v", err)
+		return err
 	}
 
 	currentUser, ok := c.Get("user").(*models.User)
@@ -61,7 +65,7 @@ func (f *FaculHandler) CreateNewFaculty(c echo.Context) error {
 	roles, err := f.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewFaculty] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -79,7 +83,8 @@ func (f *FaculHandler) CreateNewFaculty(c echo.Context) error {
 	err = f.faculService.CreateNewFaculty(ctx, req.Name, currentUser.ID)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "failed create new faculty")
+		log.Errorf("[CreateNewFaculty] failed create new faculty. err: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed create new faculty").SetInternal(err)
 	}
 	return c.JSON(http.StatusOK, "faculty created successfully")
 }
@@ -91,16 +96,16 @@ func (f *FaculHandler) CreateNewFaculty(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Success      200  {array}   dto.FacultyInfoResponse  "List of faculties"
-// @Failure      401  {object}  echo.HTTPError           "Unauthorized user"
-// @Failure      403  {object}  echo.HTTPError           "Forbidden - user is not admin"
-// @Failure      500  {object}  echo.HTTPError           "Internal server error"
+// @Failure      401  {object}  APIError                 "Unauthorized user"
+// @Failure      403  {object}  APIError                 "Forbidden - user is not admin"
+// @Failure      500  {object}  APIError                 "Internal server error"
 // @Router       /admin/faculties [get]
 // @Security     BearerAuth
 func (f *FaculHandler) GetFaculties(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
-	log.Print(context.Background(), "[GetFaculties] GetUniInfo called")
+	log.Print(c.Request().Context(), "[GetFaculties] GetUniInfo called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -111,7 +116,7 @@ func (f *FaculHandler) GetFaculties(c echo.Context) error {
 	roles, err := f.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetFaculties] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -130,7 +135,7 @@ func (f *FaculHandler) GetFaculties(c echo.Context) error {
 
 	if err != nil {
 		log.Errorf("[GetFaculties] failed get faculties. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed get faculties")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed get faculties").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, faculties)
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	db        Pinger
+	botStatus func() string
+	logger    logging.Logger
+}
+
+func NewHealthHandler(db Pinger, botStatus func() string, logger logging.Logger) *HealthHandler {
+	return &HealthHandler{
+		db:        db,
+		botStatus: botStatus,
+		logger:    logger,
+	}
+}
+
+// Healthz godoc
+// @Summary      Liveness probe
+// @Description  Returns 200 while the process serves HTTP, dependencies are not checked
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.HealthResponse  "status: ok"
+// @Router       /healthz [get]
+func (h *HealthHandler) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// Readyz godoc
+// @Summary      Readiness probe
+// @Description  Pings the database and reports bot status. Returns 503 if the database is unavailable, the bot doesn't affect readiness
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  dto.ReadinessResponse  "Ready"
+// @Failure      503  {object}  dto.ReadinessResponse  "Database is unavailable"
+// @Router       /readyz [get]
+func (h *HealthHandler) Readyz(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readinessTimeout)
+	defer cancel()
+
+	resp := dto.ReadinessResponse{Status: "ok", Database: "ok", Bot: h.botStatus()}
+	status := http.StatusOK
+
+	if err := h.db.Ping(ctx); err != nil {
+		h.logger.Errorf("[Readyz] database ping failed: %v", err)
+		resp.Status = "unavailable"
+		resp.Database = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+
+	return c.JSON(status, resp)
+}
@@ -0,0 +1,680 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// HierarchyHandler serves get, update and delete of universities, faculties, departments, courses and groups.
+// Delete is soft and cascades down the hierarchy, it is refused while active students or scheduled
+// lessons reference the entity.
+type HierarchyHandler struct {
+	hierarchyServ *services.HierarchyService
+	userServ      *services.UserService
+	logger        logging.Logger
+}
+
+func NewHierarchyHandler(hierarchyServ *services.HierarchyService, userServ *services.UserService, logger logging.Logger) *HierarchyHandler {
+	return &HierarchyHandler{
+		hierarchyServ: hierarchyServ,
+		userServ:      userServ,
+		logger:        logger,
+	}
+}
+
+// GetUniversity godoc
+// @Summary      Get university
+// @Description  Get university administrated by the current admin
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "University ID"
+// @Success      200  {object}  dto.UniInfoResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/universities/{id} [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetUniversity(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetUniversity] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	uni, err := h.hierarchyServ.GetUniversity(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetUniversity] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, uniResponse(uni))
+}
+
+// UpdateUniversity godoc
+// @Summary      Update university
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                          true  "University ID"
+// @Param        request  body      dto.UpdateUniversityRequest  true  "University data"
+// @Success      200      {object}  dto.UniInfoResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "University with this name already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/universities/{id} [put]
+// @Security     BearerAuth
+func (h *HierarchyHandler) UpdateUniversity(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateUniversity] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateUniversityRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateUniversity] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateUniversity] invalid request: %v", err)
+		return err
+	}
+
+	ctx := c.Request().Context()
+	err = h.hierarchyServ.UpdateUniversity(ctx, user.ID, models.UniversitiesData{
+		ID:          int(id),
+		Name:        req.Name,
+		ShortName:   req.ShortName,
+		SiteUrl:     optional(req.SiteUrl),
+		Description: optional(req.Description),
+		PhotoUrl:    optional(req.PhotoUrl),
+	})
+	if err != nil {
+		log.Errorf("[UpdateUniversity] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update university").SetInternal(err)
+	}
+
+	uni, err := h.hierarchyServ.GetUniversity(ctx, user.ID, id)
+	if err != nil {
+		log.Errorf("[UpdateUniversity] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, uniResponse(uni))
+}
+
+// DeleteUniversity godoc
+// @Summary      Delete university
+// @Description  Soft delete university with its faculties, departments, courses and groups
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int                true  "University ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Still referenced by active students or scheduled lessons"
+// @Failure      500  {object}  APIError
+// @Router       /admin/universities/{id} [delete]
+// @Security     BearerAuth
+func (h *HierarchyHandler) DeleteUniversity(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteUniversity] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.hierarchyServ.DeleteUniversity(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteUniversity] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete university").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetFaculty godoc
+// @Summary      Get faculty
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Faculty ID"
+// @Success      200  {object}  dto.FacultyInfoResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/faculties/{id} [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetFaculty(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetFaculty] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	faculty, err := h.hierarchyServ.GetFaculty(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetFaculty] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get faculty").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.FacultyInfoResponse{
+		ID:             faculty.ID,
+		Name:           faculty.Name,
+		UniversityName: faculty.UniversityName,
+	})
+}
+
+// UpdateFaculty godoc
+// @Summary      Rename faculty
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Faculty ID"
+// @Param        request  body      dto.UpdateFacultyRequest  true  "Faculty data"
+// @Success      200      {object}  map[string]string         "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Faculty with this name already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/faculties/{id} [put]
+// @Security     BearerAuth
+func (h *HierarchyHandler) UpdateFaculty(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateFaculty] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateFacultyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateFaculty] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateFaculty] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.hierarchyServ.UpdateFaculty(c.Request().Context(), user.ID, id, req.Name); err != nil {
+		log.Errorf("[UpdateFaculty] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update faculty").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteFaculty godoc
+// @Summary      Delete faculty
+// @Description  Soft delete faculty with its departments, courses and groups
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int                true  "Faculty ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Still referenced by active students or scheduled lessons"
+// @Failure      500  {object}  APIError
+// @Router       /admin/faculties/{id} [delete]
+// @Security     BearerAuth
+func (h *HierarchyHandler) DeleteFaculty(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteFaculty] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.hierarchyServ.DeleteFaculty(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteFaculty] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete faculty").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetDepartment godoc
+// @Summary      Get university department
+// @Description  Get department opened in a faculty by university_department_id
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "University department ID"
+// @Success      200  {object}  dto.UniversityDepartmentResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/department/{id} [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetDepartment(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetDepartment] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	dep, err := h.hierarchyServ.GetDepartment(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetDepartment] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get department").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.UniversityDepartmentResponse{
+		ID:           dep.ID,
+		DepartmentID: dep.DepartmentID,
+		Name:         dep.Name,
+		Code:         dep.Code,
+		AliasName:    NewString(dep.AliasName),
+		FacultyID:    dep.FacultyID,
+		UniversityID: dep.UniversityID,
+		StudyYears:   dep.StudyYears,
+	})
+}
+
+// UpdateDepartment godoc
+// @Summary      Update university department
+// @Description  Change alias name and study years of department and move it to another faculty of the same university
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                          true  "University department ID"
+// @Param        request  body      dto.UpdateDepartmentRequest  true  "Department data"
+// @Success      200      {object}  map[string]string            "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Faculty not found"
+// @Failure      500      {object}  APIError
+// @Router       /admin/department/{id} [put]
+// @Security     BearerAuth
+func (h *HierarchyHandler) UpdateDepartment(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateDepartment] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateDepartmentRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateDepartment] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateDepartment] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.hierarchyServ.UpdateDepartment(c.Request().Context(), user.ID, id, optional(req.AliasName), req.FacultyID, req.StudyYears); err != nil {
+		log.Errorf("[UpdateDepartment] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update department").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteDepartment godoc
+// @Summary      Delete university department
+// @Description  Soft delete department with its courses and groups
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int                true  "University department ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Still referenced by active students or scheduled lessons"
+// @Failure      500  {object}  APIError
+// @Router       /admin/department/{id} [delete]
+// @Security     BearerAuth
+func (h *HierarchyHandler) DeleteDepartment(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteDepartment] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.hierarchyServ.DeleteDepartment(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteDepartment] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete department").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetCourse godoc
+// @Summary      Get course
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Course ID"
+// @Success      200  {object}  dto.CourseInfoResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/courses/{id} [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetCourse(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetCourse] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	course, err := h.hierarchyServ.GetCourse(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetCourse] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get course").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, dto.CourseInfoResponse{
+		ID:                   course.ID,
+		StartDate:            course.StartDate.Format("2006-01-02"),
+		EndDate:              course.EndDate.Format("2006-01-02"),
+		UniversityDepartment: course.UniversityDepartment,
+		YearOfStudy:          course.YearOfStudy,
+	})
+}
+
+// UpdateCourse godoc
+// @Summary      Update course
+// @Description  Change course dates and year of study and move it to another department of the same university
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "Course ID"
+// @Param        request  body      dto.UpdateCourseRequest  true  "Course data"
+// @Success      200      {object}  map[string]string        "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Department not found or course already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/courses/{id} [put]
+// @Security     BearerAuth
+func (h *HierarchyHandler) UpdateCourse(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateCourse] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateCourseRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateCourse] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateCourse] invalid request: %v", err)
+		return err
+	}
+
+	// формат дат уже проверен валидатором
+	startDate, _ := time.Parse("2006-01-02", req.StartDate)
+	endDate, _ := time.Parse("2006-01-02", req.EndDate)
+
+	err = h.hierarchyServ.UpdateCourse(c.Request().Context(), user.ID, models.Course{
+		ID:                   id,
+		StartDate:            startDate,
+		EndDate:              endDate,
+		UniversityDepartment: req.UniversityDepartment,
+		YearOfStudy:          req.YearOfStudy,
+	})
+	if err != nil {
+		log.Errorf("[UpdateCourse] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update course").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteCourse godoc
+// @Summary      Delete course
+// @Description  Soft delete course with its groups
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int                true  "Course ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Still referenced by active students or scheduled lessons"
+// @Failure      500  {object}  APIError
+// @Router       /admin/courses/{id} [delete]
+// @Security     BearerAuth
+func (h *HierarchyHandler) DeleteCourse(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteCourse] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.hierarchyServ.DeleteCourse(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteCourse] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete course").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetGroups godoc
+// @Summary      Get groups
+// @Description  Get groups of the admin's university, of one course if course_id is set
+// @Tags         admin
+// @Produce      json
+// @Param        course_id  query     int  false  "Course ID"
+// @Success      200        {array}   dto.GroupInfoResponse
+// @Failure      400        {object}  APIError
+// @Failure      401        {object}  APIError
+// @Failure      403        {object}  APIError
+// @Failure      500        {object}  APIError
+// @Router       /admin/groups [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetGroups(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetGroups] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	courseID, err := queryID(c, "course_id")
+	if err != nil {
+		return err
+	}
+
+	groups, err := h.hierarchyServ.GetGroups(c.Request().Context(), user.ID, courseID)
+	if err != nil {
+		log.Errorf("[GetGroups] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get groups").SetInternal(err)
+	}
+
+	response := make([]dto.GroupInfoResponse, 0, len(groups))
+	for _, group := range groups {
+		response = append(response, groupResponse(&group))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetGroup godoc
+// @Summary      Get group
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Group ID"
+// @Success      200  {object}  dto.GroupInfoResponse
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      500  {object}  APIError
+// @Router       /admin/groups/{id} [get]
+// @Security     BearerAuth
+func (h *HierarchyHandler) GetGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetGroup] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	group, err := h.hierarchyServ.GetGroup(c.Request().Context(), user.ID, id)
+	if err != nil {
+		log.Errorf("[GetGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, groupResponse(group))
+}
+
+// UpdateGroup godoc
+// @Summary      Update group
+// @Description  Rename group and move it to another course of the same university
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                     true  "Group ID"
+// @Param        request  body      dto.UpdateGroupRequest  true  "Group data"
+// @Success      200      {object}  map[string]string       "status: ok"
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Course not found or group already exists"
+// @Failure      500      {object}  APIError
+// @Router       /admin/groups/{id} [put]
+// @Security     BearerAuth
+func (h *HierarchyHandler) UpdateGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[UpdateGroup] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[UpdateGroup] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[UpdateGroup] invalid request: %v", err)
+		return err
+	}
+
+	if err := h.hierarchyServ.UpdateGroup(c.Request().Context(), user.ID, id, req.GroupName, req.CourseID); err != nil {
+		log.Errorf("[UpdateGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DeleteGroup godoc
+// @Summary      Delete group
+// @Description  Soft delete group
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int                true  "Group ID"
+// @Success      200  {object}  map[string]string  "status: ok"
+// @Failure      400  {object}  APIError
+// @Failure      401  {object}  APIError
+// @Failure      403  {object}  APIError
+// @Failure      404  {object}  APIError
+// @Failure      409  {object}  APIError  "Still referenced by active students or scheduled lessons"
+// @Failure      500  {object}  APIError
+// @Router       /admin/groups/{id} [delete]
+// @Security     BearerAuth
+func (h *HierarchyHandler) DeleteGroup(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteGroup] called")
+
+	user, id, err := adminAndID(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	if err := h.hierarchyServ.DeleteGroup(c.Request().Context(), user.ID, id); err != nil {
+		log.Errorf("[DeleteGroup] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete group").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func uniResponse(uni *models.UniversitiesData) dto.UniInfoResponse {
+	return dto.UniInfoResponse{
+		ID:          uni.ID,
+		Name:        uni.Name,
+		City:        uni.City,
+		ShortName:   uni.ShortName,
+		SiteUrl:     NewString(uni.SiteUrl),
+		Description: NewString(uni.Description),
+		PhotoUrl:    NewString(uni.PhotoUrl),
+	}
+}
+
+func groupResponse(group *models.Groups) dto.GroupInfoResponse {
+	return dto.GroupInfoResponse{
+		ID:             group.ID,
+		Name:           group.Name,
+		CourseID:       group.CourseID,
+		Code:           group.Code,
+		DepartmentName: group.DepartmentName,
+	}
+}
+
+// optional returns nil for empty string, for nullable columns.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services/auth"
+)
+
+type ImpersonationHandler struct {
+	jwtService        *auth.JWTService
+	impersonationRepo repositories.ImpersonationRepository
+	userService       *services.UserService
+	logger            logging.Logger
+}
+
+func NewImpersonationHandler(jwt *auth.JWTService, repo repositories.ImpersonationRepository, userService *services.UserService, logger logging.Logger) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		jwtService:        jwt,
+		impersonationRepo: repo,
+		userService:       userService,
+		logger:            logger,
+	}
+}
+
+// Impersonate godoc
+// @Summary      View as user
+// @Description  Issue a short-lived access token of a user of the admin's university. The token carries act claim with the admin id, every request made with it is written to the impersonation audit, mutating requests are refused. No refresh token is issued.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ImpersonateRequest   true  "User to act as"
+// @Success      200      {object}  dto.ImpersonateResponse  "Impersonation token"
+// @Failure      400      {object}  APIError                 "Invalid request body"
+// @Failure      401      {object}  APIError                 "Unauthorized user"
+// @Failure      403      {object}  APIError                 "Forbidden - user is an admin or belongs to a university the caller does not administer"
+// @Failure      404      {object}  APIError                 "User not found"
+// @Failure      500      {object}  APIError                 "Internal server error"
+// @Router       /admin/impersonate [post]
+// @Security     BearerAuth
+func (h *ImpersonationHandler) Impersonate(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	ctx := c.Request().Context()
+
+	log.Print(c.Request().Context(), "[Impersonate] Impersonate called")
+
+	currentUser, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[Impersonate] Authentication error. user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.Bind(&req); err != nil || req.UserID == 0 {
+		log.Errorf("[Impersonate] Invalid request format: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[Impersonate] invalid request: %v", err)
+		return err
+	}
+
+	if req.UserID == currentUser.ID {
+		return echo.NewHTTPError(http.StatusBadRequest, "can't impersonate yourself")
+	}
+
+	allowed, err := h.impersonationRepo.CanImpersonate(ctx, currentUser.ID, req.UserID)
+	if err != nil {
+		log.Errorf("[Impersonate] failed to check permission: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to check permission").SetInternal(err)
+	}
+	if !allowed {
+		log.Errorf("[Impersonate] permission denied for user id %d to act as %d", currentUser.ID, req.UserID)
+		return echo.NewHTTPError(http.StatusForbidden, "permission denied. need role admin in every university of the user, admins can't be impersonated")
+	}
+
+	user, err := h.userService.GetUser(ctx, req.UserID)
+	if err != nil {
+		log.Errorf("[Impersonate] failed to get user %d: %v", req.UserID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user").SetInternal(err)
+	}
+
+	token, err := h.jwtService.GenerateImpersonationToken(ctx, user, currentUser.ID)
+	if err != nil {
+		log.Errorf("[Impersonate] token generation error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to generate token").SetInternal(err)
+	}
+
+	log.Printf("[Impersonate] admin id %d acts as user id %d", currentUser.ID, user.ID)
+
+	return c.JSON(http.StatusOK, dto.ImpersonateResponse{
+		AccessToken: token,
+		ExpiresAt:   time.Now().Add(h.jwtService.ImpersonationExpiry).Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/imports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// maxImportFileSize limits uploaded files, 10000 rows of a roster fit into it
+const maxImportFileSize = 10 << 20
+
+// ImportHandler serves bulk import of university data from CSV and XLSX files to admins.
+type ImportHandler struct {
+	importServ *services.ImportService
+	userServ   *services.UserService
+	logger     logging.Logger
+}
+
+func NewImportHandler(importServ *services.ImportService, userServ *services.UserService, logger logging.Logger) *ImportHandler {
+	return &ImportHandler{
+		importServ: importServ,
+		userServ:   userServ,
+		logger:     logger,
+	}
+}
+
+// Import godoc
+// @Summary      Import file
+// @Description  Import CSV (comma or semicolon separated) or XLSX file with a header row into the university, column names are case insensitive.
+// @Description  Columns by kind:
+// @Description  rooms: room;
+// @Description  classes: pair_number, start_time, end_time (HH:MM);
+// @Description  subjects: name;
+// @Description  groups: department (code or alias name), start_date, end_date (YYYY-MM-DD), year_of_study (optional), name;
+// @Description  rosters: max_user_id or username, role (student or teacher, student by default), group or group_id for students, first_name and last_name to create unknown users.
+// @Description  Every row is validated, invalid rows are reported and valid rows are applied in one transaction.
+// @Description  In create mode rows with existing natural keys are errors, in upsert mode they are updated or left unchanged, so a file may be imported again.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        kind           path      string  true   "rooms, classes, subjects, groups or rosters"
+// @Param        university_id  formData  int     true   "University ID"
+// @Param        mode           formData  string  false  "create (default) or upsert"
+// @Param        file           formData  file    true   "CSV or XLSX file, up to 10 MB and 10000 rows"
+// @Success      200            {object}  imports.ImportReport
+// @Failure      400            {object}  APIError  "Invalid file or missing columns"
+// @Failure      401            {object}  APIError
+// @Failure      403            {object}  APIError
+// @Failure      404            {object}  APIError  "University not found"
+// @Failure      413            {object}  APIError  "Request body is larger than 10 MB"
+// @Failure      500            {object}  APIError
+// @Router       /admin/imports/{kind} [post]
+// @Security     BearerAuth
+func (h *ImportHandler) Import(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[Import] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req imports.ImportRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[Import] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[Import] invalid request: %v", err)
+		return err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Errorf("[Import] file not found in form: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+	}
+	if header.Size > maxImportFileSize {
+		return echo.NewHTTPError(http.StatusBadRequest, "file is larger than 10 MB")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Errorf("[Import] failed to open file: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "failed to read file")
+	}
+	defer file.Close()
+
+	report, err := h.importServ.Import(c.Request().Context(), user.ID, req, header.Filename, file)
+	if err != nil {
+		log.Errorf("[Import] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to import file").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,20 +9,20 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	personalities2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/http/personalities"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/personalities"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type PersonalitiesHandler struct {
 	personServ *services.PersonalitiesService
 	userServ   *services.UserService
-	logger     embedlog.Logger
+	logger     logging.Logger
 }
 
-func NewPersonalitiesHandler(personServ *services.PersonalitiesService, userServ *services.UserService, logger embedlog.Logger) *PersonalitiesHandler {
+func NewPersonalitiesHandler(personServ *services.PersonalitiesService, userServ *services.UserService, logger logging.Logger) *PersonalitiesHandler {
 	return &PersonalitiesHandler{
 		personServ: personServ,
 		userServ:   userServ,
@@ -38,16 +37,16 @@ func NewPersonalitiesHandler(personServ *services.PersonalitiesService, userServ
 // @Accept       json
 // @Produce      json
 // @Param        request  body   personalities2.RequestAccessToUniversity  true  "Access request"
-// @Success      200   {object}  string  "ok"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Success      200   {object}  map[string]string  "status: ok"
+// @Failure      400   {object}  APIError        "Invalid request body"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /admin/personalities/access [post]
 func (h *PersonalitiesHandler) RequestAccess(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[RequestAccess] RequestAccess called")
+	log.Print(c.Request().Context(), "[RequestAccess] RequestAccess called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -65,23 +64,28 @@ func (h *PersonalitiesHandler) RequestAccess(c echo.Context) error {
 	// })
 	// if !hasAdmin {
 	// 	log.Errorf("[RequestAccess] GetUserRolesByID role admin not found")
-	// 	return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+	// 	return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	// }
 
 	var request personalities2.RequestAccessToUniversity
 
 	if err := json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
 		log.Errorf("[RequestAccess] failed to decode request body: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := c.Validate(&request); err != nil {
+		log.Errorf("[RequestAccess] invalid request: %v", err)
+		return err
 	}
 
 	err := h.personServ.SendAccessToAddInUniversity(ctx, int64(currentUser.ID), request)
 	if err != nil {
 		log.Errorf("[RequestAccess] failed to send access request: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to request access").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // RejectRequestAccess godoc
@@ -91,17 +95,17 @@ func (h *PersonalitiesHandler) RequestAccess(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        request_id  query     int             true  "Request ID"
-// @Success      200         {object}  string          "ok"
-// @Failure      400         {object}  echo.HTTPError  "Invalid request body"
-// @Failure      401         {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500         {object}  echo.HTTPError  "Internal server error"
+// @Success      200         {object}  map[string]string          "status: ok"
+// @Failure      400         {object}  APIError        "Invalid request body"
+// @Failure      401         {object}  APIError        "Unauthorized user"
+// @Failure      500         {object}  APIError        "Internal server error"
 // @Router       /admin/personalities/access [delete]
 // @Security     BearerAuth
 func (h *PersonalitiesHandler) RejectRequestAccess(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[RejectRequestAccess] RejectRequestAccess called")
+	log.Print(c.Request().Context(), "[RejectRequestAccess] RejectRequestAccess called")
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
 		log.Errorf("[RejectRequestAccess] Authentication error. user not found in context")
@@ -110,14 +114,14 @@ func (h *PersonalitiesHandler) RejectRequestAccess(c echo.Context) error {
 	roles, err := h.userServ.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[RequestAccess] GetUserRolesByID error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "user is not authenticated")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 	hasAdmin := slices.ContainsFunc(roles.Roles, func(s string) bool {
 		return s == "admin"
 	})
 	if !hasAdmin {
 		log.Errorf("[RequestAccess] GetUserRolesByID role admin not found")
-		return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+		return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	}
 
 	requestID := c.QueryParam("request_id")
@@ -135,10 +139,10 @@ func (h *PersonalitiesHandler) RejectRequestAccess(c echo.Context) error {
 	err = h.personServ.RejectRequest(ctx, requestIDInt)
 	if err != nil {
 		log.Errorf("[RejectRequestAccess] failed to reject request: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to reject request")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to reject request").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // GetRequests godoc
@@ -150,16 +154,16 @@ func (h *PersonalitiesHandler) RejectRequestAccess(c echo.Context) error {
 // @Param        limit   query     int                                   true  "Limit of requests, max(50), default(5)"
 // @Param        offset  query     int                                   true  "Offset, default(0)"
 // @Success      200     {object}  personalities2.AccessRequestResponse  "Requests for administration"
-// @Failure      400     {object}  echo.HTTPError                        "Invalid request body"
-// @Failure      401     {object}  echo.HTTPError                        "Unauthorized user"
-// @Failure      500     {object}  echo.HTTPError                        "Internal server error"
+// @Failure      400     {object}  APIError                              "Invalid request body"
+// @Failure      401     {object}  APIError                              "Unauthorized user"
+// @Failure      500     {object}  APIError                              "Internal server error"
 // @Router       /admin/personalities/access [get]
 // @Security     BearerAuth
 func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetRequests] GetRequests called")
+	log.Print(c.Request().Context(), "[GetRequests] GetRequests called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -170,14 +174,14 @@ func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 	roles, err := h.userServ.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[RequestAccess] GetUserRolesByID error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "user is not authenticated")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 	hasAdmin := slices.ContainsFunc(roles.Roles, func(s string) bool {
 		return s == "admin"
 	})
 	if !hasAdmin {
 		log.Errorf("[RequestAccess] GetUserRolesByID role admin not found")
-		return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+		return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	}
 
 	params := c.QueryParams()
@@ -189,7 +193,7 @@ func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 		limitInt, err = strconv.ParseInt(limit, 10, 64)
 		if err != nil {
 			log.Errorf("[GetRequests] failed to parse limit: %v", err)
-			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid limit")
 		}
 	} else {
 		limitInt = 5
@@ -199,7 +203,7 @@ func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 		offsetInt, err = strconv.ParseInt(offset, 10, 64)
 		if err != nil {
 			log.Errorf("[GetRequests] failed to parse offset: %v", err)
-			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid offset")
 		}
 	} else {
 		offsetInt = 0
@@ -208,7 +212,7 @@ func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 	response, err := h.personServ.GetAccessRequest(ctx, currentUser.ID, limitInt, offsetInt)
 	if err != nil {
 		log.Errorf("[GetRequests] failed to get access request: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get requests").SetInternal(err)
 	}
 	if response == nil {
 		response = &personalities2.AccessRequestResponse{
@@ -226,15 +230,15 @@ func (h *PersonalitiesHandler) GetRequests(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        request  body   personalities2.AcceptAccessRequest  true  "Access request"
-// @Success      200   {object}  string  "ok"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Success      200   {object}  map[string]string  "status: ok"
+// @Failure      400   {object}  APIError        "Invalid request body"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /admin/personalities/access/accept [post]
 func (h *PersonalitiesHandler) AcceptAccess(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[AcceptRequest] AcceptRequest called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[AcceptRequest] AcceptRequest called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -245,20 +249,25 @@ func (h *PersonalitiesHandler) AcceptAccess(c echo.Context) error {
 	roles, err := h.userServ.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[RequestAccess] GetUserRolesByID error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "user is not authenticated")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 	hasAdmin := slices.ContainsFunc(roles.Roles, func(s string) bool {
 		return s == "admin"
 	})
 	if !hasAdmin {
 		log.Errorf("[RequestAccess] GetUserRolesByID role admin not found")
-		return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+		return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	}
 
 	var request personalities2.AcceptAccessRequest
 	if err = json.NewDecoder(c.Request().Body).Decode(&request); err != nil {
 		log.Errorf("[AcceptRequest] failed to decode request body: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := c.Validate(&request); err != nil {
+		log.Errorf("[AcceptRequest] invalid request: %v", err)
+		return err
 	}
 
 	switch request.UserType {
@@ -274,16 +283,16 @@ func (h *PersonalitiesHandler) AcceptAccess(c echo.Context) error {
 
 	if err != nil {
 		log.Errorf("[AcceptRequest] failed to validate request body: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
 	err = h.personServ.AcceptAccess(ctx, request)
 	if err != nil {
 		log.Errorf("[AcceptRequest] failed to send access request: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to accept request").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // GetAllUniversitiesForPerson godoc
@@ -293,13 +302,13 @@ func (h *PersonalitiesHandler) AcceptAccess(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Success      200   {object}  []dto.UniInfoResponse  "Universities"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/universities [get]
 func (h *PersonalitiesHandler) GetAllUniversitiesForPerson(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllUniversitiesFromPerson] GetAllUniversitiesFromPerson called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllUniversitiesFromPerson] GetAllUniversitiesFromPerson called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -310,7 +319,7 @@ func (h *PersonalitiesHandler) GetAllUniversitiesForPerson(c echo.Context) error
 	universities, err := h.personServ.GetAllUniversitiesForPerson(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetAllUniversitiesFromPerson] failed to get all universities for person: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get universities").SetInternal(err)
 	}
 	var response []dto.UniInfoResponse
 	for _, uni := range universities {
@@ -334,26 +343,26 @@ func (h *PersonalitiesHandler) GetAllUniversitiesForPerson(c echo.Context) error
 // @Produce      json
 // @Param        university_id  query   int  true  "University ID"
 // @Success      200   {object}  []dto.FacultyInfoResponse  "Faculties"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request parameter"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request parameter"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/faculty [get]
 func (h *PersonalitiesHandler) GetAllFacultiesForUniversity(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllFacultiesForUniversity] GetAllFacultiesForUniversity called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllFacultiesForUniversity] GetAllFacultiesForUniversity called")
 
 	universityID := c.QueryParam("university_id")
 	universityIDInt, err := strconv.ParseInt(universityID, 10, 64)
 	if err != nil {
 		log.Errorf("[GetAllFacultiesForUniversity] failed to parse university id: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid university_id")
 	}
 
 	faculties, err := h.personServ.GetAllFacultiesForUniversity(ctx, universityIDInt)
 	if err != nil {
 		log.Errorf("[GetAllFacultiesForUniversity] failed to get all faculties for university: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get faculties").SetInternal(err)
 	}
 
 	var response []dto.FacultyInfoResponse
@@ -376,26 +385,26 @@ func (h *PersonalitiesHandler) GetAllFacultiesForUniversity(c echo.Context) erro
 // @Produce      json
 // @Param        faculty_id  query   int  true  "Faculty ID"
 // @Success      200   {object}  []dto.DepartmentInfoResponse  "Departments"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request parameter"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request parameter"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/departments [get]
 func (h *PersonalitiesHandler) GetAllDepartmentsForFaculty(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllDepartmentsForFaculty] GetAllDepartmentsForFaculty called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllDepartmentsForFaculty] GetAllDepartmentsForFaculty called")
 
 	facultyID := c.QueryParam("faculty_id")
 	facultyIDInt, err := strconv.ParseInt(facultyID, 10, 64)
 	if err != nil {
 		log.Errorf("[GetAllDepartmentsForFaculty] failed to parse faculty id: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid faculty_id")
 	}
 
 	departments, err := h.personServ.GetAllDepartmentsForFaculty(ctx, facultyIDInt)
 	if err != nil {
 		log.Errorf("[GetAllDepartmentsForFaculty] failed to get all departments for faculty: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get departments").SetInternal(err)
 	}
 
 	var response []dto.DepartmentInfoResponse
@@ -418,26 +427,26 @@ func (h *PersonalitiesHandler) GetAllDepartmentsForFaculty(c echo.Context) error
 // @Produce      json
 // @Param        department_id  query   int  true  "Department ID"
 // @Success      200   {object}  []dto.GroupInfoResponse  "Groups"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request parameter"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request parameter"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/groups [get]
 func (h *PersonalitiesHandler) GetAllGroupsForDepartment(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllGroupsForDepartment] GetAllGroupsForDepartment called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllGroupsForDepartment] GetAllGroupsForDepartment called")
 
 	departmentID := c.QueryParam("department_id")
 	departmentIDInt, err := strconv.ParseInt(departmentID, 10, 64)
 	if err != nil {
 		log.Errorf("[GetAllGroupsForDepartment] failed to parse department id: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid department_id")
 	}
 
 	groups, err := h.personServ.GetAllGroupsForDepartment(ctx, departmentIDInt)
 	if err != nil {
 		log.Errorf("[GetAllGroupsForDepartment] failed to get all groups for department: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get groups").SetInternal(err)
 	}
 
 	var response []dto.GroupInfoResponse
@@ -461,14 +470,14 @@ func (h *PersonalitiesHandler) GetAllGroupsForDepartment(c echo.Context) error {
 // @Produce      json
 // @Param        group_id  query   int  true  "Course Group ID"
 // @Success      200   {object}  []dto.User  "Students"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request parameter"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request parameter"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/student [get]
 func (h *PersonalitiesHandler) GetAllStudentForGtoup(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllStudentForGtoup] GetAllStudentForGtoup called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllStudentForGtoup] GetAllStudentForGtoup called")
 
 	_, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -480,13 +489,13 @@ func (h *PersonalitiesHandler) GetAllStudentForGtoup(c echo.Context) error {
 	groupIDInt, err := strconv.ParseInt(groupID, 10, 64)
 	if err != nil {
 		log.Errorf("[GetAllStudentForGtoup] failed to parse group id: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid group_id")
 	}
 
 	students, err := h.personServ.GetAllStudentsForGroup(ctx, groupIDInt)
 	if err != nil {
 		log.Errorf("[GetAllStudentForGtoup] failed to get all students for group: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get students for group").SetInternal(err)
 	}
 
 	var response []dto.User
@@ -520,14 +529,14 @@ func (h *PersonalitiesHandler) GetAllStudentForGtoup(c echo.Context) error {
 // @Produce      json
 // @Param        university_id  query   int  true  "University ID"
 // @Success      200   {object}  []dto.User  "Teachers"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request parameter"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request parameter"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /personalities/teachers [get]
 func (h *PersonalitiesHandler) GetAllTeachersForUniversity(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetAllTeachersForUniversity] GetAllTeachersForUniversity called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetAllTeachersForUniversity] GetAllTeachersForUniversity called")
 
 	_, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -539,13 +548,13 @@ func (h *PersonalitiesHandler) GetAllTeachersForUniversity(c echo.Context) error
 	universityIDInt, err := strconv.ParseInt(universityID, 10, 64)
 	if err != nil {
 		log.Errorf("[GetAllTeachersForUniversity] failed to parse university id: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid university_id")
 	}
 
 	teachers, err := h.personServ.GetAllTeachersForUniversity(ctx, universityIDInt)
 	if err != nil {
 		log.Errorf("[GetAllTeachersForUniversity] failed to get all teachers for university: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get teachers").SetInternal(err)
 	}
 
 	var response []dto.User
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/rollover"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// RolloverHandler serves the end of academic year process to admins, a preview is expected before the rollover itself.
+type RolloverHandler struct {
+	rolloverServ *services.RolloverService
+	userServ     *services.UserService
+	logger       logging.Logger
+}
+
+func NewRolloverHandler(rolloverServ *services.RolloverService, userServ *services.UserService, logger logging.Logger) *RolloverHandler {
+	return &RolloverHandler{
+		rolloverServ: rolloverServ,
+		userServ:     userServ,
+		logger:       logger,
+	}
+}
+
+// PreviewRollover godoc
+// @Summary      Preview academic year rollover
+// @Description  Report what the rollover of the university to the new academic year would do, nothing is changed.
+// @Description  Courses finished before start_date are rolled over: groups are promoted to the next year course, final year students are graduated.
+// @Description  Lessons of semesters finished before start_date are archived. Finished courses without year_of_study are skipped.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      rollover.RolloverRequest  true  "New academic year"
+// @Success      200      {object}  rollover.ReportResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Promoted group name is taken in the next year course"
+// @Failure      500      {object}  APIError
+// @Router       /admin/rollovers/preview [post]
+// @Security     BearerAuth
+func (h *RolloverHandler) PreviewRollover(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[PreviewRollover] called")
+
+	user, req, err := h.adminAndRequest(c)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.rolloverServ.Preview(c.Request().Context(), user.ID, *req)
+	if err != nil {
+		log.Errorf("[PreviewRollover] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to preview rollover").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// Rollover godoc
+// @Summary      Roll over academic year
+// @Description  Move the university to the new academic year in one transaction, nothing is changed on failure.
+// @Description  Does exactly what the preview reports, courses are rolled over once.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      rollover.RolloverRequest  true  "New academic year"
+// @Success      200      {object}  rollover.ReportResponse
+// @Failure      400      {object}  APIError
+// @Failure      401      {object}  APIError
+// @Failure      403      {object}  APIError
+// @Failure      404      {object}  APIError
+// @Failure      409      {object}  APIError  "Promoted group name is taken in the next year course"
+// @Failure      500      {object}  APIError
+// @Router       /admin/rollovers [post]
+// @Security     BearerAuth
+func (h *RolloverHandler) Rollover(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[Rollover] called")
+
+	user, req, err := h.adminAndRequest(c)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.rolloverServ.Rollover(c.Request().Context(), user.ID, *req)
+	if err != nil {
+		log.Errorf("[Rollover] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to roll over academic year").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// adminAndRequest checks admin role and binds the rollover request.
+func (h *RolloverHandler) adminAndRequest(c echo.Context) (*models.User, *rollover.RolloverRequest, error) {
+	log := logging.FromContext(c.Request().Context())
+
+	currentUser, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var req rollover.RolloverRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[adminAndRequest] invalid request data. err: %v", err)
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[adminAndRequest] invalid request: %v", err)
+		return nil, nil, err
+	}
+
+	return currentUser, &req, nil
+}
@@ -1,30 +1,30 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/schedules"
-	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type SchedulesHandler struct {
 	schedulesServ *services.SchedulesService
 	userServ      *services.UserService
-	logger        embedlog.Logger
+	logger        logging.Logger
 }
 
 func NewSchedulesHandler(
 	schedulesServ *services.SchedulesService,
 	userServ *services.UserService,
-	logger embedlog.Logger,
+	logger logging.Logger,
 ) *SchedulesHandler {
 	return &SchedulesHandler{
 		schedulesServ: schedulesServ,
@@ -40,15 +40,15 @@ func NewSchedulesHandler(
 // @Produce json
 // @Param request body schedules.CreateClassRequest true "Class info"
 // @Success 200 {object} string "class_id"
-// @Failure 400 {object} echo.HTTPError
-// @Failure 401 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/classes [post]
 func (h *SchedulesHandler) CreateClass(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[CreateClass] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateClass] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -56,10 +56,15 @@ func (h *SchedulesHandler) CreateClass(c echo.Context) error {
 	var req schedules.CreateClassRequest
 	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
 		log.Errorf("[CreateClass] decode error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateClass] invalid request: %v", err)
+		return err
 	}
 
-	id, err := h.schedulesServ.CreateClass(context.TODO(), schedules.CreateClassRequest{
+	id, err := h.schedulesServ.CreateClass(c.Request().Context(), schedules.CreateClassRequest{
 		UniversityID: req.UniversityID,
 		PairNumber:   req.PairNumber,
 		StartTime:    req.StartTime,
@@ -67,7 +72,7 @@ func (h *SchedulesHandler) CreateClass(c echo.Context) error {
 	})
 	if err != nil {
 		log.Errorf("[CreateClass] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create class").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, id)
@@ -77,16 +82,16 @@ func (h *SchedulesHandler) CreateClass(c echo.Context) error {
 // @Summary delete class
 // @Tags schedules
 // @Param class_id path int true "Class ID"
-// @Success 200 {object} string "ok"
-// @Failure 400 {object} echo.HTTPError
-// @Failure 401 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Success 200 {object} map[string]string "status: ok"
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/classes/{class_id} [delete]
 func (h *SchedulesHandler) DeleteClass(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[DeleteClass] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteClass] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -98,12 +103,12 @@ func (h *SchedulesHandler) DeleteClass(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid class_id")
 	}
 
-	if err := h.schedulesServ.DeleteClass(context.TODO(), classID); err != nil {
+	if err := h.schedulesServ.DeleteClass(c.Request().Context(), classID); err != nil {
 		log.Errorf("[DeleteClass] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete class").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // GetClassesByUniversity godoc
@@ -112,12 +117,12 @@ func (h *SchedulesHandler) DeleteClass(c echo.Context) error {
 // @Produce json
 // @Param university_id query int true "University ID"
 // @Success 200 {array} schedules.ClassesResponse
-// @Failure 400 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/classes [get]
 func (h *SchedulesHandler) GetClassesByUniversity(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetClassesByUniversity] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetClassesByUniversity] called")
 
 	universityIDStr := c.QueryParam("university_id")
 	universityIS, err := strconv.ParseInt(universityIDStr, 10, 64)
@@ -126,46 +131,15 @@ func (h *SchedulesHandler) GetClassesByUniversity(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid university_id")
 	}
 
-	classes, err := h.schedulesServ.GetClassesByUniversity(context.TODO(), universityIS)
+	classes, err := h.schedulesServ.GetClassesByUniversity(c.Request().Context(), universityIS)
 	if err != nil {
 		log.Errorf("[GetClassesByUniversity] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get classes by university").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, classes)
 }
 
-func (h *SchedulesHandler) requireAdmin(c echo.Context) (*models.User, error) {
-	log := c.Get("logger").(embedlog.Logger)
-
-	currentUser, ok := c.Get("user").(*models.User)
-	if !ok {
-		log.Errorf("[requireAdmin] user not found in context")
-		return nil, echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
-	}
-
-	roles, err := h.userServ.GetUserRolesByID(context.TODO(), currentUser.ID)
-	if err != nil {
-		log.Errorf("[requireAdmin] GetUserRolesByID error: %v", err)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "failed to get roles")
-	}
-
-	hasAdmin := false
-	for _, r := range roles.Roles {
-		if r == "admin" {
-			hasAdmin = true
-			break
-		}
-	}
-
-	if !hasAdmin {
-		log.Errorf("[requireAdmin] user is not admin")
-		return nil, echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
-	}
-
-	return currentUser, nil
-}
-
 // CreateRoom godoc
 // @Summary create room
 // @Tags schedules
@@ -173,15 +147,15 @@ func (h *SchedulesHandler) requireAdmin(c echo.Context) (*models.User, error) {
 // @Produce json
 // @Param request body schedules.CreateRoomRequest true "Room info"
 // @Success 200 {object} string "id"
-// @Failure 400 {object} echo.HTTPError
-// @Failure 401 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/rooms [post]
 func (h *SchedulesHandler) CreateRoom(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[CreateRoom] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateRoom] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -189,13 +163,18 @@ func (h *SchedulesHandler) CreateRoom(c echo.Context) error {
 	var req schedules.CreateRoomRequest
 	if err := json.NewDecoder(c.Request().Body).Decode(&req); err != nil {
 		log.Errorf("[CreateRoom] decode error: %v", err)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateRoom] invalid request: %v", err)
+		return err
 	}
 
-	id, err := h.schedulesServ.CreateRoom(context.TODO(), req)
+	id, err := h.schedulesServ.CreateRoom(c.Request().Context(), req)
 	if err != nil {
 		log.Errorf("[CreateRoom] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create room").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, id)
@@ -205,16 +184,16 @@ func (h *SchedulesHandler) CreateRoom(c echo.Context) error {
 // @Summary delete room
 // @Tags schedules
 // @Param room_id path int true "Room ID"
-// @Success 200 {object} string "ok"
-// @Failure 400 {object} echo.HTTPError
-// @Failure 401 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Success 200 {object} map[string]string "status: ok"
+// @Failure 400 {object} APIError
+// @Failure 401 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/rooms/{room_id} [delete]
 func (h *SchedulesHandler) DeleteRoom(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[DeleteRoom] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteRoom] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -226,12 +205,12 @@ func (h *SchedulesHandler) DeleteRoom(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid room_id")
 	}
 
-	if err := h.schedulesServ.DeleteRoom(context.TODO(), roomID); err != nil {
+	if err := h.schedulesServ.DeleteRoom(c.Request().Context(), roomID); err != nil {
 		log.Errorf("[DeleteRoom] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete room").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // GetRoomsByUniversity godoc
@@ -240,12 +219,12 @@ func (h *SchedulesHandler) DeleteRoom(c echo.Context) error {
 // @Produce json
 // @Param university_id query int true "University ID"
 // @Success 200 {array} schedules.RoomsResponse
-// @Failure 400 {object} echo.HTTPError
-// @Failure 500 {object} echo.HTTPError
+// @Failure 400 {object} APIError
+// @Failure 500 {object} APIError
 // @Router /schedules/rooms [get]
 func (h *SchedulesHandler) GetRoomsByUniversity(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetRoomsByUniversity] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetRoomsByUniversity] called")
 
 	universityIDStr := c.QueryParam("university_id")
 	universityID, err := strconv.ParseInt(universityIDStr, 10, 64)
@@ -254,10 +233,10 @@ func (h *SchedulesHandler) GetRoomsByUniversity(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid university_id")
 	}
 
-	rooms, err := h.schedulesServ.GetRoomsByUniversity(context.TODO(), universityID)
+	rooms, err := h.schedulesServ.GetRoomsByUniversity(c.Request().Context(), universityID)
 	if err != nil {
 		log.Errorf("[GetRoomsByUniversity] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get rooms by university").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, rooms)
@@ -271,16 +250,16 @@ func (h *SchedulesHandler) GetRoomsByUniversity(c echo.Context) error {
 // @Produce      json
 // @Param        request  body      schedules.CreateLessonRequest  true  "Lesson info"
 // @Success      200      {object}  string    "id"
-// @Failure      400      {object}  echo.HTTPError       "Invalid request body"
-// @Failure      401      {object}  echo.HTTPError       "Unauthorized user"
-// @Failure      409      {object}  echo.HTTPError       "Schedule conflict"
-// @Failure      500      {object}  echo.HTTPError       "Internal server error"
+// @Failure      400      {object}  APIError             "Invalid request body"
+// @Failure      401      {object}  APIError             "Unauthorized user"
+// @Failure      409      {object}  APIError             "Schedule conflict"
+// @Failure      500      {object}  APIError             "Internal server error"
 // @Router       /schedules/lessons [post]
 func (h *SchedulesHandler) CreateLesson(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[CreateLesson] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateLesson] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -291,19 +270,15 @@ func (h *SchedulesHandler) CreateLesson(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
-	if (req.CourseGroupSubjectID == nil && req.ElectiveGroupSubjectID == nil) ||
-		(req.CourseGroupSubjectID != nil && req.ElectiveGroupSubjectID != nil) {
-		return echo.NewHTTPError(http.StatusBadRequest, "exactly one of course_group_subject_id or elective_group_subject_id must be set")
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateLesson] invalid request: %v", err)
+		return err
 	}
 
-	lessonID, err := h.schedulesServ.CreateLesson(context.Background(), req)
+	lessonID, err := h.schedulesServ.CreateLesson(c.Request().Context(), req)
 	if err != nil {
-		if errors.Is(err, repositories.ErrScheduleConflict) {
-			log.Errorf("[CreateLesson] schedule conflict: %v", err)
-			return echo.NewHTTPError(http.StatusConflict, "schedule conflict (group/teacher/student/room)")
-		}
 		log.Errorf("[CreateLesson] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create lesson")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create lesson").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, lessonID)
@@ -314,16 +289,16 @@ func (h *SchedulesHandler) CreateLesson(c echo.Context) error {
 // @Tags         schedules
 // @Produce      json
 // @Param        lesson_id  path      int  true  "Lesson ID"
-// @Success      200        {object}  string  "ok"
-// @Failure      400        {object}  echo.HTTPError  "Invalid lesson_id"
-// @Failure      401        {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500        {object}  echo.HTTPError  "Internal server error"
+// @Success      200        {object}  map[string]string  "status: ok"
+// @Failure      400        {object}  APIError        "Invalid lesson_id"
+// @Failure      401        {object}  APIError        "Unauthorized user"
+// @Failure      500        {object}  APIError        "Internal server error"
 // @Router       /schedules/lessons/{lesson_id} [delete]
 func (h *SchedulesHandler) DeleteLesson(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[DeleteLesson] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[DeleteLesson] called")
 
-	_, err := h.requireAdmin(c)
+	_, err := requireAdmin(c, h.userServ)
 	if err != nil {
 		return err
 	}
@@ -335,12 +310,12 @@ func (h *SchedulesHandler) DeleteLesson(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid lesson_id")
 	}
 
-	if err := h.schedulesServ.DeleteLesson(context.Background(), lessonID); err != nil {
+	if err := h.schedulesServ.DeleteLesson(c.Request().Context(), lessonID); err != nil {
 		log.Errorf("[DeleteLesson] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete lesson")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete lesson").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // GetUserSchedule godoc
@@ -350,13 +325,13 @@ func (h *SchedulesHandler) DeleteLesson(c echo.Context) error {
 // @Produce      json
 // @Param        user_id  path      int  true  "MAX user id"
 // @Success      200      {array}  	schedules.LessonsResponse
-// @Failure      400      {object}  echo.HTTPError  "Invalid user_id"
-// @Failure      401      {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500      {object}  echo.HTTPError  "Internal server error"
+// @Failure      400      {object}  APIError        "Invalid user_id"
+// @Failure      401      {object}  APIError        "Unauthorized user"
+// @Failure      500      {object}  APIError        "Internal server error"
 // @Router       /schedules/users/{user_id} [get]
 func (h *SchedulesHandler) GetUserSchedule(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[GetUserSchedule] called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetUserSchedule] called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -373,10 +348,10 @@ func (h *SchedulesHandler) GetUserSchedule(c echo.Context) error {
 
 	// Разрешаем либо самому себе, либо админу.
 	if currentUser.ID != userID {
-		roles, err := h.userServ.GetUserRolesByID(context.Background(), currentUser.ID)
+		roles, err := h.userServ.GetUserRolesByID(c.Request().Context(), currentUser.ID)
 		if err != nil {
 			log.Errorf("[GetUserSchedule] GetUserRolesByID error: %v", err)
-			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 		}
 
 		isAdmin := false
@@ -389,15 +364,115 @@ func (h *SchedulesHandler) GetUserSchedule(c echo.Context) error {
 
 		if !isAdmin {
 			log.Errorf("[GetUserSchedule] user is not owner and not admin")
-			return echo.NewHTTPError(http.StatusUnauthorized, "user is not allowed to view this schedule")
+			return echo.NewHTTPError(http.StatusForbidden, "user is not allowed to view this schedule")
 		}
 	}
 
-	schedule, err := h.schedulesServ.GetUserSchedule(context.Background(), userID)
+	schedule, err := h.schedulesServ.GetUserSchedule(c.Request().Context(), userID)
 	if err != nil {
 		log.Errorf("[GetUserSchedule] service error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user schedule")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user schedule").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, schedule)
 }
+
+// GetTimetablePDF godoc
+// @Summary      Printable timetable
+// @Description  PDF with the week grid of a course group, a teacher or a room: days are columns, pairs of the bell schedule are rows,
+// @Description  cells have subject, type, teacher, groups and room. Cells with every two week lessons are split into odd and even weeks,
+// @Description  the first created lesson of a group in the pair goes on odd weeks. Parity is not stored, so the split is nominal and the PDF says so.
+// @Description  Admin of the university is required.
+// @Tags         schedules
+// @Produce      application/pdf
+// @Param        kind  path      string  true  "group, teacher or room"
+// @Param        id    path      int     true  "course_groups.id, teachers.id or rooms.id"
+// @Success      200   {file}    file
+// @Failure      400   {object}  APIError
+// @Failure      401   {object}  APIError
+// @Failure      403   {object}  APIError
+// @Failure      404   {object}  APIError  "Group, teacher or room not found"
+// @Failure      500   {object}  APIError
+// @Router       /admin/timetables/{kind}/{id} [get]
+// @Security     BearerAuth
+func (h *SchedulesHandler) GetTimetablePDF(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetTimetablePDF] called")
+
+	user, err := requireAdmin(c, h.userServ)
+	if err != nil {
+		return err
+	}
+
+	var req schedules.TimetableRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[GetTimetablePDF] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[GetTimetablePDF] invalid request: %v", err)
+		return err
+	}
+
+	pdf, err := h.schedulesServ.TimetablePDF(c.Request().Context(), user.ID, req)
+	if err != nil {
+		log.Errorf("[GetTimetablePDF] service error: %v", err)
+		// not found and foreign owners are 404 and 403, only rendering fails with 500
+		var domainErr *services.Error
+		if errors.As(err, &domainErr) {
+			return domainErr
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render timetable").SetInternal(err)
+	}
+
+	filename := fmt.Sprintf("timetable_%s_%d.pdf", req.Kind, req.ID)
+	c.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": filename}))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetRoomOccupancy godoc
+// @Summary      Room occupancy
+// @Description  Rooms of the university with pairs they are taken in the week of date from monday to sunday: lessons of semesters covering
+// @Description  the day and pending or confirmed bookings. Every two week lessons are listed on every week. Rooms without slots are free all week.
+// @Description  Only admins, teachers and active students of the university see it.
+// @Tags         schedules
+// @Produce      json
+// @Param        university_id  query     int     true   "University ID"
+// @Param        date           query     string  false  "Any date of the week as 2006-01-02, today by default"
+// @Param        room_id        query     int     false  "Room ID"
+// @Success      200            {object}  schedules.RoomOccupancyResponse
+// @Failure      400            {object}  APIError
+// @Failure      401            {object}  APIError
+// @Failure      403            {object}  APIError  "Not a member of the university"
+// @Failure      404            {object}  APIError  "Room not found"
+// @Failure      500            {object}  APIError
+// @Router       /schedules/rooms/occupancy [get]
+// @Security     BearerAuth
+func (h *SchedulesHandler) GetRoomOccupancy(c echo.Context) error {
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[GetRoomOccupancy] called")
+
+	currentUser, ok := c.Get("user").(*models.User)
+	if !ok {
+		log.Errorf("[GetRoomOccupancy] user not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "user is not authenticated")
+	}
+
+	var req schedules.RoomOccupancyRequest
+	if err := c.Bind(&req); err != nil {
+		log.Errorf("[GetRoomOccupancy] invalid request data. err: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request data")
+	}
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[GetRoomOccupancy] invalid request: %v", err)
+		return err
+	}
+
+	occupancy, err := h.schedulesServ.GetRoomOccupancy(c.Request().Context(), currentUser.ID, req)
+	if err != nil {
+		log.Errorf("[GetRoomOccupancy] service error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get room occupancy").SetInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, occupancy)
+}
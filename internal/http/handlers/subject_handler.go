@@ -1,26 +1,25 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"net/http"
 	"slices"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/subjects"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type SubjectHandler struct {
 	subjectService *services.SubjectService
 	userService    *services.UserService
-	logger         embedlog.Logger
+	logger         logging.Logger
 }
 
-func NewSubjectHandler(subjectService *services.SubjectService, userService *services.UserService, logger embedlog.Logger) *SubjectHandler {
+func NewSubjectHandler(subjectService *services.SubjectService, userService *services.UserService, logger logging.Logger) *SubjectHandler {
 	return &SubjectHandler{
 		subjectService: subjectService,
 		userService:    userService,
@@ -35,17 +34,17 @@ func NewSubjectHandler(subjectService *services.SubjectService, userService *ser
 // @Accept       json
 // @Produce      json
 // @Param        request  body      subjects.CreateSubjectRequest  true  "Subject data"
-// @Success      200      {object}  string                         "ok"
-// @Failure      400      {object}  echo.HTTPError                 "Invalid request body"
-// @Failure      401      {object}  echo.HTTPError                 "Unauthorized user"
-// @Failure      500      {object}  echo.HTTPError                 "Internal server error"
+// @Success      200      {object}  map[string]string                         "status: ok"
+// @Failure      400      {object}  APIError                       "Invalid request body"
+// @Failure      401      {object}  APIError                       "Unauthorized user"
+// @Failure      500      {object}  APIError                       "Internal server error"
 // @Router       /subjects [post]
 // @Security     BearerAuth
 func (h *SubjectHandler) Create(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
-	log.Print(context.Background(), "[Create] Create subject called")
+	log.Print(c.Request().Context(), "[Create] Create subject called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 
@@ -57,14 +56,14 @@ func (h *SubjectHandler) Create(c echo.Context) error {
 	roles, err := h.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[Create] GetUserRolesByID error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "user is not authenticated")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 	hasAdmin := slices.ContainsFunc(roles.Roles, func(s string) bool {
 		return s == "admin"
 	})
 	if !hasAdmin {
 		log.Errorf("[Create] GetUserRolesByID role admin not found")
-		return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+		return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	}
 
 	var request subjects.CreateSubjectRequest
@@ -72,12 +71,18 @@ func (h *SubjectHandler) Create(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
+	if err := c.Validate(&request); err != nil {
+		log.Errorf("[Create] invalid request: %v", err)
+		return err
+	}
+
 	err = h.subjectService.Create(ctx, request)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create subject")
+		log.Errorf("[Create] failed to create subject: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create subject").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
 
 // Get godoc
@@ -89,16 +94,16 @@ func (h *SubjectHandler) Create(c echo.Context) error {
 // @Param        limit   query     int                        true   "Limit of requests, max(50), default(5)"
 // @Param        offset  query     int                        true   "Offset, default(0)"
 // @Success      200     {object}  subjects.SubjectsResponse  "List of subjects"
-// @Failure      400     {object}  echo.HTTPError             "Invalid request body"
-// @Failure      401     {object}  echo.HTTPError             "Unauthorized user"
-// @Failure      500     {object}  echo.HTTPError             "Internal server error"
+// @Failure      400     {object}  APIError                   "Invalid request body"
+// @Failure      401     {object}  APIError                   "Unauthorized user"
+// @Failure      500     {object}  APIError                   "Internal server error"
 // @Router       /subjects [get]
 // @Security     BearerAuth
 func (h *SubjectHandler) Get(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
-	log.Print(context.Background(), "[Get] Get subject called")
+	log.Print(c.Request().Context(), "[Get] Get subject called")
 
 	_, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -137,9 +142,15 @@ func (h *SubjectHandler) Get(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
 
+	if err := c.Validate(&request); err != nil {
+		log.Errorf("[Get] invalid request: %v", err)
+		return err
+	}
+
 	subs, err := h.subjectService.Get(ctx, request, limitInt, offsetInt)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get subject")
+		log.Errorf("[Get] failed to get subjects: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get subject").SetInternal(err)
 	}
 	return c.JSON(http.StatusOK, subs)
 }
@@ -151,17 +162,17 @@ func (h *SubjectHandler) Get(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        subject_id  query     int             true  "Subject ID"
-// @Success      200         {object}  string          "ok"
-// @Failure      400         {object}  echo.HTTPError  "Invalid request body"
-// @Failure      401         {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      500         {object}  echo.HTTPError  "Internal server error"
+// @Success      200         {object}  map[string]string          "status: ok"
+// @Failure      400         {object}  APIError        "Invalid request body"
+// @Failure      401         {object}  APIError        "Unauthorized user"
+// @Failure      500         {object}  APIError        "Internal server error"
 // @Router       /subjects [delete]
 // @Security     BearerAuth
 func (h *SubjectHandler) Delete(c echo.Context) error {
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 	ctx := c.Request().Context()
 
-	log.Print(context.Background(), "[Delete] Delete subject called")
+	log.Print(c.Request().Context(), "[Delete] Delete subject called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -172,14 +183,14 @@ func (h *SubjectHandler) Delete(c echo.Context) error {
 	roles, err := h.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[Create] GetUserRolesByID error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "user is not authenticated")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 	hasAdmin := slices.ContainsFunc(roles.Roles, func(s string) bool {
 		return s == "admin"
 	})
 	if !hasAdmin {
 		log.Errorf("[Create] GetUserRolesByID role admin not found")
-		return echo.NewHTTPError(http.StatusUnauthorized, "user is not admin")
+		return echo.NewHTTPError(http.StatusForbidden, "user is not admin")
 	}
 
 	subject := c.Param("subject_id")
@@ -197,8 +208,8 @@ func (h *SubjectHandler) Delete(c echo.Context) error {
 	err = h.subjectService.Delete(ctx, subjectID)
 	if err != nil {
 		log.Errorf("[Delete] Delete subject error: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete subject")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete subject").SetInternal(err)
 	}
 
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
 }
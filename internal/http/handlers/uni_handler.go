@@ -1,24 +1,23 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type UniHandler struct {
 	uniService  *services.UniService
 	userService *services.UserService
-	logger      embedlog.Logger
+	logger      logging.Logger
 }
 
-func NewUniHandler(uniService *services.UniService, userService *services.UserService, logger embedlog.Logger) *UniHandler {
+func NewUniHandler(uniService *services.UniService, userService *services.UserService, logger logging.Logger) *UniHandler {
 	return &UniHandler{
 		userService: userService,
 		uniService:  uniService,
@@ -33,16 +32,16 @@ func NewUniHandler(uniService *services.UniService, userService *services.UserSe
 // @Accept       json
 // @Produce      json
 // @Success      200   {object}  dto.UniInfoResponse  "University information"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized - user not authenticated"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error - failed to get university info"
+// @Failure      401   {object}  APIError        "Unauthorized - user not authenticated"
+// @Failure      500   {object}  APIError        "Internal server error - failed to get university info"
 // @Router       /universities/info [get]
 // @Security     BearerAuth
 func (u *UniHandler) GetUniInfo(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetUniInfo] GetUniInfo called")
+	log.Print(c.Request().Context(), "[GetUniInfo] GetUniInfo called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -54,7 +53,7 @@ func (u *UniHandler) GetUniInfo(c echo.Context) error {
 
 	if err != nil {
 		log.Errorf("[GetUniInfo] Failed get info about uni. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed get info about uni")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed get info about uni").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, dto.UniInfoResponse{
@@ -75,21 +74,21 @@ func (u *UniHandler) GetUniInfo(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Success      200   {array}   dto.UniInfoResponse  "List of universities"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error - failed to get universities"
+// @Failure      500   {object}  APIError        "Internal server error - failed to get universities"
 // @Router       /universities/ [get]
 // @Security     BearerAuth
 func (u *UniHandler) GetAllUniversities(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetAllUniversities] GetAllUniversities called")
+	log.Print(c.Request().Context(), "[GetAllUniversities] GetAllUniversities called")
 
 	universities, err := u.uniService.GetAllUniversities(ctx)
 
 	if err != nil {
 		log.Errorf("[GetAllUniversities] failed get all universities. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed get all universities")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed get all universities").SetInternal(err)
 	}
 
 	var response []dto.UniInfoResponse
@@ -116,23 +115,28 @@ func (u *UniHandler) GetAllUniversities(c echo.Context) error {
 // @Produce      json
 // @Param        request  body   dto.CreateSemestersRequest  true  "Semester periods data"
 // @Success      200   {object}  map[string]string  "status: semesters created successfully"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body or date format"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      403   {object}  echo.HTTPError  "Forbidden - user is not admin"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request body or date format"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      403   {object}  APIError        "Forbidden - user is not admin"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /universities/semesters [post]
 // @Security     BearerAuth
 func (u *UniHandler) CreateNewSemesterPeriod(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	log := c.Get("logger").(embedlog.Logger)
-	log.Print(context.Background(), "[CreateSemesters] CreateSemesters called")
+	log := logging.FromContext(c.Request().Context())
+	log.Print(c.Request().Context(), "[CreateSemesters] CreateSemesters called")
 
 	var req dto.CreateSemestersRequest
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[CreateSemesters] Invalid request format: %v ", err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format: "+err.Error())
+		log.Errorf("[CreateSemesters] Invalid request format: %v ", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request format")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateSemesters] invalid request: %v", err)
+		return err
 	}
 
 	currentUser, ok := c.Get("user").(*models.User)
@@ -142,7 +146,7 @@ func (u *UniHandler) CreateNewSemesterPeriod(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateSemesters] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -160,12 +164,13 @@ func (u *UniHandler) CreateNewSemesterPeriod(c echo.Context) error {
 	periods, err := ConvertDtoModel(req.Periods)
 	if err != nil {
 		log.Errorf("[CreateSemesters] failed convert string time -> time.Time. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed convert string time -> time.Time")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed convert string time -> time.Time").SetInternal(err)
 	}
 
 	err = u.uniService.SetNewSemesterPeriod(ctx, int64(req.ID), periods)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed create semesters")
+		log.Errorf("[CreateSemesters] failed create semesters: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed create semesters").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "semesters created successfully"})
@@ -201,17 +206,17 @@ func ConvertDtoModel(dto []dto.SemesterPeriod) ([]models.SemesterPeriod, error)
 // @Produce      json
 // @Param        request  body   dto.CreateDepartmentRequest  true  "Department data (department_name required, department_code and alias_name optional)"
 // @Success      200   {object}  map[string]string  "status: department created successfully"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body or missing required fields"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      403   {object}  echo.HTTPError  "Forbidden - user is not admin"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request body or missing required fields"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      403   {object}  APIError        "Forbidden - user is not admin"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /admin/department [post]
 // @Security     BearerAuth
 func (u *UniHandler) CreateNewDepartment(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[CreateNewDepartment] CreateNewDepartment called")
+	log.Print(c.Request().Context(), "[CreateNewDepartment] CreateNewDepartment called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -222,7 +227,7 @@ func (u *UniHandler) CreateNewDepartment(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewDepartment] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -244,15 +249,15 @@ func (u *UniHandler) CreateNewDepartment(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
 	}
 
-	if req.DepartmentName == "" {
-		log.Errorf("[CreateNewDepartment] department name is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "department name is required")
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateNewDepartment] invalid request: %v", err)
+		return err
 	}
 
 	err = u.uniService.CreateNewDepartment(ctx, req.DepartmentName, req.DepartmentCode, req.AliasName, req.FacultyID, req.UniversityID)
 	if err != nil {
 		log.Errorf("[CreateNewDepartment] failed to create new department: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new department")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new department").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "department created successfully"})
@@ -266,17 +271,17 @@ func (u *UniHandler) CreateNewDepartment(c echo.Context) error {
 // @Produce      json
 // @Param        request  body   dto.CreateCourseRequest  true  "Course data (start_date, end_date, university_department_id required)"
 // @Success      200   {object}  map[string]string  "status: course created successfully"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body or missing required fields"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      403   {object}  echo.HTTPError  "Forbidden - user is not admin"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request body or missing required fields"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      403   {object}  APIError        "Forbidden - user is not admin"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /admin/courses [post]
 // @Security     BearerAuth
 func (u *UniHandler) CreateNewCourse(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[CreateNewCourse] CreateNewCourse called")
+	log.Print(c.Request().Context(), "[CreateNewCourse] CreateNewCourse called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -287,7 +292,7 @@ func (u *UniHandler) CreateNewCourse(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewCourse] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -309,19 +314,9 @@ func (u *UniHandler) CreateNewCourse(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
 	}
 
-	if req.StartDate == "" {
-		log.Errorf("[CreateNewCourse] start date is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "start date is required")
-	}
-
-	if req.EndDate == "" {
-		log.Errorf("[CreateNewCourse] end date is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "end date is required")
-	}
-
-	if req.UniversityDepartment <= 0 {
-		log.Errorf("[CreateNewCourse] university department ID is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "university department ID is required")
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateNewCourse] invalid request: %v", err)
+		return err
 	}
 
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
@@ -336,10 +331,10 @@ func (u *UniHandler) CreateNewCourse(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid end date format, use YYYY-MM-DD")
 	}
 
-	err = u.uniService.CreateNewCourse(ctx, startDate, endDate, req.UniversityDepartment)
+	err = u.uniService.CreateNewCourse(ctx, startDate, endDate, req.UniversityDepartment, req.YearOfStudy)
 	if err != nil {
 		log.Errorf("[CreateNewCourse] failed to create new course: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new course")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new course").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "course created successfully"})
@@ -352,16 +347,16 @@ func (u *UniHandler) CreateNewCourse(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Success      200  {array}   dto.CourseInfoResponse  "List of courses"
-// @Failure      401  {object}  echo.HTTPError          "Unauthorized user"
-// @Failure      403  {object}  echo.HTTPError          "Forbidden - user is not admin"
-// @Failure      500  {object}  echo.HTTPError          "Internal server error"
+// @Failure      401  {object}  APIError                "Unauthorized user"
+// @Failure      403  {object}  APIError                "Forbidden - user is not admin"
+// @Failure      500  {object}  APIError                "Internal server error"
 // @Router       /admin/courses [get]
 // @Security     BearerAuth
 func (u *UniHandler) GetAllCourses(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetAllCourses] GetAllCourses called")
+	log.Print(c.Request().Context(), "[GetAllCourses] GetAllCourses called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -372,7 +367,7 @@ func (u *UniHandler) GetAllCourses(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetAllCourses] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -391,13 +386,13 @@ func (u *UniHandler) GetAllCourses(c echo.Context) error {
 	uniInfo, err := u.uniService.GetInfoAboutUni(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetAllCourses] failed to get university info: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info").SetInternal(err)
 	}
 
 	courses, err := u.uniService.GetAllCoursesByUniversityID(ctx, int64(uniInfo.ID))
 	if err != nil {
 		log.Errorf("[GetAllCourses] failed to get courses: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get courses")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get courses").SetInternal(err)
 	}
 
 	// Конвертируем в DTO
@@ -408,6 +403,7 @@ func (u *UniHandler) GetAllCourses(c echo.Context) error {
 			StartDate:            course.StartDate.Format("2006-01-02"),
 			EndDate:              course.EndDate.Format("2006-01-02"),
 			UniversityDepartment: course.UniversityDepartment,
+			YearOfStudy:          course.YearOfStudy,
 		})
 	}
 
@@ -422,17 +418,17 @@ func (u *UniHandler) GetAllCourses(c echo.Context) error {
 // @Produce      json
 // @Param        request  body   dto.CreateGroupRequest  true  "Group data (group_name and course_id required)"
 // @Success      200   {object}  map[string]string  "status: group created successfully"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body or missing required fields"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      403   {object}  echo.HTTPError  "Forbidden - user is not admin"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request body or missing required fields"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      403   {object}  APIError        "Forbidden - user is not admin"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /admin/groups [post]
 // @Security     BearerAuth
 func (u *UniHandler) CreateNewGroup(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[CreateNewGroup] CreateNewGroup called")
+	log.Print(c.Request().Context(), "[CreateNewGroup] CreateNewGroup called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -443,7 +439,7 @@ func (u *UniHandler) CreateNewGroup(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewGroup] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -465,20 +461,15 @@ func (u *UniHandler) CreateNewGroup(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
 	}
 
-	if req.GroupName == "" {
-		log.Errorf("[CreateNewGroup] group name is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "group name is required")
-	}
-
-	if req.CourseID <= 0 {
-		log.Errorf("[CreateNewGroup] course ID is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "course ID is required")
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateNewGroup] invalid request: %v", err)
+		return err
 	}
 
 	err = u.uniService.CreateNewGroup(ctx, req.GroupName, req.CourseID)
 	if err != nil {
 		log.Errorf("[CreateNewGroup] failed to create new group: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new group")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create new group").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "group created successfully"})
@@ -491,15 +482,15 @@ func (u *UniHandler) CreateNewGroup(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Success      200   {array}   dto.EventResponse  "List of events"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized - user not authenticated"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      401   {object}  APIError        "Unauthorized - user not authenticated"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /universities/events [get]
 // @Security     BearerAuth
 func (u *UniHandler) GetAllEvents(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetAllEvents] GetAllEvents called")
+	log.Print(c.Request().Context(), "[GetAllEvents] GetAllEvents called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -510,13 +501,13 @@ func (u *UniHandler) GetAllEvents(c echo.Context) error {
 	uniInfo, err := u.uniService.GetInfoAboutUni(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetAllEvents] failed to get university info. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info").SetInternal(err)
 	}
 
 	events, err := u.uniService.GetAllEventsByUniversityID(ctx, int64(uniInfo.ID))
 	if err != nil {
 		log.Errorf("[GetAllEvents] failed to get events. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get events")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get events").SetInternal(err)
 	}
 
 	var response []dto.EventResponse
@@ -541,17 +532,17 @@ func (u *UniHandler) GetAllEvents(c echo.Context) error {
 // @Produce      json
 // @Param        request  body   dto.CreateEventRequest  true  "Event data"
 // @Success      200   {object}  map[string]string  "status: event created successfully"
-// @Failure      400   {object}  echo.HTTPError  "Invalid request body or missing required fields"
-// @Failure      401   {object}  echo.HTTPError  "Unauthorized user"
-// @Failure      403   {object}  echo.HTTPError  "Forbidden - user is not admin"
-// @Failure      500   {object}  echo.HTTPError  "Internal server error"
+// @Failure      400   {object}  APIError        "Invalid request body or missing required fields"
+// @Failure      401   {object}  APIError        "Unauthorized user"
+// @Failure      403   {object}  APIError        "Forbidden - user is not admin"
+// @Failure      500   {object}  APIError        "Internal server error"
 // @Router       /universities/events [post]
 // @Security     BearerAuth
 func (u *UniHandler) CreateNewEvent(c echo.Context) error {
 	ctx := c.Request().Context()
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[CreateNewEvent] CreateNewEvent called")
+	log.Print(c.Request().Context(), "[CreateNewEvent] CreateNewEvent called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -562,7 +553,7 @@ func (u *UniHandler) CreateNewEvent(c echo.Context) error {
 	roles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewEvent] fail to get user roles. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get user roles").SetInternal(err)
 	}
 
 	isAdmin := false
@@ -580,7 +571,7 @@ func (u *UniHandler) CreateNewEvent(c echo.Context) error {
 	uniInfo, err := u.uniService.GetInfoAboutUni(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[CreateNewEvent] failed to get university info. err: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get university info").SetInternal(err)
 	}
 
 	var req dto.CreateEventRequest
@@ -590,19 +581,9 @@ func (u *UniHandler) CreateNewEvent(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
 	}
 
-	if req.Title == "" {
-		log.Errorf("[CreateNewEvent] title is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "title is required")
-	}
-
-	if req.Description == "" {
-		log.Errorf("[CreateNewEvent] description is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "description is required")
-	}
-
-	if req.PhotoUrl == "" {
-		log.Errorf("[CreateNewEvent] photo_url is required")
-		return echo.NewHTTPError(http.StatusBadRequest, "photo_url is required")
+	if err := c.Validate(&req); err != nil {
+		log.Errorf("[CreateNewEvent] invalid request: %v", err)
+		return err
 	}
 
 	event := models.Event{
@@ -615,7 +596,7 @@ func (u *UniHandler) CreateNewEvent(c echo.Context) error {
 	err = u.uniService.CreateNewEvent(ctx, event)
 	if err != nil {
 		log.Errorf("[CreateNewEvent] failed to create event: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create event")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create event").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"status": "event created successfully"})
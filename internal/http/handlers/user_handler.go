@@ -1,22 +1,21 @@
 package handlers
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
-	"github.com/vmkteam/embedlog"
 )
 
 type UserHandler struct {
 	userService *services.UserService
-	logger      embedlog.Logger
+	logger      logging.Logger
 }
 
-func NewUserHandler(service *services.UserService, logger embedlog.Logger) *UserHandler {
+func NewUserHandler(service *services.UserService, logger logging.Logger) *UserHandler {
 	return &UserHandler{
 		userService: service,
 		logger:      logger,
@@ -30,15 +29,15 @@ func NewUserHandler(service *services.UserService, logger embedlog.Logger) *User
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  dto.UserInfoResponse  "User information with roles"
-// @Failure      401  {object}  echo.HTTPError        "Unauthorized user"
-// @Failure      500  {object}  echo.HTTPError        "Internal server error"
+// @Failure      401  {object}  APIError              "Unauthorized user"
+// @Failure      500  {object}  APIError              "Internal server error"
 // @Router       /user/me [get]
 // @Security     BearerAuth
 func (u *UserHandler) GetUserInfo(c echo.Context) error {
 
-	log := c.Get("logger").(embedlog.Logger)
+	log := logging.FromContext(c.Request().Context())
 
-	log.Print(context.Background(), "[GetUserInfo] GetUserInfo called")
+	log.Print(c.Request().Context(), "[GetUserInfo] GetUserInfo called")
 
 	currentUser, ok := c.Get("user").(*models.User)
 	if !ok {
@@ -52,13 +51,13 @@ func (u *UserHandler) GetUserInfo(c echo.Context) error {
 
 	if err != nil {
 		log.Errorf("[GetUserInfo] Failed get user info: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed get user info")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed get user info").SetInternal(err)
 	}
 
 	userRoles, err := u.userService.GetUserRolesByID(ctx, currentUser.ID)
 	if err != nil {
 		log.Errorf("[GetUserInfo] Failed find user roles: %v", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "failed find user roles")
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed find user roles").SetInternal(err)
 	}
 
 	log.Printf("[GetUserInfo] User info retrieved successfully for user ID %d. User roels: %v", currentUser.ID, userRoles.Roles)
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+)
+
+// incoming X-Request-ID is accepted only if it can't break log lines
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestContext sets X-Request-ID of the request (incoming one or a new uuid) and the request logger
+// with request_id, method and route in the request context, handlers, services and repositories take it by logging.FromContext.
+func requestContext(logger logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID.MatchString(id) {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			log := logger.With("request_id", id, "method", req.Method, "route", c.Path())
+			c.SetRequest(req.WithContext(logging.WithContext(req.Context(), log)))
+
+			return next(c)
+		}
+	}
+}
+
+// ipExtractor returns the client IP for c.RealIP() (rate limits, logs). X-Forwarded-For is trusted only
+// from proxies, otherwise any client could get a fresh rate limit bucket with a random header.
+func ipExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		// CIDRs are checked by config.Validate
+		if _, ipNet, err := net.ParseCIDR(proxy); err == nil {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	config "github.com/max-main-team/backend_hackaton_MAX/cfg"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/ratelimit"
+)
+
+func TestIPExtractorRateLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		// statuses of requests with a new X-Forwarded-For each
+		want []int
+	}{
+		{
+			name:       "direct, spoofed header is ignored",
+			remoteAddr: "203.0.113.7:40000",
+			want:       []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests},
+		},
+		{
+			name:           "untrusted peer, spoofed header is ignored",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "203.0.113.7:40000",
+			want:           []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests},
+		},
+		{
+			name:           "trusted proxy, header is the client",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:40000",
+			want:           []int{http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = ipExtractor(tt.trustedProxies)
+			limiter := ratelimit.New(ratelimit.NewMemoryStore(), config.RateLimitConfig{
+				Enabled: true,
+				Public:  config.RateQuota{Requests: 1, Period: 3600, Burst: 1},
+			})
+			e.POST("/auth/login", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, limiter.ByIP())
+
+			for i, want := range tt.want {
+				req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+				req.RemoteAddr = tt.remoteAddr
+				spoofed := "198.51.100." + strconv.Itoa(i+1)
+				req.Header.Set(echo.HeaderXForwardedFor, spoofed)
+				req.Header.Set(echo.HeaderXRealIP, spoofed)
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+
+				if rec.Code != want {
+					t.Errorf("request %d: status %d, want %d", i+1, rec.Code, want)
+				}
+			}
+		})
+	}
+}
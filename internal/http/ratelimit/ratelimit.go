@@ -0,0 +1,103 @@
+// Package ratelimit limits requests with token buckets keyed by client IP or by authenticated user.
+// Responses carry RateLimit-* headers (draft-ietf-httpapi-ratelimit-headers) and Retry-After when limited.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	config "github.com/max-main-team/backend_hackaton_MAX/cfg"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services/auth"
+)
+
+type Limiter struct {
+	store   Store
+	enabled bool
+	public  config.RateQuota
+	user    config.RateQuota
+	routes  map[string]config.RateQuota
+}
+
+func New(store Store, cfg config.RateLimitConfig) *Limiter {
+	return &Limiter{
+		store:   store,
+		enabled: cfg.Enabled,
+		public:  cfg.Public,
+		user:    cfg.User,
+		routes:  cfg.Routes,
+	}
+}
+
+// ByIP limits requests by client IP, for public routes.
+func (l *Limiter) ByIP() echo.MiddlewareFunc {
+	return l.middleware(l.public, func(c echo.Context) string {
+		return "ip:" + c.RealIP()
+	})
+}
+
+// ByUser limits requests by authenticated user, must be used after JWTMiddleware.
+func (l *Limiter) ByUser() echo.MiddlewareFunc {
+	return l.middleware(l.user, func(c echo.Context) string {
+		if user := auth.GetUserFromContext(c); user != nil {
+			return "user:" + strconv.FormatInt(user.ID, 10)
+		}
+		return "ip:" + c.RealIP()
+	})
+}
+
+func (l *Limiter) middleware(defaultQuota config.RateQuota, keyFunc func(c echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !l.enabled {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			route := c.Request().Method + " " + c.Path()
+
+			key := keyFunc(c)
+			quota, ok := l.routes[route]
+			if ok {
+				// у маршрута своя квота и свой бакет
+				key += " " + route
+			} else {
+				quota = defaultQuota
+			}
+
+			rate := float64(quota.Requests) / float64(quota.Period)
+			burst := float64(quota.Burst)
+
+			allowed, tokens, err := l.store.Take(c.Request().Context(), key, rate, burst)
+			if err != nil {
+				// rate limiter must not take api down with the store
+				log := logging.FromContext(c.Request().Context())
+				log.Errorf("[RateLimit] failed to take token for %s: %v", key, err)
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d", quota.Requests, quota.Period, quota.Burst))
+			h.Set("RateLimit-Limit", strconv.Itoa(quota.Burst))
+			h.Set("RateLimit-Remaining", strconv.Itoa(int(math.Floor(tokens))))
+			// seconds until the bucket is full again
+			h.Set("RateLimit-Reset", strconv.Itoa(secondsUntil(burst-tokens, rate)))
+
+			if !allowed {
+				h.Set("Retry-After", strconv.Itoa(secondsUntil(1-tokens, rate)))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "Too many requests")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func secondsUntil(tokens, rate float64) int {
+	if tokens <= 0 {
+		return 0
+	}
+	return int(math.Ceil(tokens / rate))
+}
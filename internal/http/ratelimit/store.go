@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+const (
+	// buckets not used for idleTTL are full again and can be dropped
+	idleTTL      = time.Hour
+	cleanupEvery = 10 * time.Minute
+)
+
+// Store keeps token buckets. rate is in tokens/sec, a new bucket has burst tokens.
+type Store interface {
+	Take(ctx context.Context, key string, rate, burst float64) (allowed bool, tokens float64, err error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore keeps buckets of a single instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rate, burst float64) (bool, float64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > cleanupEvery {
+		for k, b := range s.buckets {
+			if now.Sub(b.updated) > idleTTL {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false, b.tokens, nil
+	}
+	b.tokens--
+	return true, b.tokens, nil
+}
+
+// PostgresStore keeps buckets in db, so the limit is shared by all instances.
+type PostgresStore struct {
+	repo   repositories.RateLimitRepository
+	logger logging.Logger
+}
+
+func NewPostgresStore(repo repositories.RateLimitRepository, logger logging.Logger) *PostgresStore {
+	return &PostgresStore{repo: repo, logger: logger}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, key string, rate, burst float64) (bool, float64, error) {
+	return s.repo.Take(ctx, key, rate, burst)
+}
+
+// Run removes idle buckets until ctx is done.
+func (s *PostgresStore) Run(ctx context.Context) {
+	ticker := time.NewTicker(cleanupEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.repo.DeleteIdle(ctx, time.Now().Add(-idleTTL)); err != nil {
+				s.logger.Errorf("[RateLimit] failed to delete idle buckets: %v", err)
+			}
+		}
+	}
+}
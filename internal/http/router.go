@@ -7,14 +7,21 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	cfg "github.com/max-main-team/backend_hackaton_MAX/cfg"
 	_ "github.com/max-main-team/backend_hackaton_MAX/docs"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/http/handlers"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/http/ratelimit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/services/auth"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	"github.com/vmkteam/embedlog"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 )
 
-func NewRouter(logger embedlog.Logger,
+func NewRouter(logger logging.Logger,
+	serviceName string,
+	server cfg.ServerConfig,
 	userHandler *handlers.UserHandler,
 	authHandler *handlers.AuthHandler,
 	jwtService *auth.JWTService,
@@ -22,13 +29,47 @@ func NewRouter(logger embedlog.Logger,
 	personsHandler *handlers.PersonalitiesHandler,
 	facultiesHandler *handlers.FaculHandler,
 	subjectsHandler *handlers.SubjectHandler,
-	schedulesHandler *handlers.SchedulesHandler) *echo.Echo {
+	schedulesHandler *handlers.SchedulesHandler,
+	hierarchyHandler *handlers.HierarchyHandler,
+	curriculumHandler *handlers.CurriculumHandler,
+	electivesHandler *handlers.ElectivesHandler,
+	admissionsHandler *handlers.AdmissionsHandler,
+	rolloverHandler *handlers.RolloverHandler,
+	importHandler *handlers.ImportHandler,
+	exportHandler *handlers.ExportHandler,
+	bookingsHandler *handlers.BookingsHandler,
+	examsHandler *handlers.ExamsHandler,
+	impersonationHandler *handlers.ImpersonationHandler,
+	impersonationRepo repositories.ImpersonationRepository,
+	auditHandler *handlers.AuditHandler,
+	healthHandler *handlers.HealthHandler,
+	requireAdmin echo.MiddlewareFunc,
+	limiter *ratelimit.Limiter) *echo.Echo {
 	e := echo.New()
+	e.HTTPErrorHandler = errorHandler()
+	e.Validator = newRequestValidator()
+	// IP клиента: X-Forwarded-For принимается только от доверенных прокси, иначе он подделывается
+	e.IPExtractor = ipExtractor(server.TrustedProxies)
 
 	// Настройка таймаутов HTTP сервера
-	e.Server.ReadTimeout = 15 * time.Second  // Таймаут чтения запроса
-	e.Server.WriteTimeout = 15 * time.Second // Таймаут записи ответа
-	e.Server.IdleTimeout = 120 * time.Second // Таймаут для idle соединений
+	e.Server.ReadTimeout = time.Duration(server.ReadTimeout) * time.Second   // Таймаут чтения запроса
+	e.Server.WriteTimeout = time.Duration(server.WriteTimeout) * time.Second // Таймаут записи ответа
+	e.Server.IdleTimeout = time.Duration(server.IdleTimeout) * time.Second   // Таймаут для idle соединений
+
+	// request id и логгер запроса нужны всем остальным, в том числе обработчику ошибок
+	e.Use(requestContext(logger))
+
+	// span на каждый маршрут, контекст трассировки берётся из заголовка traceparent
+	e.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		switch c.Path() {
+		case "/healthz", "/readyz", "/metrics":
+			return true
+		}
+		return false
+	})))
+
+	// метрики: ошибка обработчика рендерится в них, чтобы знать итоговый статус
+	e.Use(metrics.Middleware())
 
 	// e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 	// 	Format: `[${time_rfc3339}] ${method} ${uri} ${status} ${latency_human} ` +
@@ -40,11 +81,7 @@ func NewRouter(logger embedlog.Logger,
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 
-		AllowOrigins: []string{
-			"https://hackaton-max.vercel.app",
-			"https://msokovykh.ru",
-			"https://www.msokovykh.ru",
-		},
+		AllowOrigins: server.CORSOrigins,
 		AllowMethods: []string{
 			http.MethodGet,
 			http.MethodPost,
@@ -69,7 +106,7 @@ func NewRouter(logger embedlog.Logger,
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 
-			ctx, cancel := context.WithTimeout(c.Request().Context(), 120*time.Second)
+			ctx, cancel := context.WithTimeout(c.Request().Context(), time.Duration(server.RequestTimeout)*time.Second)
 			defer cancel()
 
 			c.SetRequest(c.Request().WithContext(ctx))
@@ -78,40 +115,59 @@ func NewRouter(logger embedlog.Logger,
 		}
 	})
 
-	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			c.Set("logger", logger)
-			return next(c)
-		}
-	})
-
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 	// e.GET("/test", userHandler.GetUserById)
 
-	e.GET("/", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Костя лошара")
-	})
+	e.GET("/", healthHandler.Healthz)
+	e.GET("/healthz", healthHandler.Healthz)
+	e.GET("/readyz", healthHandler.Readyz)
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
 
 	protected := e.Group("")
 
 	public := e.Group("")
 
-	public.POST("/auth/login", authHandler.Login)
-	public.POST("/auth/refresh", authHandler.Refresh)
+	// публичные маршруты ограничиваются по IP, защищённые — по пользователю
+	limitByIP := limiter.ByIP()
+
+	public.POST("/auth/login", authHandler.Login, limitByIP)
+	public.POST("/auth/refresh", authHandler.Refresh, limitByIP)
+	public.POST("/auth/dev/login", authHandler.DevLogin, limitByIP)
+	public.GET("/.well-known/jwks.json", authHandler.JWKS, limitByIP)
 
 	protected.Use(jwtService.JWTMiddleware())
+	// запросы под токеном "войти как пользователь" пишутся в аудит, изменения запрещены
+	protected.Use(auth.ImpersonationMiddleware(impersonationRepo))
+	protected.Use(limiter.ByUser())
 
 	users := protected.Group("/user")
 
 	users.GET("/me", userHandler.GetUserInfo)
 
 	protected.GET("/auth/checkToken", authHandler.CheckToken)
+	// выход со всех устройств: refresh токены удаляются, версия токенов пользователя повышается
+	protected.POST("/auth/logout", authHandler.Logout)
 	// protected.GET("/test", userHandler.GetUserById)
 
-	admin := protected.Group("/admin")
+	// заявку на доступ подаёт пользователь без роли admin, поэтому маршрут вне группы admin
+	protected.POST("/admin/personalities/access", personsHandler.RequestAccess)
+
+	// вся группа только для роли admin, обработчики дополнительно ограничивают данные университетами админа
+	admin := protected.Group("/admin", requireAdmin)
+	admin.POST("/impersonate", impersonationHandler.Impersonate)
+	admin.GET("/audit", auditHandler.GetAuditLog)
 	faculties := admin.Group("/faculties")
 	faculties.GET("", facultiesHandler.GetFaculties)
 	faculties.POST("", facultiesHandler.CreateNewFaculty)
+	faculties.GET("/:id", hierarchyHandler.GetFaculty)
+	faculties.PUT("/:id", hierarchyHandler.UpdateFaculty)
+	faculties.DELETE("/:id", hierarchyHandler.DeleteFaculty)
+
+	// удаление мягкое и каскадное, запрещено пока есть активные студенты или занятия в расписании
+	universities := admin.Group("/universities")
+	universities.GET("/:id", hierarchyHandler.GetUniversity)
+	universities.PUT("/:id", hierarchyHandler.UpdateUniversity)
+	universities.DELETE("/:id", hierarchyHandler.DeleteUniversity)
 
 	uni := protected.Group("/universities")
 
@@ -124,7 +180,6 @@ func NewRouter(logger embedlog.Logger,
 
 	// personalities Admin
 	persons := admin.Group("/personalities")
-	persons.POST("/access", personsHandler.RequestAccess)
 	persons.GET("/access", personsHandler.GetRequests)
 	persons.DELETE("/access", personsHandler.RejectRequestAccess)
 	persons.POST("/access/accept", personsHandler.AcceptAccess)
@@ -146,15 +201,117 @@ func NewRouter(logger embedlog.Logger,
 	// department
 	department := admin.Group("/department")
 	department.POST("", uniHandler.CreateNewDepartment)
+	department.GET("/:id", hierarchyHandler.GetDepartment)
+	department.PUT("/:id", hierarchyHandler.UpdateDepartment)
+	department.DELETE("/:id", hierarchyHandler.DeleteDepartment)
 
 	// courses
 	courses := admin.Group("/courses")
 	courses.POST("", uniHandler.CreateNewCourse)
 	courses.GET("", uniHandler.GetAllCourses)
+	courses.GET("/:id", hierarchyHandler.GetCourse)
+	courses.PUT("/:id", hierarchyHandler.UpdateCourse)
+	courses.DELETE("/:id", hierarchyHandler.DeleteCourse)
 
 	// groups
 	groups := admin.Group("/groups")
 	groups.POST("", uniHandler.CreateNewGroup)
+	groups.GET("", hierarchyHandler.GetGroups)
+	groups.GET("/:id", hierarchyHandler.GetGroup)
+	groups.PUT("/:id", hierarchyHandler.UpdateGroup)
+	groups.DELETE("/:id", hierarchyHandler.DeleteGroup)
+
+	// учебный план: предметы курса по семестрам и преподаватели групп,
+	// id назначений используются в занятиях расписания
+	curriculum := admin.Group("/curriculum")
+	curriculum.GET("/semesters", curriculumHandler.GetSemesters)
+	curriculum.GET("/courses/:id/subjects", curriculumHandler.GetCourseSubjects)
+	curriculum.POST("/courses/:id/subjects", curriculumHandler.AddCourseSubject)
+	curriculum.PUT("/course-subjects/:id", curriculumHandler.UpdateCourseSubject)
+	curriculum.DELETE("/course-subjects/:id", curriculumHandler.DeleteCourseSubject)
+	curriculum.GET("/groups/:id", curriculumHandler.GetGroupCurriculum)
+	curriculum.POST("/groups/:id/subjects", curriculumHandler.AssignGroupTeacher)
+	curriculum.PUT("/group-subjects/:id", curriculumHandler.UpdateGroupTeacher)
+	curriculum.DELETE("/group-subjects/:id", curriculumHandler.DeleteGroupTeacher)
+	curriculum.POST("/electives/:id/subjects", curriculumHandler.AssignElectiveTeacher)
+	curriculum.DELETE("/elective-subjects/:id", curriculumHandler.DeleteElectiveTeacher)
+
+	// элективы: группы с квотами и окна выбора на семестр,
+	// first_come записывает сразу, lottery записывает по жеребьёвке после закрытия окна
+	electivesAdmin := admin.Group("/electives")
+	electivesAdmin.GET("/groups", electivesHandler.GetGroups)
+	electivesAdmin.POST("/groups", electivesHandler.CreateGroup)
+	electivesAdmin.PUT("/groups/:id", electivesHandler.UpdateGroup)
+	electivesAdmin.DELETE("/groups/:id", electivesHandler.DeleteGroup)
+	electivesAdmin.GET("/windows", electivesHandler.GetWindows)
+	electivesAdmin.POST("/windows", electivesHandler.CreateWindow)
+	electivesAdmin.PUT("/windows/:id", electivesHandler.UpdateWindow)
+	electivesAdmin.POST("/windows/:id/draw", electivesHandler.DrawLottery)
+
+	// выбор элективов студентом
+	electives := protected.Group("/electives")
+	electives.GET("/windows", electivesHandler.GetStudentWindows)
+	electives.GET("/windows/:id", electivesHandler.GetStudentWindow)
+	electives.PUT("/windows/:id/choices", electivesHandler.SetChoices)
+
+	// приёмная кампания: направления с числом мест, рейтинговые списки и статусы заявлений,
+	// принятое заявление превращается в студента
+	admissionsAdmin := admin.Group("/admissions")
+	admissionsAdmin.GET("/campaigns", admissionsHandler.GetCampaigns)
+	admissionsAdmin.POST("/campaigns", admissionsHandler.CreateCampaign)
+	admissionsAdmin.PUT("/campaigns/:id", admissionsHandler.UpdateCampaign)
+	admissionsAdmin.GET("/campaigns/:id/programs", admissionsHandler.GetPrograms)
+	admissionsAdmin.POST("/campaigns/:id/programs", admissionsHandler.AddProgram)
+	admissionsAdmin.PUT("/programs/:id", admissionsHandler.UpdateProgram)
+	admissionsAdmin.DELETE("/programs/:id", admissionsHandler.DeleteProgram)
+	admissionsAdmin.GET("/programs/:id/applications", admissionsHandler.GetRankedList)
+	admissionsAdmin.PUT("/applications/:id/score", admissionsHandler.SetScore)
+	admissionsAdmin.PUT("/applications/:id/status", admissionsHandler.ChangeStatus)
+	admissionsAdmin.POST("/applications/:id/enroll", admissionsHandler.Enroll)
+
+	// абитуриенты: открытые кампании, подача и отслеживание заявлений
+	admissionsGroup := protected.Group("/admissions")
+	admissionsGroup.GET("/campaigns", admissionsHandler.GetOpenCampaigns)
+	admissionsGroup.GET("/applications", admissionsHandler.GetApplications)
+	admissionsGroup.POST("/applications", admissionsHandler.Apply)
+
+	// переход на новый учебный год: сначала предпросмотр, затем перевод групп на следующий курс,
+	// выпуск последнего курса и архивирование расписаний в одной транзакции
+	rollovers := admin.Group("/rollovers")
+	rollovers.POST("/preview", rolloverHandler.PreviewRollover)
+	rollovers.POST("", rolloverHandler.Rollover)
+
+	// массовый импорт из CSV/XLSX: аудитории, звонки, предметы, группы и списки студентов/преподавателей
+	// тело ограничено до разбора multipart, иначе файл любого размера сначала пишется на диск
+	admin.POST("/imports/:kind", importHandler.Import, middleware.BodyLimit("10M"))
+	// выгрузка для отчётности в CSV/XLSX/JSON: списки групп, преподаватели, расписание по группам или аудиториям,
+	// история заявок на доступ; файл пишется по мере чтения строк из базы
+	admin.GET("/exports/:kind", exportHandler.Export)
+	// печатное расписание группы, преподавателя или аудитории в PDF для досок объявлений
+	admin.GET("/timetables/:kind/:id", schedulesHandler.GetTimetablePDF)
+
+	// брони аудиторий под встречи, экзамены и клубы: преподаватели и студенты подают заявки, администратор
+	// подтверждает или отклоняет; подтверждённая бронь и пары семестра не пересекаются
+	bookings := protected.Group("/bookings")
+	bookings.POST("", bookingsHandler.CreateBooking)
+	bookings.GET("", bookingsHandler.GetMyBookings)
+	bookings.POST("/:id/cancel", bookingsHandler.CancelBooking)
+	bookingsAdmin := admin.Group("/bookings")
+	bookingsAdmin.GET("", bookingsHandler.GetBookings)
+	bookingsAdmin.POST("/:id/confirm", bookingsHandler.ConfirmBooking)
+	bookingsAdmin.POST("/:id/reject", bookingsHandler.RejectBooking)
+
+	// сессии экзаменов и зачётов семестра: у группы не больше одного экзамена в день и не меньше min_gap_days
+	// дней между ними, аудитории и экзаменатор не заняты парами, бронями и другими экзаменами; студенты видят
+	// экзамены своих групп после публикации сессии
+	examSessions := admin.Group("/exam-sessions")
+	examSessions.GET("", examsHandler.GetSessions)
+	examSessions.POST("", examsHandler.CreateSession)
+	examSessions.POST("/:id/publish", examsHandler.PublishSession)
+	examSessions.GET("/:id/exams", examsHandler.GetSessionExams)
+	examSessions.POST("/:id/exams", examsHandler.CreateExam)
+	admin.DELETE("/exams/:id", examsHandler.DeleteExam)
+	protected.GET("/exams", examsHandler.GetMyExams)
 
 	// events
 	events := uni.Group("/events")
@@ -169,6 +326,8 @@ func NewRouter(logger embedlog.Logger,
 	schedules.DELETE("/rooms/{room_id}", schedulesHandler.DeleteRoom)
 	schedules.POST("/rooms", schedulesHandler.CreateRoom)
 	schedules.GET("/rooms", schedulesHandler.GetRoomsByUniversity)
+	// занятость аудиторий по дням и парам недели: пары и брони
+	schedules.GET("/rooms/occupancy", schedulesHandler.GetRoomOccupancy)
 	schedules.POST("/lessons", schedulesHandler.CreateLesson)
 	schedules.DELETE("/lessons/{lesson_id}", schedulesHandler.DeleteLesson)
 	schedules.GET("/users/{user_id}", schedulesHandler.GetUserSchedule)
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/bookings"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/electives"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exams"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/imports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/personalities"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/schedules"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// requestValidator is echo.Validator for `validate` tags of request DTOs.
+// Errors are services.Validation with one detail per invalid field, fields are named by their json tags.
+type requestValidator struct {
+	validate *validator.Validate
+}
+
+func newRequestValidator() *requestValidator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	// enums of the database, see schedules.day_type, schedules.interval_type, users.role_type, subjects.subject_type
+	// and groups.elective_windows.mode, admissions.applications.status
+	_ = v.RegisterValidation("day", func(fl validator.FieldLevel) bool {
+		return schedules.DayType(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("interval", func(fl validator.FieldLevel) bool {
+		return schedules.IntervalType(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("role", func(fl validator.FieldLevel) bool {
+		return personalities.RoleType(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("subject_type", func(fl validator.FieldLevel) bool {
+		return curriculum.SubjectType(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("mode", func(fl validator.FieldLevel) bool {
+		return electives.Mode(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("application_status", func(fl validator.FieldLevel) bool {
+		return admissions.Status(fl.Field().String()).Valid()
+	})
+	// kinds and modes of imported files
+	_ = v.RegisterValidation("import_kind", func(fl validator.FieldLevel) bool {
+		return imports.Kind(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("import_mode", func(fl validator.FieldLevel) bool {
+		return imports.Mode(fl.Field().String()).Valid()
+	})
+	// owners of printed timetables
+	_ = v.RegisterValidation("timetable_kind", func(fl validator.FieldLevel) bool {
+		return schedules.TimetableKind(fl.Field().String()).Valid()
+	})
+	// kinds, formats and timetable orders of exports
+	_ = v.RegisterValidation("export_kind", func(fl validator.FieldLevel) bool {
+		return exports.Kind(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("export_format", func(fl validator.FieldLevel) bool {
+		return exports.Format(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("export_order", func(fl validator.FieldLevel) bool {
+		return exports.Order(fl.Field().String()).Valid()
+	})
+
+	// purposes and statuses of room bookings
+	_ = v.RegisterValidation("booking_purpose", func(fl validator.FieldLevel) bool {
+		return bookings.Purpose(fl.Field().String()).Valid()
+	})
+	_ = v.RegisterValidation("booking_status", func(fl validator.FieldLevel) bool {
+		return bookings.Status(fl.Field().String()).Valid()
+	})
+
+	// kinds of exams of exam sessions
+	_ = v.RegisterValidation("exam_kind", func(fl validator.FieldLevel) bool {
+		return exams.Kind(fl.Field().String()).Valid()
+	})
+
+	return &requestValidator{validate: v}
+}
+
+func (rv *requestValidator) Validate(i any) error {
+	err := rv.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		// not a struct, it is a bug in the handler
+		return err
+	}
+
+	fields := make([]services.FieldError, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		fields = append(fields, services.FieldError{
+			Field:   fieldPath(fe),
+			Code:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+
+	return services.Validation("request validation failed", fields...)
+}
+
+// fieldPath returns the path of the field without the root struct: periods[0].start_date
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, path, ok := strings.Cut(ns, "."); ok {
+		return path
+	}
+	return ns
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required when " + snakeCase(fe.Param()) + " is not set"
+	case "excluded_with":
+		return "must not be set together with " + snakeCase(fe.Param())
+	case "min":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		if fe.Kind() == reflect.Slice {
+			return fmt.Sprintf("must contain at least %s items", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		if fe.Kind() == reflect.Slice {
+			return fmt.Sprintf("must contain at most %s items", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be greater than or equal to " + fe.Param()
+	case "gtfield":
+		return "must be after " + snakeCase(fe.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "url":
+		return "must be a valid URL"
+	case "datetime":
+		return "must be a date in format " + fe.Param()
+	case "day":
+		return "must be one of " + join(schedules.Days)
+	case "interval":
+		return "must be one of " + join(schedules.Intervals)
+	case "role":
+		return "must be one of " + join(personalities.Roles)
+	case "subject_type":
+		return "must be one of " + join(curriculum.SubjectTypes)
+	case "mode":
+		return "must be one of " + join(electives.Modes)
+	case "application_status":
+		return "must be one of " + join(admissions.Statuses)
+	case "import_kind":
+		return "must be one of " + join(imports.Kinds)
+	case "import_mode":
+		return "must be one of " + join(imports.Modes)
+	case "timetable_kind":
+		return "must be one of " + join(schedules.TimetableKinds)
+	case "export_kind":
+		return "must be one of " + join(exports.Kinds)
+	case "export_format":
+		return "must be one of " + join(exports.Formats)
+	case "export_order":
+		return "must be one of " + join(exports.Orders)
+	case "booking_purpose":
+		return "must be one of " + join(bookings.Purposes)
+	case "booking_status":
+		return "must be one of " + join(bookings.Statuses)
+	case "exam_kind":
+		return "must be one of " + join(exams.Kinds)
+	}
+	return "is invalid"
+}
+
+func join[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+// snakeCase converts a struct field name from a tag param to the json name: StartTime -> start_time.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && unicode.IsLower(rune(name[i-1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,103 @@
+// Package logging is the application logger: embedlog.Logger API over slog
+// with redaction of secrets and request scoped attributes carried in context.Context.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/vmkteam/embedlog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger has the same methods as embedlog.Logger, every record goes through RedactingHandler.
+// Records get trace_id and span_id of the span in the context of the call, or in the context
+// the logger was taken from for Printf and Errorf.
+type Logger struct {
+	slog *slog.Logger
+	ctx  context.Context
+}
+
+// New wraps handler of base (dev or prod embedlog logger) with redaction.
+func New(base embedlog.Logger) Logger {
+	return Logger{slog: slog.New(NewRedactingHandler(base.Log().Handler()))}
+}
+
+// Printf logs at [slog.LevelInfo] with [fmt.Sprintf].
+func (l Logger) Printf(format string, v ...any) {
+	l.log(l.context(), slog.LevelInfo, fmt.Sprintf(format, v...))
+}
+
+// Print logs at [slog.LevelInfo] with the given context.
+func (l Logger) Print(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args...)
+}
+
+// Errorf logs at [slog.LevelError] with [fmt.Sprintf].
+func (l Logger) Errorf(format string, v ...any) {
+	l.log(l.context(), slog.LevelError, fmt.Sprintf(format, v...))
+}
+
+// Error logs at [slog.LevelError] with the given context.
+func (l Logger) Error(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args...)
+}
+
+// With returns a Logger that adds args to every record.
+func (l Logger) With(args ...any) Logger {
+	if l.slog == nil {
+		return l
+	}
+	return Logger{slog: l.slog.With(args...), ctx: l.ctx}
+}
+
+// Log returns underlying slog.Logger.
+func (l Logger) Log() *slog.Logger {
+	return l.slog
+}
+
+func (l Logger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if l.slog == nil || !l.slog.Enabled(ctx, level) {
+		return
+	}
+
+	// source of the record is the caller of Print/Errorf
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip [Callers, log, Print]
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	_ = l.slog.Handler().Handle(ctx, r)
+}
+
+type ctxKey struct{}
+
+// WithContext stores logger enriched with request attributes (request_id, route, user_id) in ctx.
+func WithContext(ctx context.Context, l Logger) context.Context {
+	l.ctx = nil
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns logger of the request, or slog.Default() outside of requests.
+// Printf and Errorf of the logger take trace ids from ctx.
+func FromContext(ctx context.Context) Logger {
+	l, ok := ctx.Value(ctxKey{}).(Logger)
+	if !ok {
+		l = Logger{slog: slog.Default()}
+	}
+	l.ctx = ctx
+	return l
+}
+
+func (l Logger) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+	return l.ctx
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFromContextTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	base := Logger{slog: slog.New(slog.NewJSONHandler(&buf, nil))}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	// a middleware stores the request logger, a later one enriches and stores it again
+	ctx := WithContext(context.Background(), base.With("request_id", "r1"))
+	ctx = WithContext(ctx, FromContext(ctx).With("user_id", 7))
+	ctx = trace.ContextWithSpanContext(ctx, sc)
+
+	FromContext(ctx).Errorf("failed: %v", "boom")
+
+	line := strings.TrimSpace(buf.String())
+	var record map[string]any
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("record %q: %v", line, err)
+	}
+	want := map[string]any{
+		"msg":        "failed: boom",
+		"request_id": "r1",
+		"user_id":    float64(7),
+		"trace_id":   sc.TraceID().String(),
+		"span_id":    sc.SpanID().String(),
+	}
+	for k, v := range want {
+		if record[k] != v {
+			t.Errorf("%s = %v, want %v", k, record[k], v)
+		}
+	}
+	if n := strings.Count(line, `"trace_id"`); n != 1 {
+		t.Errorf("trace_id is written %d times: %s", n, line)
+	}
+
+	buf.Reset()
+	base.Printf("outside of requests")
+	if strings.Contains(buf.String(), "trace_id") {
+		t.Errorf("record without span has trace_id: %s", buf.String())
+	}
+}
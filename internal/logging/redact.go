@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// keys of attributes whose values are never logged
+var sensitiveKeys = map[string]struct{}{
+	"token":         {},
+	"access_token":  {},
+	"refresh_token": {},
+	"authorization": {},
+	"cookie":        {},
+	"set-cookie":    {},
+	"hash":          {},
+	"init_data":     {},
+	"password":      {},
+	"secret":        {},
+	"jwt_secret":    {},
+}
+
+var redactRules = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// JWT anywhere in text
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`), redacted},
+	// Authorization: Bearer xxx
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`), "${1}" + redacted},
+	// whole cookie headers
+	{regexp.MustCompile(`(?i)((?:set-)?cookie"?\s*[:=]\s*"?)[^"\n]*`), "${1}" + redacted},
+	// key=value, key: value and "key":"value" of init data, forms and json
+	{regexp.MustCompile(`(?i)("?\b(?:hash|access_token|refresh_token|token|init_data|password|secret)"?\s*[:=]\s*"?)[^"&\s,;}]+`), "${1}" + redacted},
+}
+
+// Redact strips tokens, init data hashes and cookies from s.
+func Redact(s string) string {
+	for _, rule := range redactRules {
+		s = rule.re.ReplaceAllString(s, rule.repl)
+	}
+	return s
+}
+
+// RedactingHandler redacts message and attributes of records before passing them to the next handler.
+type RedactingHandler struct {
+	next slog.Handler
+}
+
+func NewRedactingHandler(next slog.Handler) *RedactingHandler {
+	return &RedactingHandler{next: next}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, nr)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redactedAttrs[i] = redactAttr(a)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(redactedAttrs)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(a.Key)]; ok {
+		return slog.String(a.Key, redacted)
+	}
+
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		attrs := make([]any, len(group))
+		for i, ga := range group {
+			attrs[i] = redactAttr(ga)
+		}
+		return slog.Group(a.Key, attrs...)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok && err != nil {
+			if msg := Redact(err.Error()); msg != err.Error() {
+				return slog.String(a.Key, msg)
+			}
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
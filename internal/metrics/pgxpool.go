@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports pgxpool.Stat of the pool on every scrape.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns      *prometheus.Desc
+	idleConns          *prometheus.Desc
+	totalConns         *prometheus.Desc
+	maxConns           *prometheus.Desc
+	acquires           *prometheus.Desc
+	emptyAcquires      *prometheus.Desc
+	canceledAcquires   *prometheus.Desc
+	acquireDuration    *prometheus.Desc
+	newConns           *prometheus.Desc
+	maxLifetimeDestroy *prometheus.Desc
+	maxIdleDestroy     *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:               pool,
+		acquiredConns:      desc("acquired_conns", "Connections currently acquired from the pool."),
+		idleConns:          desc("idle_conns", "Idle connections in the pool."),
+		totalConns:         desc("total_conns", "All connections in the pool."),
+		maxConns:           desc("max_conns", "Maximum size of the pool."),
+		acquires:           desc("acquires_total", "Successful acquires from the pool."),
+		emptyAcquires:      desc("empty_acquires_total", "Acquires that had to wait for a connection."),
+		canceledAcquires:   desc("canceled_acquires_total", "Acquires canceled by context."),
+		acquireDuration:    desc("acquire_duration_seconds_total", "Time spent in successful acquires."),
+		newConns:           desc("new_conns_total", "Connections opened by the pool."),
+		maxLifetimeDestroy: desc("max_lifetime_destroys_total", "Connections closed because of max lifetime."),
+		maxIdleDestroy:     desc("max_idle_destroys_total", "Connections closed because of max idle time."),
+	}
+}
+
+func (pc *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pc.acquiredConns
+	ch <- pc.idleConns
+	ch <- pc.totalConns
+	ch <- pc.maxConns
+	ch <- pc.acquires
+	ch <- pc.emptyAcquires
+	ch <- pc.canceledAcquires
+	ch <- pc.acquireDuration
+	ch <- pc.newConns
+	ch <- pc.maxLifetimeDestroy
+	ch <- pc.maxIdleDestroy
+}
+
+func (pc *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := pc.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(pc.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(pc.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(pc.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(pc.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(pc.acquires, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.emptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.canceledAcquires, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(pc.newConns, prometheus.CounterValue, float64(s.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(pc.maxLifetimeDestroy, prometheus.CounterValue, float64(s.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(pc.maxIdleDestroy, prometheus.CounterValue, float64(s.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,313 @@
+// Package migrate applies versioned sql migrations embedded into the binary.
+//
+// Migrations are files NNNNNN_name.up.sql / NNNNNN_name.down.sql. Applied versions are kept in
+// public.schema_migrations, every migration runs in its own transaction, and a pg advisory lock
+// makes concurrent runs (several replicas starting at once) wait for each other.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+)
+
+// lockID is a pg advisory lock key, so only one process migrates at a time.
+const lockID = 7_310_039
+
+// baselineVersion is the pg_dump schema. Databases created by the old init script have it
+// without a schema_migrations record, such databases are marked as migrated to it.
+const baselineVersion = 1
+
+var fileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration with the time it was applied, AppliedAt is nil for pending ones.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	logger     logging.Logger
+	migrations []Migration
+}
+
+func New(pool *pgxpool.Pool, fsys fs.FS, dir string, logger logging.Logger) (*Migrator, error) {
+	migrations, err := load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, logger: logger, migrations: migrations}, nil
+}
+
+// load reads migrations from dir sorted by version. Every version must have an up file.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		m := fileRe.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad migration version %s: %w", e.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", e.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, mig.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies all pending migrations and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (applied int, err error) {
+	err = m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(done) == 0 {
+			if err := m.baseline(ctx, conn, done); err != nil {
+				return err
+			}
+		}
+
+		for _, mig := range m.migrations {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+
+			m.logger.Print(ctx, "applying migration", "version", mig.Version, "name", mig.Name)
+			if err := run(ctx, conn, mig.Up, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, `INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts last steps applied migrations and returns how many were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted int, err error) {
+	byVersion := make(map[int64]Migration, len(m.migrations))
+	for _, mig := range m.migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	err = m.locked(ctx, func(conn *pgxpool.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int64, 0, len(done))
+		for v := range done {
+			versions = append(versions, v)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for _, v := range versions {
+			if reverted == steps {
+				break
+			}
+
+			mig, ok := byVersion[v]
+			if !ok {
+				return fmt.Errorf("migration %d is applied but unknown to this build", v)
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", mig.Version, mig.Name)
+			}
+
+			m.logger.Print(ctx, "reverting migration", "version", mig.Version, "name", mig.Name)
+			if err := run(ctx, conn, mig.Down, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, mig.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status returns all known migrations with the time they were applied.
+// It only reads schema_migrations, so it doesn't wait for a running migration.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	var exists bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('public.schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations: %w", err)
+	}
+
+	done := make(map[int64]time.Time)
+	if exists {
+		if done, err = appliedVersions(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		s := Status{Migration: mig}
+		if at, ok := done[mig.Version]; ok {
+			s.AppliedAt = &at
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// locked runs fn on a single connection holding the migrations advisory lock.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to take migrations lock: %w", err)
+	}
+	defer func() {
+		// migrations may change session settings (pg_dump does), the connection goes back to the pool
+		if _, err := conn.Exec(context.Background(), `RESET ALL; SELECT pg_advisory_unlock_all()`); err != nil {
+			m.logger.Errorf("[Migrator] failed to release migrations lock: %v", err)
+			conn.Conn().Close(context.Background())
+		}
+	}()
+
+	const qCreate = `
+		CREATE TABLE IF NOT EXISTS public.schema_migrations (
+			version bigint NOT NULL,
+			name text NOT NULL,
+			applied_at timestamp with time zone DEFAULT now() NOT NULL,
+			CONSTRAINT schema_migrations_pkey PRIMARY KEY (version)
+		)
+	`
+	if _, err := conn.Exec(ctx, qCreate); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// baseline marks the init schema as applied when it was created before schema_migrations existed.
+// Follow-up migrations are idempotent, so they are simply applied again.
+func (m *Migrator) baseline(ctx context.Context, conn *pgxpool.Conn, done map[int64]time.Time) error {
+	var exists bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('users.max_users_data') IS NOT NULL`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check existing schema: %w", err)
+	}
+	if !exists || len(m.migrations) == 0 || m.migrations[0].Version != baselineVersion {
+		return nil
+	}
+
+	var at time.Time
+	const q = `INSERT INTO public.schema_migrations (version, name) VALUES ($1, $2) RETURNING applied_at`
+	if err := conn.QueryRow(ctx, q, baselineVersion, m.migrations[0].Name).Scan(&at); err != nil {
+		return fmt.Errorf("failed to baseline schema: %w", err)
+	}
+	done[baselineVersion] = at
+
+	m.logger.Print(ctx, "existing schema marked as migrated", "version", baselineVersion)
+	return nil
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM public.schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := make(map[int64]time.Time)
+	for rows.Next() {
+		var (
+			version int64
+			at      time.Time
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		done[version] = at
+	}
+	return done, rows.Err()
+}
+
+// run executes migration sql (no arguments, so pgx uses the simple protocol and
+// the file may have many statements) and records it in the same transaction.
+func run(ctx context.Context, conn *pgxpool.Conn, sql string, record func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
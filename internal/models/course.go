@@ -7,4 +7,6 @@ type Course struct {
 	StartDate            time.Time `json:"start_date"`
 	EndDate              time.Time `json:"end_date"`
 	UniversityDepartment int64     `json:"university_department_id"`
+	// YearOfStudy is nil when unknown, such courses are not rolled over to the next academic year
+	YearOfStudy *int `json:"year_of_study,omitempty"`
 }
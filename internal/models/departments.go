@@ -9,3 +9,15 @@ type Departments struct {
 	UniversityID   int64  `json:"university_id"`
 	UniversityName string `json:"university_name"`
 }
+
+// UniversityDepartment is a department of the departments dictionary opened in a faculty of a university.
+type UniversityDepartment struct {
+	ID           int64
+	DepartmentID int64
+	Name         string
+	Code         string
+	AliasName    *string
+	FacultyID    int64
+	UniversityID int64
+	StudyYears   int
+}
@@ -3,5 +3,6 @@ package models
 type Faculties struct {
 	ID             int
 	Name           string
+	UniversityID   int64
 	UniversityName string
 }
@@ -0,0 +1,106 @@
+package admissions
+
+import "time"
+
+type CreateCampaignRequest struct {
+	UniversityID int64     `json:"university_id" validate:"required,gt=0" example:"1"`
+	Name         string    `json:"name" validate:"required,max=125" example:"Bachelor 2026"`
+	StartsAt     time.Time `json:"starts_at" validate:"required" example:"2026-06-20T00:00:00+03:00"`
+	EndsAt       time.Time `json:"ends_at" validate:"required,gtfield=StartsAt" example:"2026-07-25T18:00:00+03:00"`
+}
+
+type UpdateCampaignRequest struct {
+	Name     string    `json:"name" validate:"required,max=125" example:"Bachelor 2026"`
+	StartsAt time.Time `json:"starts_at" validate:"required" example:"2026-06-20T00:00:00+03:00"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt" example:"2026-07-25T18:00:00+03:00"`
+}
+
+type CampaignResponse struct {
+	ID           int64     `json:"id" example:"2"`
+	UniversityID int64     `json:"university_id" example:"1"`
+	Name         string    `json:"name" example:"Bachelor 2026"`
+	StartsAt     time.Time `json:"starts_at" example:"2026-06-20T00:00:00+03:00"`
+	EndsAt       time.Time `json:"ends_at" example:"2026-07-25T18:00:00+03:00"`
+	IsOpen       bool      `json:"is_open"`
+}
+
+// CampaignProgramsResponse is an open campaign with its programs, as browsed by applicants.
+type CampaignProgramsResponse struct {
+	CampaignResponse
+	Programs []ProgramResponse `json:"programs"`
+}
+
+type AddProgramRequest struct {
+	UniversityDepartmentID int64 `json:"university_department_id" validate:"required,gt=0" example:"5"`
+	Seats                  int   `json:"seats" validate:"required,gt=0" example:"25"`
+}
+
+type UpdateProgramRequest struct {
+	Seats int `json:"seats" validate:"required,gt=0" example:"30"`
+}
+
+type ProgramResponse struct {
+	ID                     int64  `json:"id" example:"9"`
+	CampaignID             int64  `json:"campaign_id" example:"2"`
+	UniversityDepartmentID int64  `json:"university_department_id" example:"5"`
+	FacultyName            string `json:"faculty_name" example:"Faculty of Software Engineering"`
+	DepartmentName         string `json:"department_name" example:"Information systems and technologies"`
+	Seats                  int    `json:"seats" example:"25"`
+	Accepted               int    `json:"accepted" example:"12"`
+	Applications           int    `json:"applications" example:"140"`
+}
+
+type ApplyRequest struct {
+	ProgramID int64 `json:"program_id" validate:"required,gt=0" example:"9"`
+}
+
+// SetScoreRequest is the score of the applicant checked by the admin against exam results.
+type SetScoreRequest struct {
+	Score int `json:"score" validate:"gte=0,max=1000" example:"271"`
+}
+
+type ChangeStatusRequest struct {
+	Status  string  `json:"status" validate:"required,application_status" example:"under_review"`
+	Comment *string `json:"comment,omitempty" validate:"omitempty,max=1000" example:"Documents are checked"`
+}
+
+type EnrollRequest struct {
+	CourseGroupID int64 `json:"course_group_id" validate:"required,gt=0" example:"14"`
+}
+
+type EnrollResponse struct {
+	StudentID int64 `json:"student_id" example:"301"`
+}
+
+// RankedApplicationResponse is an application in the ranked list of a program, rank 0 is rejected.
+// Score is null until the admin verifies it, such applications go after scored ones.
+type RankedApplicationResponse struct {
+	ID        int64     `json:"id" example:"77"`
+	Rank      int       `json:"rank" example:"3"`
+	UserID    int64     `json:"user_id" example:"123456789"`
+	FirstName string    `json:"first_name" example:"Ivan"`
+	LastName  string    `json:"last_name" example:"Petrov"`
+	Score     *int      `json:"score" example:"271"`
+	Status    string    `json:"status" example:"under_review"`
+	StudentID *int64    `json:"student_id,omitempty" example:"301"`
+	CreatedAt time.Time `json:"created_at" example:"2026-06-21T10:00:00+03:00"`
+}
+
+type StatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty" example:"submitted"`
+	ToStatus   string    `json:"to_status" example:"under_review"`
+	Comment    string    `json:"comment,omitempty" example:"Documents are checked"`
+	CreatedAt  time.Time `json:"created_at" example:"2026-06-22T12:00:00+03:00"`
+}
+
+// ApplicationResponse is an application of the applicant with its place in the ranked list of the program.
+type ApplicationResponse struct {
+	ID        int64                  `json:"id" example:"77"`
+	Status    string                 `json:"status" example:"waitlisted"`
+	Score     *int                   `json:"score" example:"271"`
+	Rank      int                    `json:"rank" example:"27"`
+	CreatedAt time.Time              `json:"created_at" example:"2026-06-21T10:00:00+03:00"`
+	Campaign  CampaignResponse       `json:"campaign"`
+	Program   ProgramResponse        `json:"program"`
+	History   []StatusChangeResponse `json:"history"`
+}
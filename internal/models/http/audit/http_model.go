@@ -0,0 +1,21 @@
+package audit
+
+import "encoding/json"
+
+type Entry struct {
+	ID             int64           `json:"id"`
+	ActorID        *int64          `json:"actor_id"`
+	ImpersonatorID *int64          `json:"impersonator_id,omitempty"`
+	Action         string          `json:"action" example:"update"`
+	EntityType     string          `json:"entity_type" example:"schedules.rooms"`
+	EntityID       *string         `json:"entity_id"`
+	Before         json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After          json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	RequestID      *string         `json:"request_id"`
+	CreatedAt      string          `json:"created_at" example:"2025-01-01T12:00:00Z"`
+}
+
+type AuditLogResponse struct {
+	Data    []Entry `json:"data"`
+	HasMore bool    `json:"has_more"`
+}
@@ -0,0 +1,50 @@
+package bookings
+
+import "time"
+
+// CreateBookingRequest books a room for a pair of the bell schedule on a date.
+type CreateBookingRequest struct {
+	RoomID  int64   `json:"room_id" validate:"required,gt=0" example:"3"`
+	ClassID int64   `json:"class_id" validate:"required,gt=0" example:"2"`
+	Date    string  `json:"date" validate:"required,datetime=2006-01-02" example:"2026-11-12"`
+	Purpose string  `json:"purpose" validate:"required,booking_purpose" example:"club"`
+	Title   string  `json:"title" validate:"required,max=125" example:"Chess club"`
+	Comment *string `json:"comment,omitempty" validate:"omitempty,max=1000" example:"Need a projector"`
+}
+
+// CreateBookingResponse is the id of the booking with its status: confirmed for admins, pending for others.
+type CreateBookingResponse struct {
+	ID     int64  `json:"id" example:"15"`
+	Status string `json:"status" example:"pending"`
+}
+
+// BookingsRequest filters bookings of a university, from and to are dates, both inclusive.
+type BookingsRequest struct {
+	UniversityID int64   `query:"university_id" validate:"required,gt=0" example:"1"`
+	Status       *string `query:"status" validate:"omitempty,booking_status" example:"pending"`
+	RoomID       *int64  `query:"room_id" validate:"omitempty,gt=0" example:"3"`
+	From         *string `query:"from" validate:"omitempty,datetime=2006-01-02" example:"2026-11-01"`
+	To           *string `query:"to" validate:"omitempty,datetime=2006-01-02" example:"2026-11-30"`
+}
+
+type BookingResponse struct {
+	ID                 int64      `json:"id" example:"15"`
+	UniversityID       int64      `json:"university_id" example:"1"`
+	RoomID             int64      `json:"room_id" example:"3"`
+	Room               string     `json:"room" example:"A-101"`
+	ClassID            int64      `json:"class_id" example:"2"`
+	PairNumber         int        `json:"pair_number" example:"2"`
+	StartTime          string     `json:"start_time" example:"10:40"`
+	EndTime            string     `json:"end_time" example:"12:10"`
+	Date               string     `json:"date" example:"2026-11-12"`
+	Purpose            string     `json:"purpose" example:"club"`
+	Title              string     `json:"title" example:"Chess club"`
+	Comment            *string    `json:"comment,omitempty" example:"Need a projector"`
+	Status             string     `json:"status" example:"pending"`
+	RequestedBy        int64      `json:"requested_by" example:"123456789"`
+	RequesterFirstName string     `json:"requester_first_name" example:"Ivan"`
+	RequesterLastName  *string    `json:"requester_last_name,omitempty" example:"Petrov"`
+	DecidedBy          *int64     `json:"decided_by,omitempty" example:"987654321"`
+	DecidedAt          *time.Time `json:"decided_at,omitempty" example:"2026-11-01T12:00:00+03:00"`
+	CreatedAt          time.Time  `json:"created_at" example:"2026-10-30T09:00:00+03:00"`
+}
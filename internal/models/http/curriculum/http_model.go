@@ -0,0 +1,69 @@
+package curriculum
+
+type SemesterResponse struct {
+	ID           int64  `json:"id" example:"4"`
+	UniversityID int64  `json:"university_id" example:"1"`
+	StartDate    string `json:"start_date" example:"2025-09-01"`
+	EndDate      string `json:"end_date" example:"2026-01-31"`
+}
+
+type AddCourseSubjectRequest struct {
+	SemesterID          int64 `json:"semester_id" validate:"required,gt=0" example:"4"`
+	UniversitySubjectID int64 `json:"university_subject_id" validate:"required,gt=0" example:"17"`
+	IsElective          bool  `json:"is_elective"`
+	RequiredHours       *int  `json:"required_hours,omitempty" validate:"omitempty,gt=0" example:"72"`
+}
+
+type UpdateCourseSubjectRequest struct {
+	RequiredHours *int `json:"required_hours" validate:"omitempty,gt=0" example:"72"`
+}
+
+type CourseSubjectResponse struct {
+	ID                  int64  `json:"id" example:"31"`
+	CourseID            int64  `json:"course_id" example:"7"`
+	SemesterID          int64  `json:"semester_id" example:"4"`
+	SemesterStart       string `json:"semester_start" example:"2025-09-01"`
+	SemesterEnd         string `json:"semester_end" example:"2026-01-31"`
+	UniversitySubjectID int64  `json:"university_subject_id" example:"17"`
+	SubjectName         string `json:"subject_name" example:"Linear algebra"`
+	IsElective          bool   `json:"is_elective"`
+	RequiredHours       *int   `json:"required_hours,omitempty" example:"72"`
+}
+
+type AssignTeacherRequest struct {
+	CourseSubjectID int64  `json:"course_subject_id" validate:"required,gt=0" example:"31"`
+	TeacherUserID   int64  `json:"teacher_user_id" validate:"required,gt=0" example:"123456789"`
+	SubjectType     string `json:"subject_type" validate:"required,subject_type" example:"lecture"`
+}
+
+type UpdateAssignmentRequest struct {
+	TeacherUserID int64  `json:"teacher_user_id" validate:"required,gt=0" example:"123456789"`
+	SubjectType   string `json:"subject_type" validate:"required,subject_type" example:"practice"`
+}
+
+type AssignElectiveTeacherRequest struct {
+	TeacherUserID int64  `json:"teacher_user_id" validate:"required,gt=0" example:"123456789"`
+	SubjectType   string `json:"subject_type" validate:"required,subject_type" example:"seminar"`
+}
+
+type TeacherResponse struct {
+	UserID    int64  `json:"user_id" example:"123456789"`
+	FirstName string `json:"first_name" example:"Ivan"`
+	LastName  string `json:"last_name" example:"Petrov"`
+}
+
+// AssignmentResponse is a teacher of a subject in a course group, its id is course_group_subject_id of lessons.
+type AssignmentResponse struct {
+	ID          int64           `json:"id" example:"55"`
+	SubjectType string          `json:"subject_type" example:"lecture"`
+	Teacher     TeacherResponse `json:"teacher"`
+}
+
+type CurriculumItemResponse struct {
+	Subject    CourseSubjectResponse `json:"subject"`
+	Assignment *AssignmentResponse   `json:"assignment,omitempty"`
+}
+
+type CreatedResponse struct {
+	ID int64 `json:"id" example:"55"`
+}
@@ -0,0 +1,98 @@
+package electives
+
+import "time"
+
+type CreateGroupRequest struct {
+	Name                string `json:"name" validate:"required,max=125" example:"Machine learning, group 1"`
+	SemesterID          int64  `json:"semester_id" validate:"required,gt=0" example:"4"`
+	UniversitySubjectID int64  `json:"university_subject_id" validate:"required,gt=0" example:"17"`
+	Capacity            *int   `json:"capacity,omitempty" validate:"omitempty,gt=0" example:"30"`
+}
+
+// UpdateGroupRequest renames group and sets its capacity, capacity omitted is unlimited.
+type UpdateGroupRequest struct {
+	Name     string `json:"name" validate:"required,max=125" example:"Machine learning, group 1"`
+	Capacity *int   `json:"capacity,omitempty" validate:"omitempty,gt=0" example:"30"`
+}
+
+type GroupResponse struct {
+	ID                  int64  `json:"id" example:"12"`
+	Name                string `json:"name" example:"Machine learning, group 1"`
+	SemesterID          int64  `json:"semester_id" example:"4"`
+	UniversitySubjectID int64  `json:"university_subject_id" example:"17"`
+	SubjectName         string `json:"subject_name" example:"Machine learning"`
+	Capacity            *int   `json:"capacity,omitempty" example:"30"`
+	Enrolled            int    `json:"enrolled" example:"27"`
+	// FreeSeats is omitted for groups without capacity
+	FreeSeats *int `json:"free_seats,omitempty" example:"3"`
+}
+
+type CreateWindowRequest struct {
+	SemesterID int64     `json:"semester_id" validate:"required,gt=0" example:"4"`
+	OpensAt    time.Time `json:"opens_at" validate:"required" example:"2025-08-20T09:00:00+03:00"`
+	ClosesAt   time.Time `json:"closes_at" validate:"required,gtfield=OpensAt" example:"2025-08-27T23:59:00+03:00"`
+	Mode       string    `json:"mode" validate:"required,mode" example:"first_come"`
+	MaxChoices int       `json:"max_choices" validate:"required,gt=0" example:"2"`
+}
+
+type UpdateWindowRequest struct {
+	OpensAt    time.Time `json:"opens_at" validate:"required" example:"2025-08-20T09:00:00+03:00"`
+	ClosesAt   time.Time `json:"closes_at" validate:"required,gtfield=OpensAt" example:"2025-08-27T23:59:00+03:00"`
+	MaxChoices int       `json:"max_choices" validate:"required,gt=0" example:"2"`
+}
+
+type WindowResponse struct {
+	ID           int64      `json:"id" example:"3"`
+	UniversityID int64      `json:"university_id" example:"1"`
+	SemesterID   int64      `json:"semester_id" example:"4"`
+	OpensAt      time.Time  `json:"opens_at" example:"2025-08-20T09:00:00+03:00"`
+	ClosesAt     time.Time  `json:"closes_at" example:"2025-08-27T23:59:00+03:00"`
+	Mode         string     `json:"mode" example:"lottery"`
+	MaxChoices   int        `json:"max_choices" example:"2"`
+	IsOpen       bool       `json:"is_open"`
+	DrawnAt      *time.Time `json:"drawn_at,omitempty" example:"2025-08-28T10:00:00+03:00"`
+	// LotterySeed reproduces the draw, it is set once the lottery is drawn
+	LotterySeed *int64 `json:"lottery_seed,omitempty" example:"8731502846"`
+}
+
+type DrawRequest struct {
+	// Seed of the draw, a random one is used if omitted
+	Seed *int64 `json:"seed,omitempty" example:"8731502846"`
+}
+
+type DrawResponse struct {
+	Window   WindowResponse `json:"window"`
+	Enrolled int            `json:"enrolled" example:"118"`
+}
+
+// ChoicesRequest is the full list of electives the student wants, for lottery windows in order of preference.
+type ChoicesRequest struct {
+	ElectiveGroupIDs []int64 `json:"elective_group_ids" validate:"omitempty,dive,gt=0" example:"12,15"`
+}
+
+// ClashResponse is a lesson of an elective held at the same time as a lesson of the student's group.
+type ClashResponse struct {
+	ElectiveGroupID  int64  `json:"elective_group_id" example:"12"`
+	Day              string `json:"day" example:"monday"`
+	PairNumber       int    `json:"pair_number" example:"3"`
+	ElectiveSubject  string `json:"elective_subject" example:"Machine learning"`
+	ElectiveInterval string `json:"elective_interval" example:"every week"`
+	GroupSubject     string `json:"group_subject" example:"Linear algebra"`
+	GroupInterval    string `json:"group_interval" example:"every two week"`
+}
+
+type ChoicesResponse struct {
+	// Enrolled are groups the student is enrolled in
+	Enrolled []int64 `json:"enrolled" example:"12"`
+	// Preferences are ranked choices of a lottery window waiting for the draw
+	Preferences []int64         `json:"preferences" example:"15,12"`
+	Clashes     []ClashResponse `json:"clashes"`
+}
+
+type StudentWindowResponse struct {
+	Window  WindowResponse  `json:"window"`
+	Groups  []GroupResponse `json:"groups"`
+	Choices ChoicesResponse `json:"choices"`
+	// Clashes are for all groups of the window, to warn before choosing
+	Clashes []ClashResponse `json:"clashes"`
+}
@@ -0,0 +1,72 @@
+package exams
+
+import "time"
+
+// CreateSessionRequest creates an exam session of a semester, starts_on and ends_on are dates, both inclusive.
+// Exams of a course group in the session are at least min_gap_days days apart, 1 by default.
+type CreateSessionRequest struct {
+	SemesterID int64  `json:"semester_id" validate:"required,gt=0" example:"4"`
+	Name       string `json:"name" validate:"required,max=125" example:"Winter session 2026/2027"`
+	StartsOn   string `json:"starts_on" validate:"required,datetime=2006-01-02" example:"2027-01-11"`
+	EndsOn     string `json:"ends_on" validate:"required,datetime=2006-01-02" example:"2027-01-31"`
+	MinGapDays *int   `json:"min_gap_days,omitempty" validate:"omitempty,min=1,max=14" example:"2"`
+}
+
+type SessionsRequest struct {
+	UniversityID int64 `query:"university_id" validate:"required,gt=0" example:"1"`
+}
+
+type SessionResponse struct {
+	ID           int64      `json:"id" example:"3"`
+	UniversityID int64      `json:"university_id" example:"1"`
+	SemesterID   int64      `json:"semester_id" example:"4"`
+	Name         string     `json:"name" example:"Winter session 2026/2027"`
+	StartsOn     string     `json:"starts_on" example:"2027-01-11"`
+	EndsOn       string     `json:"ends_on" example:"2027-01-31"`
+	MinGapDays   int        `json:"min_gap_days" example:"2"`
+	Published    bool       `json:"published" example:"true"`
+	PublishedAt  *time.Time `json:"published_at,omitempty" example:"2026-12-20T12:00:00+03:00"`
+	Exams        int        `json:"exams" example:"42"`
+}
+
+// CreateExamRequest schedules an exam or a test of a course group in a subject of the session's semester,
+// start_time and end_time are times of day.
+type CreateExamRequest struct {
+	CourseSemesterSubjectID int64   `json:"course_semester_subject_id" validate:"required,gt=0" example:"12"`
+	CourseGroupID           int64   `json:"course_group_id" validate:"required,gt=0" example:"5"`
+	ExaminerUserID          int64   `json:"examiner_user_id" validate:"required,gt=0" example:"987654321"`
+	Kind                    string  `json:"kind" validate:"required,exam_kind" example:"exam"`
+	Date                    string  `json:"date" validate:"required,datetime=2006-01-02" example:"2027-01-14"`
+	StartTime               string  `json:"start_time" validate:"required,datetime=15:04" example:"09:00"`
+	EndTime                 string  `json:"end_time" validate:"required,datetime=15:04" example:"12:00"`
+	RoomIDs                 []int64 `json:"room_ids" validate:"required,min=1,max=10,unique,dive,gt=0" example:"3,4"`
+}
+
+type ExamRoom struct {
+	ID   int64  `json:"id" example:"3"`
+	Room string `json:"room" example:"A-101"`
+}
+
+type ExamResponse struct {
+	ID                      int64      `json:"id" example:"21"`
+	SessionID               int64      `json:"session_id" example:"3"`
+	CourseSemesterSubjectID int64      `json:"course_semester_subject_id" example:"12"`
+	Subject                 string     `json:"subject" example:"Mathematical analysis"`
+	CourseGroupID           int64      `json:"course_group_id" example:"5"`
+	Group                   string     `json:"group" example:"CS-101"`
+	ExaminerUserID          int64      `json:"examiner_user_id" example:"987654321"`
+	ExaminerFirstName       string     `json:"examiner_first_name" example:"Anna"`
+	ExaminerLastName        *string    `json:"examiner_last_name,omitempty" example:"Smirnova"`
+	Kind                    string     `json:"kind" example:"exam"`
+	Date                    string     `json:"date" example:"2027-01-14"`
+	Day                     string     `json:"day" example:"thursday"`
+	StartTime               string     `json:"start_time" example:"09:00"`
+	EndTime                 string     `json:"end_time" example:"12:00"`
+	Rooms                   []ExamRoom `json:"rooms"`
+}
+
+// StudentExamResponse is an exam in the timetable of a student with the name of its session.
+type StudentExamResponse struct {
+	ExamResponse
+	Session string `json:"session" example:"Winter session 2026/2027"`
+}
@@ -0,0 +1,12 @@
+package exports
+
+// ExportRequest selects what to export, kind is a path parameter and the rest are query parameters.
+// group_id applies to rosters and timetable, room_id and by apply to timetable.
+type ExportRequest struct {
+	Kind         string `json:"kind" param:"kind" validate:"required,export_kind" example:"rosters"`
+	UniversityID int64  `json:"university_id" query:"university_id" validate:"required,gt=0" example:"1"`
+	Format       string `json:"format" query:"format" validate:"omitempty,export_format" example:"xlsx"`
+	GroupID      *int64 `json:"group_id,omitempty" query:"group_id" validate:"omitempty,gt=0" example:"12"`
+	RoomID       *int64 `json:"room_id,omitempty" query:"room_id" validate:"omitempty,gt=0" example:"3"`
+	By           string `json:"by" query:"by" validate:"omitempty,export_order" example:"room"`
+}
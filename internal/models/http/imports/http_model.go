@@ -0,0 +1,27 @@
+package imports
+
+// ImportRequest is sent as multipart/form-data together with the file, kind is a path parameter.
+type ImportRequest struct {
+	Kind         string `json:"kind" param:"kind" validate:"required,import_kind" example:"rooms"`
+	UniversityID int64  `json:"university_id" form:"university_id" validate:"required,gt=0" example:"1"`
+	Mode         string `json:"mode" form:"mode" validate:"omitempty,import_mode" example:"upsert"`
+}
+
+// RowErrorResponse is a row of the file not imported, row is the line of the file counting the header as 1.
+type RowErrorResponse struct {
+	Row     int    `json:"row" example:"7"`
+	Column  string `json:"column,omitempty" example:"pair_number"`
+	Message string `json:"message" example:"already exists"`
+}
+
+// ImportReport is the result of an import, valid rows are applied and the rest are reported in errors.
+type ImportReport struct {
+	Kind      string             `json:"kind" example:"rooms"`
+	Mode      string             `json:"mode" example:"upsert"`
+	Rows      int                `json:"rows" example:"120"`
+	Created   int                `json:"created" example:"100"`
+	Updated   int                `json:"updated" example:"3"`
+	Unchanged int                `json:"unchanged" example:"15"`
+	Failed    int                `json:"failed" example:"2"`
+	Errors    []RowErrorResponse `json:"errors"`
+}
@@ -3,8 +3,8 @@ package personalities
 import "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/personalities"
 
 type RequestAccessToUniversity struct {
-	UniversityID int64                  `json:"university_id"`
-	UserType     personalities.RoleType `json:"role"`
+	UniversityID int64                  `json:"university_id" validate:"required,gt=0"`
+	UserType     personalities.RoleType `json:"role" validate:"required,role"`
 }
 
 type AccessRequestResponse struct {
@@ -20,10 +20,10 @@ type AccessRequestResponse struct {
 }
 
 type AcceptAccessRequest struct {
-	UserID                 int64                  `json:"user_id" validate:"required"`
-	UserType               personalities.RoleType `json:"role" validate:"required"`
-	UniversityID           *int64                 `json:"university_id,omitempty"`
-	FacultyID              *int64                 `json:"faculty_id,omitempty"`
-	UniversityDepartmentID *int64                 `json:"university_department_id,omitempty"`
-	CourseGroupID          *int64                 `json:"course_group_id,omitempty"`
+	UserID                 int64                  `json:"user_id" validate:"required,gt=0"`
+	UserType               personalities.RoleType `json:"role" validate:"required,role"`
+	UniversityID           *int64                 `json:"university_id,omitempty" validate:"omitempty,gt=0"`
+	FacultyID              *int64                 `json:"faculty_id,omitempty" validate:"omitempty,gt=0"`
+	UniversityDepartmentID *int64                 `json:"university_department_id,omitempty" validate:"omitempty,gt=0"`
+	CourseGroupID          *int64                 `json:"course_group_id,omitempty" validate:"omitempty,gt=0"`
 }
@@ -0,0 +1,55 @@
+package rollover
+
+type GroupRename struct {
+	GroupID int64  `json:"group_id" validate:"required,gt=0" example:"14"`
+	Name    string `json:"name" validate:"required,max=125" example:"IS-21"`
+}
+
+// RolloverRequest is the new academic year of the university, groups of finished courses keep their names
+// unless renamed.
+type RolloverRequest struct {
+	UniversityID int64         `json:"university_id" validate:"required,gt=0" example:"1"`
+	StartDate    string        `json:"start_date" validate:"required,datetime=2006-01-02" example:"2026-09-01"`
+	EndDate      string        `json:"end_date" validate:"required,datetime=2006-01-02" example:"2027-06-30"`
+	Renames      []GroupRename `json:"renames,omitempty" validate:"omitempty,dive"`
+}
+
+type GroupResponse struct {
+	ID       int64  `json:"id" example:"14"`
+	Name     string `json:"name" example:"IS-11"`
+	NewName  string `json:"new_name" example:"IS-21"`
+	Students int    `json:"students" example:"25"`
+}
+
+type CourseResponse struct {
+	ID                     int64           `json:"id" example:"7"`
+	UniversityDepartmentID int64           `json:"university_department_id" example:"5"`
+	DepartmentName         string          `json:"department_name" example:"Information systems and technologies"`
+	YearOfStudy            int             `json:"year_of_study" example:"1"`
+	StudyYears             int             `json:"study_years" example:"4"`
+	Action                 string          `json:"action" example:"promoted"`
+	NewCourseID            int64           `json:"new_course_id,omitempty" example:"31"`
+	NewCourseCreated       bool            `json:"new_course_created"`
+	Groups                 []GroupResponse `json:"groups"`
+}
+
+// SkippedCourseResponse is a finished course without year of study, set it to roll the course over.
+type SkippedCourseResponse struct {
+	CourseID               int64  `json:"course_id" example:"3"`
+	UniversityDepartmentID int64  `json:"university_department_id" example:"5"`
+	DepartmentName         string `json:"department_name" example:"Information systems and technologies"`
+}
+
+// ReportResponse is what a rollover did, or would do for a preview. ID is omitted for previews.
+type ReportResponse struct {
+	ID                int64                   `json:"id,omitempty" example:"2"`
+	DryRun            bool                    `json:"dry_run"`
+	UniversityID      int64                   `json:"university_id" example:"1"`
+	StartDate         string                  `json:"start_date" example:"2026-09-01"`
+	EndDate           string                  `json:"end_date" example:"2027-06-30"`
+	Courses           []CourseResponse        `json:"courses"`
+	Skipped           []SkippedCourseResponse `json:"skipped"`
+	PromotedGroups    int                     `json:"promoted_groups" example:"12"`
+	GraduatedStudents int                     `json:"graduated_students" example:"87"`
+	ArchivedLessons   int                     `json:"archived_lessons" example:"340"`
+}
@@ -5,10 +5,10 @@ import (
 )
 
 type CreateClassRequest struct {
-	UniversityID int64     `json:"university_id"`
-	PairNumber   int       `json:"pair_number"`
-	StartTime    time.Time `json:"start_time"`
-	EndTime      time.Time `json:"end_time"`
+	UniversityID int64     `json:"university_id" validate:"required,gt=0"`
+	PairNumber   int       `json:"pair_number" validate:"required,min=1,max=14"`
+	StartTime    time.Time `json:"start_time" validate:"required"`
+	EndTime      time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
 }
 
 type ClassesResponse struct {
@@ -20,8 +20,8 @@ type ClassesResponse struct {
 }
 
 type CreateRoomRequest struct {
-	UniversityID int64  `json:"university_id"`
-	Room         string `json:"room"`
+	UniversityID int64  `json:"university_id" validate:"required,gt=0"`
+	Room         string `json:"room" validate:"required,max=125"`
 }
 type RoomsResponse struct {
 	ID           int64  `json:"id"`
@@ -30,12 +30,13 @@ type RoomsResponse struct {
 }
 
 type CreateLessonRequest struct {
-	CourseGroupSubjectID   *int64 `json:"course_group_subject_id,omitempty"`
-	ElectiveGroupSubjectID *int64 `json:"elective_group_subject_id,omitempty"`
-	Day                    string `json:"day"`      // schedules.day_type
-	ClassID                int64  `json:"class_id"` // schedules.classes.id
-	RoomID                 int64  `json:"room_id"`  // schedules.rooms.id
-	Interval               string `json:"interval"` // schedules.interval_type
+	// exactly one of course_group_subject_id and elective_group_subject_id
+	CourseGroupSubjectID   *int64 `json:"course_group_subject_id,omitempty" validate:"required_without=ElectiveGroupSubjectID,excluded_with=ElectiveGroupSubjectID,omitempty,gt=0"`
+	ElectiveGroupSubjectID *int64 `json:"elective_group_subject_id,omitempty" validate:"required_without=CourseGroupSubjectID,omitempty,gt=0"`
+	Day                    string `json:"day" validate:"required,day"`           // schedules.day_type
+	ClassID                int64  `json:"class_id" validate:"required,gt=0"`     // schedules.classes.id
+	RoomID                 int64  `json:"room_id" validate:"required,gt=0"`      // schedules.rooms.id
+	Interval               string `json:"interval" validate:"required,interval"` // schedules.interval_type
 }
 
 type LessonsResponse struct {
@@ -62,3 +63,59 @@ type LessonItem struct {
 	TeacherFirstName *string `json:"teacher_first_name,omitempty"`
 	TeacherLastName  *string `json:"teacher_last_name,omitempty"`
 }
+
+// TimetableRequest selects the printed timetable: kind and id are path parameters,
+// id is course_groups.id, teachers.id or rooms.id.
+type TimetableRequest struct {
+	Kind string `param:"kind" validate:"required,timetable_kind" example:"group"`
+	ID   int64  `param:"id" validate:"required,gt=0" example:"12"`
+}
+
+// RoomOccupancyRequest selects the week of date, the current one by default, and optionally a room.
+type RoomOccupancyRequest struct {
+	UniversityID int64   `query:"university_id" validate:"required,gt=0" example:"1"`
+	Date         *string `query:"date" validate:"omitempty,datetime=2006-01-02" example:"2026-11-12"`
+	RoomID       *int64  `query:"room_id" validate:"omitempty,gt=0" example:"3"`
+}
+
+// RoomOccupancyResponse lists rooms with the pairs they are taken in the week from monday to sunday,
+// rooms without slots are free all week.
+type RoomOccupancyResponse struct {
+	WeekStart string          `json:"week_start" example:"2026-11-09"`
+	WeekEnd   string          `json:"week_end" example:"2026-11-15"`
+	Rooms     []RoomOccupancy `json:"rooms"`
+}
+
+type RoomOccupancy struct {
+	RoomID int64          `json:"room_id" example:"3"`
+	Room   string         `json:"room" example:"A-101"`
+	Slots  []OccupiedSlot `json:"slots"`
+}
+
+// OccupiedSlot is a pair on a date with lessons and pending or confirmed bookings of the room.
+// Every two week lessons are listed on every week.
+type OccupiedSlot struct {
+	Date       string             `json:"date" example:"2026-11-12"`
+	Day        string             `json:"day" example:"thursday"`
+	PairNumber int                `json:"pair_number" example:"2"`
+	ClassID    int64              `json:"class_id" example:"2"`
+	StartTime  string             `json:"start_time" example:"10:40"`
+	EndTime    string             `json:"end_time" example:"12:10"`
+	Lessons    []OccupancyLesson  `json:"lessons,omitempty"`
+	Bookings   []OccupancyBooking `json:"bookings,omitempty"`
+}
+
+type OccupancyLesson struct {
+	LessonID    int64   `json:"lesson_id" example:"40"`
+	Interval    string  `json:"interval" example:"every week"`
+	Subject     *string `json:"subject,omitempty" example:"Databases"`
+	SubjectType string  `json:"subject_type" example:"lecture"`
+	Group       *string `json:"group,omitempty" example:"IS-21"`
+}
+
+type OccupancyBooking struct {
+	BookingID int64  `json:"booking_id" example:"15"`
+	Title     string `json:"title" example:"Chess club"`
+	Purpose   string `json:"purpose" example:"club"`
+	Status    string `json:"status" example:"confirmed"`
+}
@@ -1,12 +1,12 @@
 package subjects
 
 type CreateSubjectRequest struct {
-	Name         string `json:"name"`
-	UniversityID int64  `json:"university_id"`
+	Name         string `json:"name" validate:"required,max=125"`
+	UniversityID int64  `json:"university_id" validate:"required,gt=0"`
 }
 
 type GetSubjectsRequest struct {
-	UniversityID int64 `json:"university_id"`
+	UniversityID int64 `json:"university_id" validate:"required,gt=0"`
 }
 
 type SubjectsResponse struct {
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type ImpersonationAuditEntry struct {
+	ID        int64
+	ActorID   int64
+	UserID    int64
+	Method    string
+	Path      string
+	Status    int
+	RequestID string
+	CreatedAt time.Time
+}
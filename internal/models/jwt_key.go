@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type JWTKey struct {
+	KID        string
+	Alg        string
+	PrivateKey []byte
+	PublicKey  []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
@@ -0,0 +1,105 @@
+package admissions
+
+import (
+	"slices"
+	"time"
+)
+
+type Status string
+
+// values of admissions.applications.status
+const (
+	Submitted   Status = "submitted"
+	UnderReview Status = "under_review"
+	Accepted    Status = "accepted"
+	Rejected    Status = "rejected"
+	Waitlisted  Status = "waitlisted"
+)
+
+var Statuses = []Status{Submitted, UnderReview, Accepted, Rejected, Waitlisted}
+
+func (s Status) Valid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+// transitions of the status machine, accepted and rejected are final
+var transitions = map[Status][]Status{
+	Submitted:   {UnderReview},
+	UnderReview: {Accepted, Rejected, Waitlisted},
+	Waitlisted:  {Accepted, Rejected},
+}
+
+// CanBecome reports whether an application in status s may be moved to next.
+func (s Status) CanBecome(next Status) bool {
+	return slices.Contains(transitions[s], next)
+}
+
+// Campaign is an admission campaign of a university, applications are accepted from StartsAt till EndsAt.
+type Campaign struct {
+	ID           int64
+	UniversityID int64
+	Name         string
+	StartsAt     time.Time
+	EndsAt       time.Time
+}
+
+func (c Campaign) IsOpen(now time.Time) bool {
+	return !now.Before(c.StartsAt) && now.Before(c.EndsAt)
+}
+
+// Program is a university department admitting Seats applicants in a campaign.
+type Program struct {
+	ID                     int64
+	CampaignID             int64
+	UniversityDepartmentID int64
+	FacultyName            string
+	DepartmentName         string
+	Seats                  int
+	Accepted               int
+	Applications           int
+}
+
+type Application struct {
+	ID        int64
+	ProgramID int64
+	UserID    int64
+	FirstName string
+	LastName  *string
+	// Score is set by an admin after checking exam results, nil until then
+	Score     *int
+	Status    Status
+	StudentID *int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Rank is the position in the ranked list of the program: by score, unscored last, then by submission time
+	Rank int
+}
+
+// ApplicantApplication is an application with its program and campaign, as seen by the applicant.
+type ApplicantApplication struct {
+	Application
+	Program  Program
+	Campaign Campaign
+	History  []StatusChange
+}
+
+type StatusChange struct {
+	ID            int64
+	ApplicationID int64
+	FromStatus    *Status
+	ToStatus      Status
+	Comment       *string
+	ChangedBy     int64
+	CreatedAt     time.Time
+}
+
+// Notification is a status change not delivered to the applicant yet.
+type Notification struct {
+	ID            int64
+	UserID        int64
+	ApplicationID int64
+	Status        Status
+	Comment       *string
+	University    string
+	Department    string
+}
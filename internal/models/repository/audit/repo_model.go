@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Entry struct {
+	ID             int64
+	ActorID        *int64
+	ImpersonatorID *int64
+	Action         string
+	EntityType     string
+	EntityID       *string
+	UniversityID   *int64
+	Before         json.RawMessage
+	After          json.RawMessage
+	RequestID      *string
+	CreatedAt      time.Time
+}
+
+// Filter for audit log, nil fields are not filtered.
+type Filter struct {
+	UniversityID int64
+	ActorID      *int64
+	Action       *string
+	EntityType   *string
+	EntityID     *string
+	From         *time.Time
+	To           *time.Time
+	Limit        int64
+	Offset       int64
+}
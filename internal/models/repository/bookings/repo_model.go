@@ -0,0 +1,81 @@
+package bookings
+
+import (
+	"slices"
+	"time"
+)
+
+type Status string
+
+// values of schedules.room_bookings.status
+const (
+	Pending   Status = "pending"
+	Confirmed Status = "confirmed"
+	Rejected  Status = "rejected"
+	Cancelled Status = "cancelled"
+)
+
+var Statuses = []Status{Pending, Confirmed, Rejected, Cancelled}
+
+func (s Status) Valid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+// transitions of the status machine, rejected and cancelled are final
+var transitions = map[Status][]Status{
+	Pending:   {Confirmed, Rejected, Cancelled},
+	Confirmed: {Cancelled},
+}
+
+// CanBecome reports whether a booking in status s may be moved to next.
+func (s Status) CanBecome(next Status) bool {
+	return slices.Contains(transitions[s], next)
+}
+
+type Purpose string
+
+// values of schedules.room_bookings.purpose
+const (
+	Meeting Purpose = "meeting"
+	Exam    Purpose = "exam"
+	Club    Purpose = "club"
+	Other   Purpose = "other"
+)
+
+var Purposes = []Purpose{Meeting, Exam, Club, Other}
+
+func (p Purpose) Valid() bool {
+	return slices.Contains(Purposes, p)
+}
+
+// Booking is a reservation of a room for a pair of the bell schedule on a date.
+type Booking struct {
+	ID                 int64
+	UniversityID       int64
+	RoomID             int64
+	Room               string
+	ClassID            int64
+	PairNumber         int
+	StartTime          time.Time
+	EndTime            time.Time
+	Date               time.Time
+	Purpose            Purpose
+	Title              string
+	Comment            *string
+	Status             Status
+	RequestedBy        int64
+	RequesterFirstName string
+	RequesterLastName  *string
+	DecidedBy          *int64
+	DecidedAt          *time.Time
+	CreatedAt          time.Time
+}
+
+// Filter selects bookings of a university, nil fields are not filtered. From and To are dates, both inclusive.
+type Filter struct {
+	UniversityID int64
+	Status       *Status
+	RoomID       *int64
+	From         *time.Time
+	To           *time.Time
+}
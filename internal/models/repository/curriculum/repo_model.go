@@ -0,0 +1,74 @@
+package curriculum
+
+import (
+	"slices"
+	"time"
+)
+
+type SubjectType string
+
+// values of subjects.subject_type
+const (
+	Lecture  SubjectType = "lecture"
+	Practice SubjectType = "practice"
+	Labwork  SubjectType = "labwork"
+	Seminar  SubjectType = "seminar"
+)
+
+var SubjectTypes = []SubjectType{Lecture, Practice, Labwork, Seminar}
+
+func (t SubjectType) Valid() bool {
+	return slices.Contains(SubjectTypes, t)
+}
+
+type Semester struct {
+	ID           int64
+	UniversityID int64
+	StartDate    time.Time
+	EndDate      time.Time
+}
+
+// CourseSubject is a university subject studied by a course in a semester (subjects.course_semester_subjects).
+type CourseSubject struct {
+	ID                  int64
+	CourseID            int64
+	SemesterID          int64
+	UniversitySubjectID int64
+	SubjectName         string
+	IsElective          bool
+	RequiredHours       *int
+	SemesterStart       time.Time
+	SemesterEnd         time.Time
+}
+
+// Teacher is personalities.teachers row with the name of its MAX user.
+type Teacher struct {
+	ID        int64
+	UserID    int64
+	FirstName string
+	LastName  *string
+}
+
+// GroupSubject is a course subject taught to a course group by a teacher (subjects.course_group_subjects).
+type GroupSubject struct {
+	ID              int64
+	CourseGroupID   int64
+	CourseSubjectID int64
+	SubjectType     SubjectType
+	Teacher         Teacher
+}
+
+// CurriculumItem is a course subject of a group's course with its assignment to the group,
+// Assignment is nil if no teacher is assigned yet.
+type CurriculumItem struct {
+	Subject    CourseSubject
+	Assignment *GroupSubject
+}
+
+// ElectiveSubject is a teacher of an elective group (subjects.elective_group_subjects).
+type ElectiveSubject struct {
+	ID              int64
+	ElectiveGroupID int64
+	SubjectType     SubjectType
+	Teacher         Teacher
+}
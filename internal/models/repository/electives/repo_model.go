@@ -0,0 +1,90 @@
+package electives
+
+import (
+	"slices"
+	"time"
+)
+
+type Mode string
+
+// values of groups.elective_windows.mode
+const (
+	FirstCome Mode = "first_come"
+	Lottery   Mode = "lottery"
+)
+
+var Modes = []Mode{FirstCome, Lottery}
+
+func (m Mode) Valid() bool {
+	return slices.Contains(Modes, m)
+}
+
+// Group is an elective group of a semester (groups.elective_groups), Capacity nil is unlimited.
+type Group struct {
+	ID                  int64
+	Name                string
+	SemesterID          int64
+	UniversitySubjectID int64
+	SubjectName         string
+	Capacity            *int
+	Enrolled            int
+}
+
+// HasSeat reports whether one more student fits into the group.
+func (g Group) HasSeat() bool {
+	return g.Capacity == nil || g.Enrolled < *g.Capacity
+}
+
+// Window is a selection window of a semester (groups.elective_windows).
+type Window struct {
+	ID           int64
+	UniversityID int64
+	SemesterID   int64
+	OpensAt      time.Time
+	ClosesAt     time.Time
+	Mode         Mode
+	MaxChoices   int
+	DrawnAt      *time.Time
+	LotterySeed  *int64
+}
+
+func (w Window) IsOpen(now time.Time) bool {
+	return !now.Before(w.OpensAt) && now.Before(w.ClosesAt)
+}
+
+// Student is an active student of the window's university with the course of their group.
+type Student struct {
+	ID       int64
+	CourseID int64
+	GroupID  int64
+}
+
+// Preference is a ranked choice of a student in a lottery window, rank 1 is the most wanted.
+type Preference struct {
+	StudentID       int64
+	ElectiveGroupID int64
+	Rank            int
+}
+
+type Enrollment struct {
+	StudentID       int64
+	ElectiveGroupID int64
+}
+
+// Choices of a student in a window: groups they are enrolled in and, for lottery windows,
+// ranked preferences.
+type Choices struct {
+	Enrolled    []int64
+	Preferences []int64
+}
+
+// Clash is a lesson of an elective group at the same day and pair as a lesson of the student's course group.
+type Clash struct {
+	ElectiveGroupID  int64
+	Day              string
+	PairNumber       int
+	ElectiveSubject  string
+	ElectiveInterval string
+	GroupSubject     string
+	GroupInterval    string
+}
@@ -0,0 +1,60 @@
+package exams
+
+import (
+	"slices"
+	"time"
+)
+
+type Kind string
+
+// values of schedules.exams.kind
+const (
+	KindExam Kind = "exam"
+	KindTest Kind = "test"
+)
+
+var Kinds = []Kind{KindExam, KindTest}
+
+func (k Kind) Valid() bool {
+	return slices.Contains(Kinds, k)
+}
+
+// Session is an exam session of a semester. Exams of a course group are at least MinGapDays days apart,
+// students see them once the session is published.
+type Session struct {
+	ID           int64
+	UniversityID int64
+	SemesterID   int64
+	Name         string
+	StartsOn     time.Time
+	EndsOn       time.Time
+	MinGapDays   int
+	PublishedAt  *time.Time
+	Exams        int
+}
+
+// Exam is an exam or a test of a course group in a subject of the semester. StartTime and EndTime
+// are times of day as 15:04 when the exam is created.
+type Exam struct {
+	ID                      int64
+	SessionID               int64
+	CourseSemesterSubjectID int64
+	Subject                 string
+	CourseGroupID           int64
+	Group                   string
+	ExaminerUserID          int64
+	ExaminerFirstName       string
+	ExaminerLastName        *string
+	Kind                    Kind
+	Date                    time.Time
+	StartTime               time.Time
+	EndTime                 time.Time
+	RoomIDs                 []int64
+	Rooms                   []string
+}
+
+// StudentExam is an exam of a published session in the timetable of a student.
+type StudentExam struct {
+	Exam
+	Session string
+}
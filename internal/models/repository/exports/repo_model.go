@@ -0,0 +1,110 @@
+package exports
+
+import (
+	"slices"
+	"time"
+)
+
+// Kind is what is exported.
+type Kind string
+
+const (
+	Rosters        Kind = "rosters"
+	Teachers       Kind = "teachers"
+	Timetable      Kind = "timetable"
+	AccessRequests Kind = "access_requests"
+)
+
+var Kinds = []Kind{Rosters, Teachers, Timetable, AccessRequests}
+
+func (k Kind) Valid() bool {
+	return slices.Contains(Kinds, k)
+}
+
+// Format is the file format of an export.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+	JSON Format = "json"
+)
+
+var Formats = []Format{CSV, XLSX, JSON}
+
+func (f Format) Valid() bool {
+	return slices.Contains(Formats, f)
+}
+
+// Order is how the timetable is grouped.
+type Order string
+
+const (
+	ByGroup Order = "group"
+	ByRoom  Order = "room"
+)
+
+var Orders = []Order{ByGroup, ByRoom}
+
+func (o Order) Valid() bool {
+	return slices.Contains(Orders, o)
+}
+
+// Filter narrows an export of the university, GroupID and RoomID are used where they apply.
+type Filter struct {
+	UniversityID int64
+	GroupID      *int64
+	RoomID       *int64
+	Order        Order
+}
+
+// Student is an active student of a live course group.
+type Student struct {
+	UserID      int64
+	Username    *string
+	FirstName   string
+	LastName    *string
+	GroupID     int64
+	Group       string
+	Department  string
+	YearOfStudy *int
+	StartDate   time.Time
+	EndDate     time.Time
+}
+
+type Teacher struct {
+	UserID    int64
+	Username  *string
+	FirstName string
+	LastName  *string
+}
+
+// Lesson is a lesson of a course group or of an elective group.
+type Lesson struct {
+	Group       string
+	Elective    bool
+	Room        string
+	Day         string
+	PairNumber  int
+	StartTime   time.Time
+	EndTime     time.Time
+	Interval    string
+	Subject     *string
+	SubjectType string
+	Teacher     *string
+}
+
+// AccessRequest is a request to join the university, restored from the audit log.
+// Status is the current one: accepted if the user has the role, pending if the request
+// is not answered, rejected otherwise.
+type AccessRequest struct {
+	UserID      int64
+	Username    *string
+	FirstName   string
+	LastName    *string
+	Role        string
+	RequestedAt time.Time
+	Status      string
+	RejectedAt  *time.Time
+	RejectedBy  *int64
+}
@@ -0,0 +1,124 @@
+package imports
+
+import (
+	"slices"
+	"time"
+)
+
+// Kind is what a file imports.
+type Kind string
+
+const (
+	Rooms    Kind = "rooms"
+	Classes  Kind = "classes"
+	Subjects Kind = "subjects"
+	Groups   Kind = "groups"
+	Rosters  Kind = "rosters"
+)
+
+var Kinds = []Kind{Rooms, Classes, Subjects, Groups, Rosters}
+
+func (k Kind) Valid() bool {
+	return slices.Contains(Kinds, k)
+}
+
+// Mode tells what to do with rows whose natural key already exists.
+type Mode string
+
+const (
+	// Create reports existing rows as errors
+	Create Mode = "create"
+	// Upsert updates existing rows, so the same file may be imported again
+	Upsert Mode = "upsert"
+)
+
+var Modes = []Mode{Create, Upsert}
+
+func (m Mode) Valid() bool {
+	return slices.Contains(Modes, m)
+}
+
+type Outcome int
+
+const (
+	Created Outcome = iota
+	Updated
+	Unchanged
+)
+
+// Room is keyed by name in the university.
+type Room struct {
+	Row  int
+	Name string
+}
+
+// Class is a pair of the bell schedule keyed by its number in the university, times are HH:MM.
+type Class struct {
+	Row        int
+	PairNumber int
+	StartTime  string
+	EndTime    string
+}
+
+// Subject is keyed by name in the university.
+type Subject struct {
+	Row  int
+	Name string
+}
+
+// Group is keyed by name in the course found by department, dates and year of study, the course is
+// created if missing. Department is a code or alias name of a university department.
+type Group struct {
+	Row         int
+	Department  string
+	StartDate   time.Time
+	EndDate     time.Time
+	YearOfStudy *int
+	Name        string
+}
+
+type Role string
+
+const (
+	Student Role = "student"
+	Teacher Role = "teacher"
+)
+
+// RosterEntry makes a MAX user a student of a group or a teacher of the university. The user is found
+// by UserID or Username, unknown users given by id are created with FirstName and LastName.
+// Students are keyed by user and group, teachers by user.
+type RosterEntry struct {
+	Row       int
+	UserID    int64
+	Username  string
+	FirstName string
+	LastName  string
+	Role      Role
+	// GroupID or Group, the name of a group of a current course of the university
+	GroupID int64
+	Group   string
+}
+
+// Failure is a row not applied: its cells are invalid, it references missing data or violates a constraint.
+type Failure struct {
+	Row int
+	Err error
+}
+
+type Result struct {
+	Created   int
+	Updated   int
+	Unchanged int
+	Failures  []Failure
+}
+
+func (r *Result) Add(outcome Outcome) {
+	switch outcome {
+	case Created:
+		r.Created++
+	case Updated:
+		r.Updated++
+	case Unchanged:
+		r.Unchanged++
+	}
+}
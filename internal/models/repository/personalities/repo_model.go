@@ -1,5 +1,7 @@
 package personalities
 
+import "slices"
+
 type RoleType string
 
 const (
@@ -8,6 +10,13 @@ const (
 	Admin   RoleType = "administration"
 )
 
+// Roles are values of users.role_type
+var Roles = []RoleType{Admin, Student, Teacher}
+
+func (r RoleType) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
 type UniversityAccess struct {
 	UserID       int64
 	UserType     RoleType
@@ -0,0 +1,68 @@
+package rollover
+
+import "time"
+
+// Params of a rollover of a university to the academic year StartDate..EndDate.
+type Params struct {
+	UniversityID int64
+	StartDate    time.Time
+	EndDate      time.Time
+	// Renames are new names of promoted groups by group id, other groups keep their names
+	Renames map[int64]string
+}
+
+type Action string
+
+const (
+	// Promoted groups are moved to the course of the next year of study
+	Promoted Action = "promoted"
+	// Graduated groups stay in the final year course, their students are graduated
+	Graduated Action = "graduated"
+)
+
+type Group struct {
+	ID       int64
+	Name     string
+	NewName  string
+	Students int
+}
+
+// Course is a course finished before the new academic year and what the rollover did with it.
+type Course struct {
+	ID                     int64
+	UniversityDepartmentID int64
+	DepartmentName         string
+	YearOfStudy            int
+	StudyYears             int
+	Action                 Action
+	// NewCourseID is the course groups are promoted to, 0 for graduated courses
+	NewCourseID int64
+	// NewCourseCreated is false when the next year course already existed
+	NewCourseCreated bool
+	Groups           []Group
+}
+
+func (c Course) IsFinalYear() bool {
+	return c.YearOfStudy >= c.StudyYears
+}
+
+// Skipped is a finished course without year of study, the rollover does not know what to do with it.
+type Skipped struct {
+	CourseID               int64
+	UniversityDepartmentID int64
+	DepartmentName         string
+}
+
+type Report struct {
+	// ID is 0 for dry runs, nothing is saved
+	ID                int64
+	DryRun            bool
+	UniversityID      int64
+	StartDate         time.Time
+	EndDate           time.Time
+	Courses           []Course
+	Skipped           []Skipped
+	PromotedGroups    int
+	GraduatedStudents int
+	ArchivedLessons   int
+}
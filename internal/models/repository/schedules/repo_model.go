@@ -1,6 +1,9 @@
 package schedules
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Class struct {
 	ID           int64
@@ -19,6 +22,36 @@ type Room struct {
 type DayType string
 type IntervalType string
 
+// values of schedules.day_type
+const (
+	Monday    DayType = "monday"
+	Tuesday   DayType = "tuesday"
+	Wednesday DayType = "wednesday"
+	Thursday  DayType = "thursday"
+	Friday    DayType = "friday"
+	Saturday  DayType = "saturday"
+	Sunday    DayType = "sunday"
+)
+
+// values of schedules.interval_type
+const (
+	EveryWeek    IntervalType = "every week"
+	EveryTwoWeek IntervalType = "every two week"
+)
+
+var (
+	Days      = []DayType{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
+	Intervals = []IntervalType{EveryWeek, EveryTwoWeek}
+)
+
+func (d DayType) Valid() bool {
+	return slices.Contains(Days, d)
+}
+
+func (i IntervalType) Valid() bool {
+	return slices.Contains(Intervals, i)
+}
+
 type CreateLesson struct {
 	CourseGroupSubjectID   *int64
 	ElectiveGroupSubjectID *int64
@@ -43,3 +76,73 @@ type UserScheduleItem struct {
 	TeacherFirstName *string   `json:"teacher_first_name,omitempty"`
 	TeacherLastName  *string   `json:"teacher_last_name,omitempty"`
 }
+
+// TimetableKind is whose timetable is printed.
+type TimetableKind string
+
+const (
+	GroupTimetable   TimetableKind = "group"
+	TeacherTimetable TimetableKind = "teacher"
+	RoomTimetable    TimetableKind = "room"
+)
+
+var TimetableKinds = []TimetableKind{GroupTimetable, TeacherTimetable, RoomTimetable}
+
+func (k TimetableKind) Valid() bool {
+	return slices.Contains(TimetableKinds, k)
+}
+
+// Branding is the university printed in the header of a timetable.
+type Branding struct {
+	Name      string
+	ShortName *string
+	City      *string
+	SiteURL   *string
+}
+
+// Timetable is the week grid of a course group, a teacher or a room. Owner is the group, teacher or room name,
+// Department and YearOfStudy are set for groups.
+type Timetable struct {
+	Kind        TimetableKind
+	University  Branding
+	Owner       string
+	Department  *string
+	YearOfStudy *int
+	Classes     []Class
+	Lessons     []TimetableLesson
+}
+
+// TimetableLesson is a lesson in the grid. Week is 0 for every week lessons, 1 for odd and 2 for even weeks:
+// up to two every two week lessons of a group share a pair, the first created one goes on odd weeks.
+type TimetableLesson struct {
+	Day         DayType
+	PairNumber  int
+	Week        int
+	Subject     *string
+	SubjectType string
+	Teacher     *string
+	Room        string
+	Group       string
+}
+
+// Occupancy is a lesson or a pending or confirmed booking taking a room for a pair on a date of the week.
+// Lesson fields are set for lessons and booking fields for bookings, the others are empty.
+type Occupancy struct {
+	RoomID     int64
+	Date       time.Time
+	PairNumber int
+	ClassID    int64
+	StartTime  time.Time
+	EndTime    time.Time
+
+	LessonID    *int64
+	Interval    string
+	Subject     *string
+	SubjectType string
+	Group       *string
+
+	BookingID *int64
+	Title     string
+	Purpose   string
+	Status    string
+}
@@ -0,0 +1,650 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+)
+
+var (
+	ErrCampaignClosed     = errors.New("admission campaign is not open")
+	ErrNoSeats            = errors.New("all seats of the program are taken")
+	ErrSeatsBelowAccepted = errors.New("seats are less than the number of accepted applications")
+	ErrNotAccepted        = errors.New("application is not accepted")
+	ErrAlreadyEnrolled    = errors.New("applicant is already enrolled by the application")
+	ErrScoreNotVerified   = errors.New("score of the application is not verified")
+	ErrScoreFixed         = errors.New("score of an accepted application can't change")
+)
+
+// TransitionError is returned when the status machine of applications does not allow the change.
+type TransitionError struct {
+	From admissions.Status
+	To   admissions.Status
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("application can't change status from %s to %s", e.From, e.To)
+}
+
+const qCampaigns = `
+	SELECT c.id, c.university_id, c.name, c.starts_at, c.ends_at
+	FROM admissions.campaigns AS c
+`
+
+const qPrograms = `
+	SELECT p.id, p.campaign_id, p.university_department_id, f.name, COALESCE(ud.alias_name, d.name), p.seats,
+	       count(a.id) FILTER (WHERE a.status = 'accepted'), count(a.id)
+	FROM admissions.programs AS p
+	JOIN universities.university_departments AS ud ON p.university_department_id = ud.id
+	JOIN universities.departments AS d ON ud.department_id = d.id
+	JOIN universities.faculties AS f ON ud.faculty_id = f.id
+	LEFT JOIN admissions.applications AS a ON a.program_id = p.id
+`
+
+const qProgramsGroup = `
+	GROUP BY p.id, f.name, ud.alias_name, d.name
+	ORDER BY f.name, COALESCE(ud.alias_name, d.name)
+`
+
+// qRanked numbers applications of a program by verified score, then by submission time. Applications
+// without a verified score go after scored ones, rejected applications are out of the list and have rank 0.
+const qRanked = `
+	SELECT a.id, a.program_id, a.max_user_id, u.first_name, u.last_name, a.score, a.status, a.student_id,
+	       a.created_at, a.updated_at,
+	       CASE WHEN a.status <> 'rejected'
+	            THEN row_number() OVER (PARTITION BY a.program_id, a.status = 'rejected' ORDER BY a.score DESC NULLS LAST, a.created_at, a.id)
+	            ELSE 0
+	       END AS rank
+	FROM admissions.applications AS a
+	JOIN users.max_users_data AS u ON a.max_user_id = u.id
+`
+
+// programAdminOf is adminOf for the university of the campaign of program p.
+var programAdminOf = fmt.Sprintf(adminOf, "(SELECT c.university_id FROM admissions.campaigns c WHERE c.id = p.campaign_id)")
+
+type admissionsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdmissionsRepository(pool *pgxpool.Pool) AdmissionsRepository {
+	return &admissionsRepository{pool: pool}
+}
+
+// CreateCampaign creates campaign of a university the admin administers.
+func (r *admissionsRepository) CreateCampaign(ctx context.Context, adminID int64, c admissions.Campaign) (int64, error) {
+	q := fmt.Sprintf(`
+		INSERT INTO admissions.campaigns (university_id, name, starts_at, ends_at)
+		SELECT $1::bigint, $3::varchar, $4::timestamptz, $5::timestamptz
+		WHERE %s
+		RETURNING id
+	`, fmt.Sprintf(adminOf, "$1"))
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, q, c.UniversityID, adminID, c.Name, c.StartsAt, c.EndsAt).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		return err
+	})
+	return id, err
+}
+
+func (r *admissionsRepository) GetCampaigns(ctx context.Context, adminID int64) ([]admissions.Campaign, error) {
+	q := qCampaigns + `
+		WHERE c.university_id IN (
+			SELECT pa.university_id FROM personalities.administrations pa WHERE pa.max_user_id = $1
+		)
+		ORDER BY c.starts_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, q, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaigns: %w", err)
+	}
+	return scanCampaigns(rows)
+}
+
+func (r *admissionsRepository) UpdateCampaign(ctx context.Context, adminID int64, c admissions.Campaign) error {
+	q := fmt.Sprintf(`
+		UPDATE admissions.campaigns AS c
+		SET name = $3, starts_at = $4, ends_at = $5
+		WHERE c.id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "c.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, c.ID, adminID, c.Name, c.StartsAt, c.EndsAt)
+	})
+}
+
+// AddProgram adds a live university department of the campaign's university to the campaign.
+func (r *admissionsRepository) AddProgram(ctx context.Context, adminID int64, p admissions.Program) (int64, error) {
+	qCampaign := fmt.Sprintf(`
+		SELECT EXISTS (SELECT 1 FROM universities.university_departments ud
+		               WHERE ud.id = $3 AND ud.university_id = c.university_id AND ud.deleted_at IS NULL)
+		FROM admissions.campaigns AS c
+		WHERE c.id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "c.university_id"))
+	const qInsert = `
+		INSERT INTO admissions.programs (campaign_id, university_department_id, seats)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var departmentOK bool
+		err := tx.QueryRow(ctx, qCampaign, p.CampaignID, adminID, p.UniversityDepartmentID).Scan(&departmentOK)
+		if err != nil {
+			return err
+		}
+		if !departmentOK {
+			return ErrReferenceNotFound
+		}
+
+		return tx.QueryRow(ctx, qInsert, p.CampaignID, p.UniversityDepartmentID, p.Seats).Scan(&id)
+	})
+	return id, err
+}
+
+// GetPrograms returns programs of a campaign of a university the admin administers with numbers
+// of accepted and all applications.
+func (r *admissionsRepository) GetPrograms(ctx context.Context, adminID, campaignID int64) ([]admissions.Program, error) {
+	q := qPrograms + `WHERE p.campaign_id = $1 AND ` + programAdminOf + qProgramsGroup
+
+	rows, err := r.pool.Query(ctx, q, campaignID, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programs: %w", err)
+	}
+	return scanPrograms(rows)
+}
+
+// GetOpenPrograms returns programs of a campaign accepting applications now, as seen by applicants.
+func (r *admissionsRepository) GetOpenPrograms(ctx context.Context, campaignID int64) ([]admissions.Program, error) {
+	q := qPrograms + `
+		JOIN admissions.campaigns AS c ON p.campaign_id = c.id
+		WHERE p.campaign_id = $1
+		  AND now() >= c.starts_at AND now() < c.ends_at
+		  AND ud.deleted_at IS NULL
+	` + qProgramsGroup
+
+	rows, err := r.pool.Query(ctx, q, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programs: %w", err)
+	}
+	return scanPrograms(rows)
+}
+
+func scanPrograms(rows pgx.Rows) ([]admissions.Program, error) {
+	defer rows.Close()
+
+	var programs []admissions.Program
+	for rows.Next() {
+		p, err := scanProgram(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program row: %w", err)
+		}
+		programs = append(programs, *p)
+	}
+	return programs, rows.Err()
+}
+
+// UpdateProgramSeats changes seats of the program, seats can't be less than accepted applications.
+func (r *admissionsRepository) UpdateProgramSeats(ctx context.Context, adminID, id int64, seats int) error {
+	qLock := fmt.Sprintf(`
+		SELECT (SELECT count(*) FROM admissions.applications a WHERE a.program_id = p.id AND a.status = 'accepted')
+		FROM admissions.programs AS p
+		WHERE p.id = $1
+		  AND %s
+		FOR UPDATE OF p
+	`, programAdminOf)
+	const qUpdate = `UPDATE admissions.programs SET seats = $2 WHERE id = $1`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var accepted int
+		if err := tx.QueryRow(ctx, qLock, id, adminID).Scan(&accepted); err != nil {
+			return err
+		}
+		if seats < accepted {
+			return ErrSeatsBelowAccepted
+		}
+
+		_, err := tx.Exec(ctx, qUpdate, id, seats)
+		return err
+	})
+}
+
+// DeleteProgram deletes program, it fails with foreign key violation while it has applications.
+func (r *admissionsRepository) DeleteProgram(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM admissions.programs AS p
+		WHERE p.id = $1
+		  AND %s
+	`, programAdminOf)
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// GetRankedList returns applications of the program in the order of the ranked list, rejected ones last.
+func (r *admissionsRepository) GetRankedList(ctx context.Context, adminID, programID int64) ([]admissions.Application, error) {
+	q := fmt.Sprintf(`
+		SELECT r.*
+		FROM (%s WHERE a.program_id = $1) AS r
+		JOIN admissions.programs AS p ON r.program_id = p.id
+		WHERE %s
+		ORDER BY r.rank = 0, r.rank
+	`, qRanked, programAdminOf)
+
+	rows, err := r.pool.Query(ctx, q, programID, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ranked list: %w", err)
+	}
+	defer rows.Close()
+
+	var applications []admissions.Application
+	for rows.Next() {
+		var a admissions.Application
+		if err := scanApplication(rows, &a); err != nil {
+			return nil, fmt.Errorf("failed to scan application row: %w", err)
+		}
+		applications = append(applications, a)
+	}
+	return applications, rows.Err()
+}
+
+// ChangeStatus moves application along the status machine and records the change to be notified.
+// An application is accepted only with a verified score and while the program has free seats.
+func (r *admissionsRepository) ChangeStatus(ctx context.Context, adminID, applicationID int64, status admissions.Status, comment *string) error {
+	qLock := fmt.Sprintf(`
+		SELECT a.status, a.score IS NOT NULL, p.id, p.seats
+		FROM admissions.applications AS a
+		JOIN admissions.programs AS p ON a.program_id = p.id
+		WHERE a.id = $1
+		  AND %s
+		FOR UPDATE OF a, p
+	`, programAdminOf)
+	const (
+		qAccepted = `SELECT count(*) FROM admissions.applications WHERE program_id = $1 AND status = 'accepted'`
+		qUpdate   = `UPDATE admissions.applications SET status = $2, updated_at = now() WHERE id = $1`
+		qChange   = `
+			INSERT INTO admissions.status_changes (application_id, from_status, to_status, comment, changed_by)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+	)
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			current   admissions.Status
+			scored    bool
+			programID int64
+			seats     int
+		)
+		if err := tx.QueryRow(ctx, qLock, applicationID, adminID).Scan(&current, &scored, &programID, &seats); err != nil {
+			return err
+		}
+		if !current.CanBecome(status) {
+			return &TransitionError{From: current, To: status}
+		}
+
+		// the program row is locked, so concurrent accepts of the program wait for this one
+		if status == admissions.Accepted {
+			if !scored {
+				return ErrScoreNotVerified
+			}
+			var accepted int
+			if err := tx.QueryRow(ctx, qAccepted, programID).Scan(&accepted); err != nil {
+				return fmt.Errorf("failed to count accepted applications: %w", err)
+			}
+			if accepted >= seats {
+				return ErrNoSeats
+			}
+		}
+
+		if _, err := tx.Exec(ctx, qUpdate, applicationID, status); err != nil {
+			return fmt.Errorf("failed to update application: %w", err)
+		}
+		if _, err := tx.Exec(ctx, qChange, applicationID, current, status, comment, adminID); err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+		return nil
+	})
+}
+
+// SetScore records the score of the application checked by the admin, the application takes its
+// place in the ranked list by it. The score of an accepted application is fixed.
+func (r *admissionsRepository) SetScore(ctx context.Context, adminID, applicationID int64, score int) error {
+	qLock := fmt.Sprintf(`
+		SELECT a.status
+		FROM admissions.applications AS a
+		JOIN admissions.programs AS p ON a.program_id = p.id
+		WHERE a.id = $1
+		  AND %s
+		FOR UPDATE OF a
+	`, programAdminOf)
+	const qUpdate = `
+		UPDATE admissions.applications
+		SET score = $3, score_verified_by = $2, score_verified_at = now(), updated_at = now()
+		WHERE id = $1
+	`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var status admissions.Status
+		if err := tx.QueryRow(ctx, qLock, applicationID, adminID).Scan(&status); err != nil {
+			return err
+		}
+		if status == admissions.Accepted {
+			return ErrScoreFixed
+		}
+
+		_, err := tx.Exec(ctx, qUpdate, applicationID, adminID, score)
+		return err
+	})
+}
+
+// Enroll creates student of the program's university department in a course group of the department
+// from an accepted application. It returns id of the student.
+func (r *admissionsRepository) Enroll(ctx context.Context, adminID, applicationID, courseGroupID int64) (int64, error) {
+	qLock := fmt.Sprintf(`
+		SELECT a.status, a.student_id, a.max_user_id, p.university_department_id
+		FROM admissions.applications AS a
+		JOIN admissions.programs AS p ON a.program_id = p.id
+		WHERE a.id = $1
+		  AND %s
+		FOR UPDATE OF a
+	`, programAdminOf)
+	const (
+		qGroup = `
+			SELECT EXISTS (
+				SELECT 1
+				FROM groups.course_groups AS cg
+				JOIN universities.courses AS c ON cg.course_id = c.id
+				WHERE cg.id = $1 AND c.university_department_id = $2
+				  AND cg.deleted_at IS NULL AND c.deleted_at IS NULL
+			)
+		`
+		qStudent = `
+			INSERT INTO personalities.students (max_user_id, university_department_id, course_group_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (max_user_id, course_group_id) DO UPDATE SET is_graduated = false
+			RETURNING id
+		`
+		qLink = `UPDATE admissions.applications SET student_id = $2, updated_at = now() WHERE id = $1`
+	)
+
+	var studentID int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			status       admissions.Status
+			enrolledAs   *int64
+			userID       int64
+			departmentID int64
+		)
+		if err := tx.QueryRow(ctx, qLock, applicationID, adminID).Scan(&status, &enrolledAs, &userID, &departmentID); err != nil {
+			return err
+		}
+		if status != admissions.Accepted {
+			return ErrNotAccepted
+		}
+		if enrolledAs != nil {
+			return ErrAlreadyEnrolled
+		}
+
+		var groupOK bool
+		if err := tx.QueryRow(ctx, qGroup, courseGroupID, departmentID).Scan(&groupOK); err != nil {
+			return fmt.Errorf("failed to check course group: %w", err)
+		}
+		if !groupOK {
+			return ErrReferenceNotFound
+		}
+
+		if err := tx.QueryRow(ctx, qStudent, userID, departmentID, courseGroupID).Scan(&studentID); err != nil {
+			return fmt.Errorf("failed to create student: %w", err)
+		}
+		if _, err := tx.Exec(ctx, qLink, applicationID, studentID); err != nil {
+			return fmt.Errorf("failed to link student: %w", err)
+		}
+
+		// roles changed -> tokens issued before must be re-issued
+		return bumpTokenVersion(ctx, tx, userID)
+	})
+	return studentID, err
+}
+
+// GetOpenCampaigns returns campaigns accepting applications now, of universityID only if it is not 0.
+func (r *admissionsRepository) GetOpenCampaigns(ctx context.Context, universityID int64) ([]admissions.Campaign, error) {
+	q := qCampaigns + `
+		JOIN universities.universities_data AS uud ON c.university_id = uud.id
+		WHERE now() >= c.starts_at AND now() < c.ends_at
+		  AND uud.deleted_at IS NULL
+		  AND ($1 = 0 OR c.university_id = $1)
+		ORDER BY c.ends_at
+	`
+
+	rows, err := r.pool.Query(ctx, q, universityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaigns: %w", err)
+	}
+	return scanCampaigns(rows)
+}
+
+// Apply submits application of the user to a program of an open campaign.
+// The score is unknown until an admin checks it, see SetScore.
+func (r *admissionsRepository) Apply(ctx context.Context, userID, programID int64) (int64, error) {
+	const (
+		qCampaign = `
+			SELECT c.id, c.university_id, c.name, c.starts_at, c.ends_at
+			FROM admissions.programs AS p
+			JOIN admissions.campaigns AS c ON p.campaign_id = c.id
+			JOIN universities.university_departments AS ud ON p.university_department_id = ud.id
+			WHERE p.id = $1 AND ud.deleted_at IS NULL
+			FOR SHARE OF c
+		`
+		qInsert = `
+			INSERT INTO admissions.applications (program_id, max_user_id)
+			VALUES ($1, $2)
+			RETURNING id
+		`
+		// submission is not a change of status, the applicant is not notified about it
+		qChange = `
+			INSERT INTO admissions.status_changes (application_id, to_status, changed_by, notified_at)
+			VALUES ($1, 'submitted', $2, now())
+		`
+	)
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		campaign, err := scanCampaign(tx.QueryRow(ctx, qCampaign, programID))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !campaign.IsOpen(time.Now()) {
+			return ErrCampaignClosed
+		}
+
+		if err := tx.QueryRow(ctx, qInsert, programID, userID).Scan(&id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, qChange, id, userID); err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+		return nil
+	})
+	return id, err
+}
+
+// GetApplicantApplications returns applications of the user with their rank, program, campaign and history.
+func (r *admissionsRepository) GetApplicantApplications(ctx context.Context, userID int64) ([]admissions.ApplicantApplication, error) {
+	q := fmt.Sprintf(`
+		SELECT r.*, c.id, c.university_id, c.name, c.starts_at, c.ends_at,
+		       p.id, p.campaign_id, p.university_department_id, f.name, COALESCE(ud.alias_name, d.name), p.seats
+		FROM (%s WHERE a.program_id IN (SELECT program_id FROM admissions.applications WHERE max_user_id = $1)) AS r
+		JOIN admissions.programs AS p ON r.program_id = p.id
+		JOIN admissions.campaigns AS c ON p.campaign_id = c.id
+		JOIN universities.university_departments AS ud ON p.university_department_id = ud.id
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		JOIN universities.faculties AS f ON ud.faculty_id = f.id
+		WHERE r.max_user_id = $1
+		ORDER BY r.created_at DESC
+	`, qRanked)
+	const qHistory = `
+		SELECT sc.id, sc.application_id, sc.from_status, sc.to_status, sc.comment, sc.changed_by, sc.created_at
+		FROM admissions.status_changes AS sc
+		JOIN admissions.applications AS a ON sc.application_id = a.id
+		WHERE a.max_user_id = $1
+		ORDER BY sc.id
+	`
+
+	rows, err := r.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applications: %w", err)
+	}
+	defer rows.Close()
+
+	var applications []admissions.ApplicantApplication
+	byID := make(map[int64]int)
+	for rows.Next() {
+		var (
+			a admissions.ApplicantApplication
+			c = &a.Campaign
+			p = &a.Program
+		)
+		err := rows.Scan(&a.ID, &a.ProgramID, &a.UserID, &a.FirstName, &a.LastName, &a.Score, &a.Status, &a.StudentID,
+			&a.CreatedAt, &a.UpdatedAt, &a.Rank,
+			&c.ID, &c.UniversityID, &c.Name, &c.StartsAt, &c.EndsAt,
+			&p.ID, &p.CampaignID, &p.UniversityDepartmentID, &p.FacultyName, &p.DepartmentName, &p.Seats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan application row: %w", err)
+		}
+		byID[a.ID] = len(applications)
+		applications = append(applications, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	rows, err = r.pool.Query(ctx, qHistory, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sc admissions.StatusChange
+		if err := rows.Scan(&sc.ID, &sc.ApplicationID, &sc.FromStatus, &sc.ToStatus, &sc.Comment, &sc.ChangedBy, &sc.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan status change row: %w", err)
+		}
+		if i, ok := byID[sc.ApplicationID]; ok {
+			applications[i].History = append(applications[i].History, sc)
+		}
+	}
+	return applications, rows.Err()
+}
+
+// ClaimNotifications takes up to limit undelivered status changes with less than maxAttempts attempts
+// for notifyFor, other replicas skip them until then. An attempt is counted when a change is claimed.
+func (r *admissionsRepository) ClaimNotifications(ctx context.Context, limit, maxAttempts int, notifyFor time.Duration) ([]admissions.Notification, error) {
+	const q = `
+		WITH claimed AS (
+			UPDATE admissions.status_changes
+			SET notify_attempts = notify_attempts + 1, claimed_until = now() + $3::interval
+			WHERE id IN (
+				SELECT id
+				FROM admissions.status_changes
+				WHERE notified_at IS NULL
+				  AND notify_attempts < $2
+				  AND (claimed_until IS NULL OR claimed_until < now())
+				ORDER BY id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, application_id, to_status, comment
+		)
+		SELECT sc.id, a.max_user_id, a.id, sc.to_status, sc.comment, uud.name, COALESCE(ud.alias_name, d.name)
+		FROM claimed AS sc
+		JOIN admissions.applications AS a ON sc.application_id = a.id
+		JOIN admissions.programs AS p ON a.program_id = p.id
+		JOIN universities.university_departments AS ud ON p.university_department_id = ud.id
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		JOIN universities.universities_data AS uud ON ud.university_id = uud.id
+		ORDER BY sc.id
+	`
+
+	rows, err := r.pool.Query(ctx, q, limit, maxAttempts, notifyFor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []admissions.Notification
+	for rows.Next() {
+		var n admissions.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.ApplicationID, &n.Status, &n.Comment, &n.University, &n.Department); err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkNotified records delivery of the claimed status change, or releases it for the next attempt
+// if delivered is false.
+func (r *admissionsRepository) MarkNotified(ctx context.Context, id int64, delivered bool) error {
+	q := `UPDATE admissions.status_changes SET claimed_until = NULL WHERE id = $1`
+	if delivered {
+		q = `UPDATE admissions.status_changes SET notified_at = now(), claimed_until = NULL WHERE id = $1`
+	}
+
+	if _, err := r.pool.Exec(ctx, q, id); err != nil {
+		return fmt.Errorf("failed to mark notification: %w", err)
+	}
+	return nil
+}
+
+func scanCampaign(row pgx.Row) (*admissions.Campaign, error) {
+	var c admissions.Campaign
+	if err := row.Scan(&c.ID, &c.UniversityID, &c.Name, &c.StartsAt, &c.EndsAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func scanCampaigns(rows pgx.Rows) ([]admissions.Campaign, error) {
+	defer rows.Close()
+
+	var campaigns []admissions.Campaign
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan campaign row: %w", err)
+		}
+		campaigns = append(campaigns, *c)
+	}
+	return campaigns, rows.Err()
+}
+
+func scanProgram(row pgx.Row) (*admissions.Program, error) {
+	var p admissions.Program
+	err := row.Scan(&p.ID, &p.CampaignID, &p.UniversityDepartmentID, &p.FacultyName, &p.DepartmentName, &p.Seats, &p.Accepted, &p.Applications)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanApplication(row pgx.Row, a *admissions.Application) error {
+	return row.Scan(&a.ID, &a.ProgramID, &a.UserID, &a.FirstName, &a.LastName, &a.Score, &a.Status, &a.StudentID,
+		&a.CreatedAt, &a.UpdatedAt, &a.Rank)
+}
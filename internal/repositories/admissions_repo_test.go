@@ -0,0 +1,202 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+)
+
+type admissionsFixture struct {
+	adminID    int64
+	userIDs    []int64
+	campaignID int64
+	programID  int64
+}
+
+// newAdmissionsFixture commits a university with its admin, an open campaign with one program and two
+// applicants, the repository runs its own transactions. Rows are removed when the test ends.
+func newAdmissionsFixture(t *testing.T, pool *pgxpool.Pool) admissionsFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	base := time.Now().UnixNano()
+	f := admissionsFixture{adminID: base, userIDs: []int64{base + 1, base + 2}}
+	var cityID, uniID, facultyID, departmentID, udID, administrationID int64
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, id := range append([]int64{f.adminID}, f.userIDs...) {
+		testUser(t, tx, id)
+	}
+
+	steps := []struct {
+		q    string
+		args []any
+		dest *int64
+	}{
+		{`INSERT INTO universities.cities (name) VALUES ('Test') RETURNING id`, nil, &cityID},
+		{`INSERT INTO universities.universities_data (name, city_id) VALUES ('Test', $1) RETURNING id`, []any{&cityID}, &uniID},
+		{`INSERT INTO universities.faculties (university_id, name) VALUES ($1, 'Test') RETURNING id`, []any{&uniID}, &facultyID},
+		{`INSERT INTO universities.departments (name, code) VALUES ('Test', 'T') RETURNING id`, nil, &departmentID},
+		{`INSERT INTO universities.university_departments (university_id, faculty_id, department_id) VALUES ($1, $2, $3) RETURNING id`,
+			[]any{&uniID, &facultyID, &departmentID}, &udID},
+		{`INSERT INTO personalities.administrations (max_user_id, university_id) VALUES ($1, $2) RETURNING id`,
+			[]any{&f.adminID, &uniID}, &administrationID},
+		{`INSERT INTO admissions.campaigns (university_id, name, starts_at, ends_at)
+		  VALUES ($1, 'Test', now() - interval '1 day', now() + interval '1 day') RETURNING id`, []any{&uniID}, &f.campaignID},
+		{`INSERT INTO admissions.programs (campaign_id, university_department_id, seats) VALUES ($1, $2, 1) RETURNING id`,
+			[]any{&f.campaignID, &udID}, &f.programID},
+	}
+	for _, s := range steps {
+		args := make([]any, len(s.args))
+		for i, a := range s.args {
+			args[i] = *a.(*int64)
+		}
+		if err := tx.QueryRow(ctx, s.q, args...).Scan(s.dest); err != nil {
+			t.Fatalf("failed to insert fixture %q: %v", s.q, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cleanup := []struct {
+			q  string
+			id int64
+		}{
+			{`DELETE FROM admissions.applications WHERE program_id = $1`, f.programID},
+			{`DELETE FROM admissions.programs WHERE id = $1`, f.programID},
+			{`DELETE FROM admissions.campaigns WHERE id = $1`, f.campaignID},
+			{`DELETE FROM personalities.administrations WHERE id = $1`, administrationID},
+			{`DELETE FROM universities.university_departments WHERE id = $1`, udID},
+			{`DELETE FROM universities.departments WHERE id = $1`, departmentID},
+			{`DELETE FROM universities.faculties WHERE id = $1`, facultyID},
+			{`DELETE FROM universities.universities_data WHERE id = $1`, uniID},
+			{`DELETE FROM universities.cities WHERE id = $1`, cityID},
+		}
+		for _, c := range cleanup {
+			if _, err := pool.Exec(context.Background(), c.q, c.id); err != nil {
+				t.Errorf("failed to clean up %q: %v", c.q, err)
+			}
+		}
+		for _, id := range append([]int64{f.adminID}, f.userIDs...) {
+			if _, err := pool.Exec(context.Background(), `DELETE FROM users.max_users_data WHERE id = $1`, id); err != nil {
+				t.Errorf("failed to clean up user %d: %v", id, err)
+			}
+		}
+	})
+	return f
+}
+
+func TestSetScoreRanking(t *testing.T) {
+	pool := testPool(t)
+	f := newAdmissionsFixture(t, pool)
+	repo := NewAdmissionsRepository(pool)
+	ctx := context.Background()
+
+	// the first applicant applies earlier, but only the second one has a verified score
+	var ids []int64
+	for _, userID := range f.userIDs {
+		id, err := repo.Apply(ctx, userID, f.programID)
+		if err != nil {
+			t.Fatalf("Apply(%d) = %v", userID, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := repo.SetScore(ctx, f.adminID, ids[1], 100); err != nil {
+		t.Fatalf("SetScore = %v", err)
+	}
+
+	ranked, err := repo.GetRankedList(ctx, f.adminID, f.programID)
+	if err != nil {
+		t.Fatalf("GetRankedList = %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].ID != ids[1] || ranked[1].ID != ids[0] {
+		t.Fatalf("ranked list %v, want the scored application first", ranked)
+	}
+	if ranked[1].Score != nil || ranked[1].Rank != 2 {
+		t.Fatalf("unscored application has score %v and rank %d, want nil and 2", ranked[1].Score, ranked[1].Rank)
+	}
+
+	if err := repo.ChangeStatus(ctx, f.adminID, ids[0], admissions.UnderReview, nil); err != nil {
+		t.Fatalf("ChangeStatus(under_review) = %v", err)
+	}
+	if err := repo.ChangeStatus(ctx, f.adminID, ids[0], admissions.Accepted, nil); !errors.Is(err, ErrScoreNotVerified) {
+		t.Fatalf("ChangeStatus(accepted) without score = %v, want ErrScoreNotVerified", err)
+	}
+
+	if err := repo.SetScore(ctx, f.userIDs[0], ids[0], 1000); err == nil {
+		t.Fatal("SetScore by an applicant succeeded")
+	}
+	programs, err := repo.GetPrograms(ctx, f.userIDs[0], f.campaignID)
+	if err != nil {
+		t.Fatalf("GetPrograms = %v", err)
+	}
+	if len(programs) != 0 {
+		t.Fatalf("GetPrograms returned %d programs to a non-admin", len(programs))
+	}
+}
+
+func TestClaimNotifications(t *testing.T) {
+	pool := testPool(t)
+	f := newAdmissionsFixture(t, pool)
+	repo := NewAdmissionsRepository(pool)
+	ctx := context.Background()
+
+	id, err := repo.Apply(ctx, f.userIDs[0], f.programID)
+	if err != nil {
+		t.Fatalf("Apply = %v", err)
+	}
+	if err := repo.ChangeStatus(ctx, f.adminID, id, admissions.UnderReview, nil); err != nil {
+		t.Fatalf("ChangeStatus = %v", err)
+	}
+
+	// other tests may leave pending changes, only the change of this application counts
+	claim := func() []admissions.Notification {
+		t.Helper()
+		claimed, err := repo.ClaimNotifications(ctx, 1000, 5, time.Minute)
+		if err != nil {
+			t.Fatalf("ClaimNotifications = %v", err)
+		}
+		var own []admissions.Notification
+		for _, n := range claimed {
+			if n.ApplicationID == id {
+				own = append(own, n)
+			}
+		}
+		return own
+	}
+
+	first := claim()
+	if len(first) != 1 || first[0].Status != admissions.UnderReview {
+		t.Fatalf("first claim %v, want the under_review change", first)
+	}
+	if again := claim(); len(again) != 0 {
+		t.Fatalf("claimed change was claimed again: %v", again)
+	}
+
+	if err := repo.MarkNotified(ctx, first[0].ID, false); err != nil {
+		t.Fatalf("MarkNotified(failed) = %v", err)
+	}
+	retry := claim()
+	if len(retry) != 1 {
+		t.Fatalf("failed change is not retried: %v", retry)
+	}
+
+	if err := repo.MarkNotified(ctx, retry[0].ID, true); err != nil {
+		t.Fatalf("MarkNotified(delivered) = %v", err)
+	}
+	if delivered := claim(); len(delivered) != 0 {
+		t.Fatalf("delivered change was claimed: %v", delivered)
+	}
+}
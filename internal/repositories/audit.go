@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/reqctx"
+)
+
+// beginAudited starts a write transaction and passes the actor of ctx to audit.log_change() trigger,
+// which writes every change of administrative tables to audit.audit_log.
+// All writes to audited tables must go through it.
+func beginAudited(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := setAuditActor(ctx, tx); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func setAuditActor(ctx context.Context, tx pgx.Tx) error {
+	actor, ok := reqctx.ActorFrom(ctx)
+	if !ok {
+		return nil
+	}
+
+	const q = `
+		SELECT set_config('audit.actor_id', $1, true),
+		       set_config('audit.impersonator_id', $2, true),
+		       set_config('audit.request_id', $3, true)
+	`
+
+	var impersonatorID string
+	if actor.ImpersonatorID != 0 {
+		impersonatorID = strconv.FormatInt(actor.ImpersonatorID, 10)
+	}
+
+	_, err := tx.Exec(ctx, q, strconv.FormatInt(actor.UserID, 10), impersonatorID, actor.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to set audit actor: %w", err)
+	}
+
+	return nil
+}
+
+// inAuditedTx runs fn in a transaction started by beginAudited and commits it if fn succeeds.
+func inAuditedTx(ctx context.Context, pool *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := beginAudited(ctx, pool)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// after Commit the transaction is closed, a canceled request rolls back with the connection
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) && ctx.Err() == nil {
+			logging.FromContext(ctx).Errorf("[inAuditedTx] failed to roll back: %v", err)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
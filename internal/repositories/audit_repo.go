@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/audit"
+)
+
+type auditRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepository(pool *pgxpool.Pool) AuditRepository {
+	return &auditRepository{pool: pool}
+}
+
+func (r *auditRepository) GetAuditLog(ctx context.Context, filter audit.Filter) ([]audit.Entry, error) {
+	const q = `
+		SELECT id, actor_id, impersonator_id, action, entity_type, entity_id, university_id, before, after, request_id, created_at
+		FROM audit.audit_log
+		WHERE university_id = $1
+		  AND ($2::bigint IS NULL OR actor_id = $2)
+		  AND ($3::text IS NULL OR action = $3)
+		  AND ($4::text IS NULL OR entity_type = $4)
+		  AND ($5::text IS NULL OR entity_id = $5)
+		  AND ($6::timestamptz IS NULL OR created_at >= $6)
+		  AND ($7::timestamptz IS NULL OR created_at < $7)
+		ORDER BY id DESC
+		LIMIT $8 OFFSET $9
+	`
+
+	rows, err := r.pool.Query(ctx, q,
+		filter.UniversityID,
+		filter.ActorID,
+		filter.Action,
+		filter.EntityType,
+		filter.EntityID,
+		filter.From,
+		filter.To,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed GetAuditLog from db. err: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []audit.Entry
+	for rows.Next() {
+		var e audit.Entry
+		if err := rows.Scan(
+			&e.ID,
+			&e.ActorID,
+			&e.ImpersonatorID,
+			&e.Action,
+			&e.EntityType,
+			&e.EntityID,
+			&e.UniversityID,
+			&e.Before,
+			&e.After,
+			&e.RequestID,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed GetAuditLog from db in scan. err: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed GetAuditLog during iteration. err: %w", err)
+	}
+
+	return entries, nil
+}
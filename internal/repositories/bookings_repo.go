@@ -0,0 +1,323 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/bookings"
+)
+
+var (
+	ErrNotMember   = errors.New("user is not an admin, a teacher or an active student of the university")
+	ErrBookingPast = errors.New("booking date has passed")
+)
+
+// BookingTransitionError is returned when the status machine of bookings does not allow the change.
+type BookingTransitionError struct {
+	From bookings.Status
+	To   bookings.Status
+}
+
+func (e *BookingTransitionError) Error() string {
+	return fmt.Sprintf("booking can't change status from %s to %s", e.From, e.To)
+}
+
+// dayOf is the schedules.day_type of a date expression, the enum is ordered from monday as ISO days are.
+const dayOf = `(enum_range(NULL::schedules.day_type))[extract(isodow FROM %s)::int]`
+
+const qBookings = `
+	SELECT b.id, b.university_id, b.room_id, r.room, b.class_id, c.pair_number, c.start_time, c.end_time,
+	       b.date, b.purpose, b.title, b.comment, b.status, b.requested_by, u.first_name, u.last_name,
+	       b.decided_by, b.decided_at, b.created_at
+	FROM schedules.room_bookings AS b
+	JOIN schedules.rooms AS r ON b.room_id = r.id
+	JOIN schedules.classes AS c ON b.class_id = c.id
+	JOIN users.max_users_data AS u ON b.requested_by = u.id
+`
+
+// qUniversityMember is true for a teacher or an active student $1 of the university $2.
+const qUniversityMember = `
+	EXISTS (SELECT 1 FROM personalities.teachers AS t WHERE t.max_user_id = $1 AND t.university_id = $2)
+	OR EXISTS (
+	    SELECT 1
+	    FROM personalities.students AS ps
+	    JOIN universities.university_departments AS ud ON ps.university_department_id = ud.id
+	    WHERE ps.max_user_id = $1
+	      AND ud.university_id = $2
+	      AND NOT ps.is_graduated
+	)
+`
+
+type bookingsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewBookingsRepository(pool *pgxpool.Pool) BookingsRepository {
+	return &bookingsRepository{pool: pool}
+}
+
+// CreateBooking books a room of the user's university for a pair on a date. Bookings of admins are confirmed
+// at once, others wait for a decision. The slot must be free of lessons, exams and confirmed bookings either way,
+// so members don't request what can't be confirmed.
+func (r *bookingsRepository) CreateBooking(ctx context.Context, userID int64, b bookings.Booking) (int64, bookings.Status, error) {
+	// the room lock orders bookings, lessons and exams of the room, so two of them can't pass the checks together
+	qRoom := fmt.Sprintf(`
+		SELECT r.university_id, %s
+		FROM schedules.rooms AS r
+		JOIN schedules.classes AS c ON c.id = $3 AND c.university_id = r.university_id
+		WHERE r.id = $1
+		FOR NO KEY UPDATE OF r
+	`, fmt.Sprintf(adminOf, "r.university_id"))
+	const (
+		qMember = `SELECT ` + qUniversityMember
+		qInsert = `
+			INSERT INTO schedules.room_bookings (
+				university_id, room_id, class_id, date, purpose, title, comment, status, requested_by, decided_by, decided_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,
+			        CASE WHEN $8 = 'confirmed' THEN $9::bigint END,
+			        CASE WHEN $8 = 'confirmed' THEN now() END)
+			RETURNING id
+		`
+	)
+
+	var (
+		id     int64
+		status = bookings.Pending
+	)
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var isAdmin bool
+		err := tx.QueryRow(ctx, qRoom, b.RoomID, userID, b.ClassID).Scan(&b.UniversityID, &isAdmin)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if isAdmin {
+			status = bookings.Confirmed
+		} else {
+			var isMember bool
+			if err := tx.QueryRow(ctx, qMember, userID, b.UniversityID).Scan(&isMember); err != nil {
+				return fmt.Errorf("failed to check membership: %w", err)
+			}
+			if !isMember {
+				return ErrNotMember
+			}
+		}
+
+		if err := checkBookingConflicts(ctx, tx, b.RoomID, b.ClassID, b.Date, 0); err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, qInsert, b.UniversityID, b.RoomID, b.ClassID, b.Date, b.Purpose, b.Title, b.Comment,
+			status, userID).Scan(&id)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return id, status, nil
+}
+
+// GetUserBookings returns bookings requested by the user, the latest dates first.
+func (r *bookingsRepository) GetUserBookings(ctx context.Context, userID int64) ([]bookings.Booking, error) {
+	q := qBookings + `
+		WHERE b.requested_by = $1
+		ORDER BY b.date DESC, c.pair_number, b.id
+	`
+
+	rows, err := r.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanBookings(rows)
+}
+
+// GetBookings returns bookings of a live university administrated by adminID ordered by date and pair.
+func (r *bookingsRepository) GetBookings(ctx context.Context, adminID int64, filter bookings.Filter) ([]bookings.Booking, error) {
+	qAdmin := fmt.Sprintf(`
+		SELECT u.id
+		FROM universities.universities_data AS u
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "u.id"))
+	q := qBookings + `
+		WHERE b.university_id = $1
+		  AND ($2::text IS NULL OR b.status = $2)
+		  AND ($3::bigint IS NULL OR b.room_id = $3)
+		  AND ($4::date IS NULL OR b.date >= $4)
+		  AND ($5::date IS NULL OR b.date <= $5)
+		ORDER BY b.date, c.pair_number, r.room, b.id
+	`
+
+	var universityID int64
+	if err := r.pool.QueryRow(ctx, qAdmin, filter.UniversityID, adminID).Scan(&universityID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, q, filter.UniversityID, filter.Status, filter.RoomID, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	return scanBookings(rows)
+}
+
+// Decide confirms or rejects a pending booking of a university administrated by adminID.
+// A booking is confirmed only while its slot is free.
+func (r *bookingsRepository) Decide(ctx context.Context, adminID, id int64, status bookings.Status) error {
+	qLock := fmt.Sprintf(`
+		SELECT b.status, b.room_id, b.class_id, b.date, b.date < current_date
+		FROM schedules.room_bookings AS b
+		WHERE b.id = $1
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "b.university_id"))
+	const (
+		qLockRoom = `SELECT 1 FROM schedules.rooms WHERE id = $1 FOR NO KEY UPDATE`
+		qUpdate   = `UPDATE schedules.room_bookings SET status = $2, decided_by = $3, decided_at = now() WHERE id = $1`
+	)
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			current         bookings.Status
+			roomID, classID int64
+			date            time.Time
+			past            bool
+		)
+		if err := tx.QueryRow(ctx, qLock, id, adminID).Scan(&current, &roomID, &classID, &date, &past); err != nil {
+			return err
+		}
+		if !current.CanBecome(status) {
+			return &BookingTransitionError{From: current, To: status}
+		}
+
+		if status == bookings.Confirmed {
+			if past {
+				return ErrBookingPast
+			}
+			if _, err := tx.Exec(ctx, qLockRoom, roomID); err != nil {
+				return fmt.Errorf("failed to lock room: %w", err)
+			}
+			if err := checkBookingConflicts(ctx, tx, roomID, classID, date, id); err != nil {
+				return err
+			}
+		}
+
+		return execOne(ctx, tx, qUpdate, id, status, adminID)
+	})
+}
+
+// Cancel cancels a pending or confirmed booking requested by the user or of a university administrated by them.
+func (r *bookingsRepository) Cancel(ctx context.Context, userID, id int64) error {
+	qLock := fmt.Sprintf(`
+		SELECT b.status
+		FROM schedules.room_bookings AS b
+		WHERE b.id = $1
+		  AND (b.requested_by = $2 OR %s)
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "b.university_id"))
+	const qUpdate = `UPDATE schedules.room_bookings SET status = 'cancelled', decided_by = $2, decided_at = now() WHERE id = $1`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var current bookings.Status
+		if err := tx.QueryRow(ctx, qLock, id, userID).Scan(&current); err != nil {
+			return err
+		}
+		if !current.CanBecome(bookings.Cancelled) {
+			return &BookingTransitionError{From: current, To: bookings.Cancelled}
+		}
+		return execOne(ctx, tx, qUpdate, id, userID)
+	})
+}
+
+// checkBookingConflicts returns ErrScheduleConflict when a lesson of a not deleted group in a semester covering the date,
+// a confirmed booking other than exceptID or an exam overlapping the pair takes the room. The week parity of every two week
+// lessons is not stored, so they take the room every week. The room must be locked by the caller.
+func checkBookingConflicts(ctx context.Context, tx pgx.Tx, roomID, classID int64, date time.Time, exceptID int64) error {
+	qLessons := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.groups_schedules AS gs
+			LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+			LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+			LEFT JOIN groups.course_groups AS cg ON cg.id = cgs.course_group_id
+			LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+			LEFT JOIN groups.elective_groups AS eg ON egs.elective_group_id = eg.id
+			JOIN universities.semesters AS s ON s.id = COALESCE(css.semester_id, eg.semester_id)
+			WHERE gs.room_id = $1
+			  AND gs.class_id = $2
+			  AND gs.day = %s
+			  AND $3::date BETWEEN s.start_date::date AND s.end_date::date
+			  AND cg.deleted_at IS NULL
+		)
+	`, fmt.Sprintf(dayOf, "$3::date"))
+	const qBooked = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.room_bookings
+			WHERE room_id = $1
+			  AND class_id = $2
+			  AND date = $3
+			  AND status = 'confirmed'
+			  AND id <> $4
+		)
+	`
+	const qExams = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.exam_rooms AS er
+			JOIN schedules.exams AS e ON er.exam_id = e.id
+			JOIN schedules.classes AS c ON c.id = $2
+			WHERE er.room_id = $1
+			  AND e.date = $3
+			  AND e.start_time < c.end_time
+			  AND c.start_time < e.end_time
+		)
+	`
+
+	var busy bool
+	if err := tx.QueryRow(ctx, qLessons, roomID, classID, date).Scan(&busy); err != nil {
+		return fmt.Errorf("failed to check lessons: %w", err)
+	}
+	if busy {
+		return scheduleConflict(ctx, "room")
+	}
+
+	if err := tx.QueryRow(ctx, qBooked, roomID, classID, date, exceptID).Scan(&busy); err != nil {
+		return fmt.Errorf("failed to check bookings: %w", err)
+	}
+	if busy {
+		return scheduleConflict(ctx, "booking")
+	}
+
+	if err := tx.QueryRow(ctx, qExams, roomID, classID, date).Scan(&busy); err != nil {
+		return fmt.Errorf("failed to check exams: %w", err)
+	}
+	if busy {
+		return scheduleConflict(ctx, "exam")
+	}
+	return nil
+}
+
+func scanBookings(rows pgx.Rows) ([]bookings.Booking, error) {
+	defer rows.Close()
+
+	var result []bookings.Booking
+	for rows.Next() {
+		var b bookings.Booking
+		err := rows.Scan(&b.ID, &b.UniversityID, &b.RoomID, &b.Room, &b.ClassID, &b.PairNumber, &b.StartTime, &b.EndTime,
+			&b.Date, &b.Purpose, &b.Title, &b.Comment, &b.Status, &b.RequestedBy, &b.RequesterFirstName, &b.RequesterLastName,
+			&b.DecidedBy, &b.DecidedAt, &b.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,373 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/curriculum"
+)
+
+// ErrElectiveSubject is returned when a teacher is assigned to a course group for an elective subject,
+// electives are taught to elective groups.
+var ErrElectiveSubject = errors.New("subject is elective")
+
+type curriculumRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCurriculumRepository(pool *pgxpool.Pool) CurriculumRepository {
+	return &curriculumRepository{pool: pool}
+}
+
+func (r *curriculumRepository) GetSemesters(ctx context.Context, adminID int64) ([]curriculum.Semester, error) {
+	const q = `
+		SELECT s.id, s.university_id, s.start_date, s.end_date
+		FROM universities.semesters AS s
+		WHERE s.university_id IN (
+			SELECT pa.university_id FROM personalities.administrations pa WHERE pa.max_user_id = $1
+		)
+		ORDER BY s.start_date DESC
+	`
+
+	rows, err := r.pool.Query(ctx, q, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get semesters: %w", err)
+	}
+	defer rows.Close()
+
+	var semesters []curriculum.Semester
+	for rows.Next() {
+		var s curriculum.Semester
+		if err := rows.Scan(&s.ID, &s.UniversityID, &s.StartDate, &s.EndDate); err != nil {
+			return nil, fmt.Errorf("failed to scan semester row: %w", err)
+		}
+		semesters = append(semesters, s)
+	}
+
+	return semesters, rows.Err()
+}
+
+// AddCourseSubject attaches university subject to a live course of admin's university for a semester
+// of the same university that overlaps course dates.
+func (r *curriculumRepository) AddCourseSubject(ctx context.Context, adminID int64, cs curriculum.CourseSubject) (int64, error) {
+	qCheck := fmt.Sprintf(`
+		SELECT
+			EXISTS (SELECT 1 FROM universities.semesters s
+			        WHERE s.id = $3 AND s.university_id = ud.university_id
+			          AND s.start_date < c.end_date AND s.end_date > c.start_date),
+			EXISTS (SELECT 1 FROM subjects.university_subjects us
+			        WHERE us.id = $4 AND us.university_id = ud.university_id)
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE c.id = $1
+		  AND c.deleted_at IS NULL
+		  AND %s
+		FOR SHARE OF c
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+	const qInsert = `
+		INSERT INTO subjects.course_semester_subjects (semester_id, course_id, university_subject_id, is_elective, required_hours_by_semester)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var semesterOK, subjectOK bool
+		if err := tx.QueryRow(ctx, qCheck, cs.CourseID, adminID, cs.SemesterID, cs.UniversitySubjectID).Scan(&semesterOK, &subjectOK); err != nil {
+			return err
+		}
+		if !semesterOK || !subjectOK {
+			return ErrReferenceNotFound
+		}
+
+		return tx.QueryRow(ctx, qInsert, cs.SemesterID, cs.CourseID, cs.UniversitySubjectID, cs.IsElective, cs.RequiredHours).Scan(&id)
+	})
+	return id, err
+}
+
+// GetCourseSubjects returns subjects of a course, of semesterID only if it is not 0.
+func (r *curriculumRepository) GetCourseSubjects(ctx context.Context, adminID, courseID, semesterID int64) ([]curriculum.CourseSubject, error) {
+	q := fmt.Sprintf(`
+		SELECT css.id, css.course_id, css.semester_id, css.university_subject_id, us.name,
+		       css.is_elective, css.required_hours_by_semester, s.start_date, s.end_date
+		FROM subjects.course_semester_subjects AS css
+		JOIN subjects.university_subjects AS us ON css.university_subject_id = us.id
+		JOIN universities.semesters AS s ON css.semester_id = s.id
+		WHERE css.course_id = $1
+		  AND ($3 = 0 OR css.semester_id = $3)
+		  AND %s
+		ORDER BY s.start_date, us.name
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	rows, err := r.pool.Query(ctx, q, courseID, adminID, semesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course subjects: %w", err)
+	}
+	defer rows.Close()
+
+	var subjects []curriculum.CourseSubject
+	for rows.Next() {
+		var cs curriculum.CourseSubject
+		err := rows.Scan(&cs.ID, &cs.CourseID, &cs.SemesterID, &cs.UniversitySubjectID, &cs.SubjectName,
+			&cs.IsElective, &cs.RequiredHours, &cs.SemesterStart, &cs.SemesterEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan course subject row: %w", err)
+		}
+		subjects = append(subjects, cs)
+	}
+
+	return subjects, rows.Err()
+}
+
+func (r *curriculumRepository) UpdateCourseSubjectHours(ctx context.Context, adminID, id int64, hours *int) error {
+	q := fmt.Sprintf(`
+		UPDATE subjects.course_semester_subjects AS css
+		SET required_hours_by_semester = $3
+		FROM universities.semesters AS s
+		WHERE css.id = $1
+		  AND s.id = css.semester_id
+		  AND %s
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID, hours)
+	})
+}
+
+// DeleteCourseSubject deletes course subject, it fails with foreign key violation while
+// teachers are assigned to it.
+func (r *curriculumRepository) DeleteCourseSubject(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM subjects.course_semester_subjects AS css
+		USING universities.semesters AS s
+		WHERE css.id = $1
+		  AND s.id = css.semester_id
+		  AND %s
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// GetGroupCurriculum returns non elective subjects of the group's course with teachers assigned to the group,
+// of semesterID only if it is not 0. A subject taught as lecture and practice is returned once per assignment.
+func (r *curriculumRepository) GetGroupCurriculum(ctx context.Context, adminID, groupID, semesterID int64) ([]curriculum.CurriculumItem, error) {
+	q := fmt.Sprintf(`
+		SELECT css.id, css.course_id, css.semester_id, css.university_subject_id, us.name,
+		       css.is_elective, css.required_hours_by_semester, s.start_date, s.end_date,
+		       cgs.id, cgs.subject_type, t.id, u.id, u.first_name, u.last_name
+		FROM groups.course_groups AS cg
+		JOIN subjects.course_semester_subjects AS css ON css.course_id = cg.course_id AND NOT css.is_elective
+		JOIN subjects.university_subjects AS us ON css.university_subject_id = us.id
+		JOIN universities.semesters AS s ON css.semester_id = s.id
+		LEFT JOIN subjects.course_group_subjects AS cgs
+		       ON cgs.course_semester_subject_id = css.id AND cgs.course_group_id = cg.id
+		LEFT JOIN personalities.teachers AS t ON cgs.teacher_id = t.id
+		LEFT JOIN users.max_users_data AS u ON t.max_user_id = u.id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND ($3 = 0 OR css.semester_id = $3)
+		  AND %s
+		ORDER BY s.start_date, us.name, cgs.subject_type
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	rows, err := r.pool.Query(ctx, q, groupID, adminID, semesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group curriculum: %w", err)
+	}
+	defer rows.Close()
+
+	var items []curriculum.CurriculumItem
+	for rows.Next() {
+		var (
+			item        curriculum.CurriculumItem
+			cs          = &item.Subject
+			cgsID       *int64
+			subjectType *string
+			teacherID   *int64
+			userID      *int64
+			firstName   *string
+			lastName    *string
+		)
+		err := rows.Scan(&cs.ID, &cs.CourseID, &cs.SemesterID, &cs.UniversitySubjectID, &cs.SubjectName,
+			&cs.IsElective, &cs.RequiredHours, &cs.SemesterStart, &cs.SemesterEnd,
+			&cgsID, &subjectType, &teacherID, &userID, &firstName, &lastName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan curriculum row: %w", err)
+		}
+
+		if cgsID != nil {
+			item.Assignment = &curriculum.GroupSubject{
+				ID:              *cgsID,
+				CourseGroupID:   groupID,
+				CourseSubjectID: cs.ID,
+				SubjectType:     curriculum.SubjectType(*subjectType),
+				Teacher: curriculum.Teacher{
+					ID:        *teacherID,
+					UserID:    *userID,
+					FirstName: *firstName,
+					LastName:  lastName,
+				},
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// AssignGroupSubject assigns teacher (by MAX user id) to teach course subject to a course group.
+// The subject must belong to the group's course and must not be elective, the teacher must work
+// in the same university.
+func (r *curriculumRepository) AssignGroupSubject(ctx context.Context, adminID int64, gs curriculum.GroupSubject) (int64, error) {
+	qCheck := fmt.Sprintf(`
+		SELECT ud.university_id, css.id IS NOT NULL, COALESCE(css.is_elective, false)
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON cg.course_id = c.id
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		LEFT JOIN subjects.course_semester_subjects AS css ON css.id = $3 AND css.course_id = cg.course_id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND %s
+		FOR SHARE OF cg
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+	const qInsert = `
+		INSERT INTO subjects.course_group_subjects (course_group_id, course_semester_subject_id, teacher_id, subject_type)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			universityID int64
+			subjectOK    bool
+			isElective   bool
+		)
+		err := tx.QueryRow(ctx, qCheck, gs.CourseGroupID, adminID, gs.CourseSubjectID).Scan(&universityID, &subjectOK, &isElective)
+		if err != nil {
+			return err
+		}
+		if !subjectOK {
+			return ErrReferenceNotFound
+		}
+		if isElective {
+			return ErrElectiveSubject
+		}
+
+		teacherID, err := teacherOf(ctx, tx, gs.Teacher.UserID, universityID)
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, qInsert, gs.CourseGroupID, gs.CourseSubjectID, teacherID, gs.SubjectType).Scan(&id)
+	})
+	return id, err
+}
+
+// UpdateGroupSubject changes teacher and type of a group subject, lessons already scheduled for it keep their slots.
+func (r *curriculumRepository) UpdateGroupSubject(ctx context.Context, adminID int64, gs curriculum.GroupSubject) error {
+	qLock := fmt.Sprintf(`
+		SELECT t.university_id
+		FROM subjects.course_group_subjects AS cgs
+		JOIN personalities.teachers AS t ON cgs.teacher_id = t.id
+		WHERE cgs.id = $1
+		  AND %s
+		FOR UPDATE OF cgs
+	`, fmt.Sprintf(adminOf, "t.university_id"))
+	const qUpdate = `UPDATE subjects.course_group_subjects SET teacher_id = $2, subject_type = $3 WHERE id = $1`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var universityID int64
+		if err := tx.QueryRow(ctx, qLock, gs.ID, adminID).Scan(&universityID); err != nil {
+			return err
+		}
+
+		teacherID, err := teacherOf(ctx, tx, gs.Teacher.UserID, universityID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, qUpdate, gs.ID, teacherID, gs.SubjectType)
+		return err
+	})
+}
+
+// DeleteGroupSubject deletes group subject, it fails with foreign key violation while lessons are scheduled for it.
+func (r *curriculumRepository) DeleteGroupSubject(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM subjects.course_group_subjects AS cgs
+		USING personalities.teachers AS t
+		WHERE cgs.id = $1
+		  AND t.id = cgs.teacher_id
+		  AND %s
+	`, fmt.Sprintf(adminOf, "t.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// AssignElectiveSubject assigns teacher (by MAX user id) to an elective group of admin's university.
+func (r *curriculumRepository) AssignElectiveSubject(ctx context.Context, adminID int64, es curriculum.ElectiveSubject) (int64, error) {
+	qCheck := fmt.Sprintf(`
+		SELECT s.university_id
+		FROM groups.elective_groups AS eg
+		JOIN universities.semesters AS s ON eg.semester_id = s.id
+		WHERE eg.id = $1
+		  AND %s
+		FOR SHARE OF eg
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+	const qInsert = `
+		INSERT INTO subjects.elective_group_subjects (elective_group_id, teacher_id, subject_type)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var universityID int64
+		if err := tx.QueryRow(ctx, qCheck, es.ElectiveGroupID, adminID).Scan(&universityID); err != nil {
+			return err
+		}
+
+		teacherID, err := teacherOf(ctx, tx, es.Teacher.UserID, universityID)
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, qInsert, es.ElectiveGroupID, teacherID, es.SubjectType).Scan(&id)
+	})
+	return id, err
+}
+
+// DeleteElectiveSubject deletes elective group subject, it fails with foreign key violation while lessons are scheduled for it.
+func (r *curriculumRepository) DeleteElectiveSubject(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM subjects.elective_group_subjects AS egs
+		USING personalities.teachers AS t
+		WHERE egs.id = $1
+		  AND t.id = egs.teacher_id
+		  AND %s
+	`, fmt.Sprintf(adminOf, "t.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// teacherOf returns personalities.teachers id of MAX user userID in the university or ErrReferenceNotFound.
+func teacherOf(ctx context.Context, tx pgx.Tx, userID, universityID int64) (int64, error) {
+	const q = `SELECT id FROM personalities.teachers WHERE max_user_id = $1 AND university_id = $2`
+
+	var teacherID int64
+	err := tx.QueryRow(ctx, q, userID, universityID).Scan(&teacherID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrReferenceNotFound
+	}
+	return teacherID, err
+}
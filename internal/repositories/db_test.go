@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/db"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/migrate"
+	"github.com/vmkteam/embedlog"
+)
+
+// testDSNEnv is the DSN of a throwaway database for repository tests. The init schema assigns
+// objects to max_superuser, so the role must exist there. Tests are skipped when it is not set.
+const testDSNEnv = "TEST_DATABASE_URL"
+
+// testPool connects to the test database and applies all migrations.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	m, err := migrate.New(pool, db.Migrations, "migrations", logging.New(embedlog.NewDevLogger()))
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return pool
+}
+
+// testTx begins a transaction that is rolled back when the test ends, so tests leave no rows behind.
+func testTx(t *testing.T, pool *pgxpool.Pool) pgx.Tx {
+	t.Helper()
+
+	tx, err := pool.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	t.Cleanup(func() { _ = tx.Rollback(context.Background()) })
+	return tx
+}
+
+// testUser inserts a MAX user with id.
+func testUser(t *testing.T, tx pgx.Tx, id int64) {
+	t.Helper()
+
+	const q = `INSERT INTO users.max_users_data (id, first_name) VALUES ($1, 'Test')`
+	if _, err := tx.Exec(context.Background(), q, id); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+}
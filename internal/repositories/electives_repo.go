@@ -0,0 +1,652 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/electives"
+)
+
+var (
+	ErrNotStudent          = errors.New("user is not an active student of the university")
+	ErrWindowClosed        = errors.New("selection window is not open")
+	ErrWindowNotClosed     = errors.New("selection window is not closed yet")
+	ErrWindowDrawn         = errors.New("lottery is already drawn")
+	ErrNotLottery          = errors.New("selection window is not a lottery")
+	ErrTooManyChoices      = errors.New("too many electives chosen")
+	ErrNotEligible         = errors.New("elective is not offered to the student's course")
+	ErrDuplicateChoice     = errors.New("elective is chosen more than once")
+	ErrCapacityBelowSeated = errors.New("capacity is less than the number of enrolled students")
+)
+
+// GroupFullError is returned when a student chooses a first come elective group without free seats.
+type GroupFullError struct {
+	GroupID int64
+	Name    string
+}
+
+func (e *GroupFullError) Error() string {
+	return fmt.Sprintf("elective group %q has no free seats", e.Name)
+}
+
+// DrawFunc assigns students to groups by their preferences, groups have Enrolled set to already taken seats.
+type DrawFunc func(groups []electives.Group, preferences []electives.Preference, enrolled []electives.Enrollment, maxChoices int) []electives.Enrollment
+
+const qElectiveGroups = `
+	SELECT eg.id, eg.name, eg.semester_id, eg.university_subject_id, us.name, eg.capacity,
+	       (SELECT count(*) FROM groups.students_elective_groups seg WHERE seg.elective_group_id = eg.id)
+	FROM groups.elective_groups AS eg
+	JOIN subjects.university_subjects AS us ON eg.university_subject_id = us.id
+`
+
+const qWindows = `
+	SELECT w.id, w.university_id, w.semester_id, w.opens_at, w.closes_at, w.mode::text, w.max_choices, w.drawn_at, w.lottery_seed
+	FROM groups.elective_windows AS w
+`
+
+type electivesRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewElectivesRepository(pool *pgxpool.Pool) ElectivesRepository {
+	return &electivesRepository{pool: pool}
+}
+
+// CreateGroup creates elective group of a semester and university subject of admin's university.
+func (r *electivesRepository) CreateGroup(ctx context.Context, adminID int64, group electives.Group) (int64, error) {
+	qCheck := fmt.Sprintf(`
+		SELECT EXISTS (SELECT 1 FROM subjects.university_subjects us
+		               WHERE us.id = $3 AND us.university_id = s.university_id)
+		FROM universities.semesters AS s
+		WHERE s.id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+	const qInsert = `
+		INSERT INTO groups.elective_groups (name, semester_id, university_subject_id, capacity)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var subjectOK bool
+		err := tx.QueryRow(ctx, qCheck, group.SemesterID, adminID, group.UniversitySubjectID).Scan(&subjectOK)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && !subjectOK) {
+			return ErrReferenceNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, qInsert, group.Name, group.SemesterID, group.UniversitySubjectID, group.Capacity).Scan(&id)
+	})
+	return id, err
+}
+
+// GetGroups returns elective groups of admin's universities, of semesterID only if it is not 0.
+func (r *electivesRepository) GetGroups(ctx context.Context, adminID, semesterID int64) ([]electives.Group, error) {
+	q := qElectiveGroups + fmt.Sprintf(`
+		JOIN universities.semesters AS s ON eg.semester_id = s.id
+		WHERE ($1 = 0 OR eg.semester_id = $1)
+		  AND %s
+		ORDER BY s.start_date DESC, eg.name
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	rows, err := r.pool.Query(ctx, q, semesterID, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get elective groups: %w", err)
+	}
+	return scanElectiveGroups(rows)
+}
+
+// UpdateGroup renames group and changes its capacity, capacity can't be less than already enrolled students.
+func (r *electivesRepository) UpdateGroup(ctx context.Context, adminID int64, group electives.Group) error {
+	qLock := fmt.Sprintf(`
+		SELECT (SELECT count(*) FROM groups.students_elective_groups seg WHERE seg.elective_group_id = eg.id)
+		FROM groups.elective_groups AS eg
+		JOIN universities.semesters AS s ON eg.semester_id = s.id
+		WHERE eg.id = $1
+		  AND %s
+		FOR UPDATE OF eg
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+	const qUpdate = `UPDATE groups.elective_groups SET name = $2, capacity = $3 WHERE id = $1`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var enrolled int
+		if err := tx.QueryRow(ctx, qLock, group.ID, adminID).Scan(&enrolled); err != nil {
+			return err
+		}
+		if group.Capacity != nil && *group.Capacity < enrolled {
+			return ErrCapacityBelowSeated
+		}
+
+		_, err := tx.Exec(ctx, qUpdate, group.ID, group.Name, group.Capacity)
+		return err
+	})
+}
+
+// DeleteGroup deletes elective group, it fails with foreign key violation while students, teachers
+// or preferences reference it.
+func (r *electivesRepository) DeleteGroup(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM groups.elective_groups AS eg
+		USING universities.semesters AS s
+		WHERE eg.id = $1
+		  AND s.id = eg.semester_id
+		  AND %s
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// CreateWindow opens selection for a semester of admin's university, a semester has at most one window.
+func (r *electivesRepository) CreateWindow(ctx context.Context, adminID int64, w electives.Window) (int64, error) {
+	q := fmt.Sprintf(`
+		INSERT INTO groups.elective_windows (university_id, semester_id, opens_at, closes_at, mode, max_choices)
+		SELECT s.university_id, s.id, $3::timestamptz, $4::timestamptz, $5::text, $6::integer
+		FROM universities.semesters AS s
+		WHERE s.id = $1
+		  AND %s
+		RETURNING id
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, q, w.SemesterID, adminID, w.OpensAt, w.ClosesAt, w.Mode, w.MaxChoices).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		return err
+	})
+	return id, err
+}
+
+func (r *electivesRepository) GetWindows(ctx context.Context, adminID int64) ([]electives.Window, error) {
+	q := qWindows + `
+		WHERE w.university_id IN (
+			SELECT pa.university_id FROM personalities.administrations pa WHERE pa.max_user_id = $1
+		)
+		ORDER BY w.opens_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, q, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selection windows: %w", err)
+	}
+	return scanWindows(rows)
+}
+
+// UpdateWindow changes dates and max choices of a window, a drawn window can't be changed.
+func (r *electivesRepository) UpdateWindow(ctx context.Context, adminID int64, w electives.Window) error {
+	qLock := qWindows + fmt.Sprintf(`
+		WHERE w.id = $1
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "w.university_id"))
+	const qUpdate = `UPDATE groups.elective_windows SET opens_at = $2, closes_at = $3, max_choices = $4 WHERE id = $1`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		current, err := scanWindow(tx.QueryRow(ctx, qLock, w.ID, adminID))
+		if err != nil {
+			return err
+		}
+		if current.DrawnAt != nil {
+			return ErrWindowDrawn
+		}
+
+		_, err = tx.Exec(ctx, qUpdate, w.ID, w.OpensAt, w.ClosesAt, w.MaxChoices)
+		return err
+	})
+}
+
+// DrawLottery enrolls students of a closed lottery window by draw and marks the window drawn.
+// It returns the window with its seed and the number of new enrollments.
+func (r *electivesRepository) DrawLottery(ctx context.Context, adminID, windowID, seed int64, draw DrawFunc) (*electives.Window, int, error) {
+	qLock := qWindows + fmt.Sprintf(`
+		WHERE w.id = $1
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "w.university_id"))
+	qGroups := qElectiveGroups + `
+		WHERE eg.semester_id = $1
+		ORDER BY eg.id
+		FOR UPDATE OF eg
+	`
+	const qPreferences = `
+		SELECT student_id, elective_group_id, rank
+		FROM groups.elective_preferences
+		WHERE window_id = $1
+		ORDER BY student_id, rank
+	`
+	const qEnrolled = `
+		SELECT seg.student_id, seg.elective_group_id
+		FROM groups.students_elective_groups AS seg
+		JOIN groups.elective_groups AS eg ON seg.elective_group_id = eg.id
+		WHERE eg.semester_id = $1
+	`
+	const qEnroll = `
+		INSERT INTO groups.students_elective_groups (student_id, elective_group_id)
+		VALUES ($1, $2)
+		ON CONFLICT (student_id, elective_group_id) DO NOTHING
+	`
+	const qDrawn = `UPDATE groups.elective_windows SET drawn_at = now(), lottery_seed = $2 WHERE id = $1 RETURNING drawn_at`
+
+	var (
+		w     *electives.Window
+		count int
+	)
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var err error
+		w, err = scanWindow(tx.QueryRow(ctx, qLock, windowID, adminID))
+		if err != nil {
+			return err
+		}
+		switch {
+		case w.Mode != electives.Lottery:
+			return ErrNotLottery
+		case w.DrawnAt != nil:
+			return ErrWindowDrawn
+		case time.Now().Before(w.ClosesAt):
+			return ErrWindowNotClosed
+		}
+
+		rows, err := tx.Query(ctx, qGroups, w.SemesterID)
+		if err != nil {
+			return fmt.Errorf("failed to lock elective groups: %w", err)
+		}
+		groups, err := scanElectiveGroups(rows)
+		if err != nil {
+			return err
+		}
+
+		rows, err = tx.Query(ctx, qPreferences, w.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get preferences: %w", err)
+		}
+		var preferences []electives.Preference
+		for rows.Next() {
+			var p electives.Preference
+			if err := rows.Scan(&p.StudentID, &p.ElectiveGroupID, &p.Rank); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan preference row: %w", err)
+			}
+			preferences = append(preferences, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		rows, err = tx.Query(ctx, qEnrolled, w.SemesterID)
+		if err != nil {
+			return fmt.Errorf("failed to get enrollments: %w", err)
+		}
+		var enrolled []electives.Enrollment
+		for rows.Next() {
+			var e electives.Enrollment
+			if err := rows.Scan(&e.StudentID, &e.ElectiveGroupID); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan enrollment row: %w", err)
+			}
+			enrolled = append(enrolled, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+		for _, e := range draw(groups, preferences, enrolled, w.MaxChoices) {
+			batch.Queue(qEnroll, e.StudentID, e.ElectiveGroupID)
+		}
+		results := tx.SendBatch(ctx, batch)
+		for range batch.Len() {
+			tag, err := results.Exec()
+			if err != nil {
+				_ = results.Close()
+				return fmt.Errorf("failed to enroll student: %w", err)
+			}
+			count += int(tag.RowsAffected())
+		}
+		if err := results.Close(); err != nil {
+			return err
+		}
+
+		w.LotterySeed = &seed
+		return tx.QueryRow(ctx, qDrawn, w.ID, seed).Scan(&w.DrawnAt)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return w, count, nil
+}
+
+// GetStudentWindows returns windows of not finished semesters of universities the user studies in.
+func (r *electivesRepository) GetStudentWindows(ctx context.Context, userID int64) ([]electives.Window, error) {
+	q := qWindows + `
+		JOIN universities.semesters AS s ON w.semester_id = s.id
+		WHERE s.end_date > now()
+		  AND w.university_id IN (
+			SELECT ud.university_id
+			FROM personalities.students AS ps
+			JOIN universities.university_departments AS ud ON ps.university_department_id = ud.id
+			WHERE ps.max_user_id = $1 AND NOT ps.is_graduated
+		  )
+		ORDER BY w.opens_at
+	`
+
+	rows, err := r.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selection windows: %w", err)
+	}
+	return scanWindows(rows)
+}
+
+// GetStudentWindow returns window, the user as a student of its university and groups of the window
+// offered to the student's course: elective subjects of the course in the window's semester.
+func (r *electivesRepository) GetStudentWindow(ctx context.Context, userID, windowID int64) (*electives.Window, *electives.Student, []electives.Group, error) {
+	q := qWindows + `WHERE w.id = $1`
+
+	w, err := scanWindow(r.pool.QueryRow(ctx, q, windowID))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	student, err := studentOf(ctx, r.pool, userID, w.UniversityID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	groups, err := offeredGroups(ctx, r.pool, w.SemesterID, student.CourseID, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return w, student, groups, nil
+}
+
+// GetChoices returns groups of the window's semester the student is enrolled in and their ranked preferences.
+func (r *electivesRepository) GetChoices(ctx context.Context, studentID int64, w electives.Window) (*electives.Choices, error) {
+	const qEnrolled = `
+		SELECT seg.elective_group_id
+		FROM groups.students_elective_groups AS seg
+		JOIN groups.elective_groups AS eg ON seg.elective_group_id = eg.id
+		WHERE seg.student_id = $1 AND eg.semester_id = $2
+		ORDER BY seg.id
+	`
+	const qPreferences = `
+		SELECT elective_group_id
+		FROM groups.elective_preferences
+		WHERE student_id = $1 AND window_id = $2
+		ORDER BY rank
+	`
+
+	var choices electives.Choices
+	rows, err := r.pool.Query(ctx, qEnrolled, studentID, w.SemesterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	if choices.Enrolled, err = scanIDs(rows); err != nil {
+		return nil, fmt.Errorf("failed to scan enrollments: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx, qPreferences, studentID, w.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if choices.Preferences, err = scanIDs(rows); err != nil {
+		return nil, fmt.Errorf("failed to scan preferences: %w", err)
+	}
+	return &choices, nil
+}
+
+// SetChoices replaces choices of the user in an open window. In a first come window the student is
+// enrolled in groupIDs right away while seats last and leaves groups not in groupIDs; in a lottery
+// window groupIDs are stored as preferences ranked in the given order.
+func (r *electivesRepository) SetChoices(ctx context.Context, userID, windowID int64, groupIDs []int64) (*electives.Student, error) {
+	q := qWindows + `WHERE w.id = $1 FOR SHARE`
+	const (
+		qLeave = `
+			DELETE FROM groups.students_elective_groups AS seg
+			USING groups.elective_groups AS eg
+			WHERE seg.elective_group_id = eg.id
+			  AND seg.student_id = $1
+			  AND eg.semester_id = $2
+			  AND NOT (eg.id = ANY($3))
+		`
+		qJoin = `
+			INSERT INTO groups.students_elective_groups (student_id, elective_group_id)
+			VALUES ($1, $2)
+			ON CONFLICT (student_id, elective_group_id) DO NOTHING
+		`
+		qClearPreferences = `DELETE FROM groups.elective_preferences WHERE window_id = $1 AND student_id = $2`
+		qPrefer           = `
+			INSERT INTO groups.elective_preferences (window_id, student_id, elective_group_id, rank)
+			VALUES ($1, $2, $3, $4)
+		`
+	)
+
+	// a nil slice is sent as NULL and NOT (eg.id = ANY(NULL)) keeps every group, an empty choice leaves them all
+	if groupIDs == nil {
+		groupIDs = []int64{}
+	}
+
+	var student *electives.Student
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		w, err := scanWindow(tx.QueryRow(ctx, q, windowID))
+		if err != nil {
+			return err
+		}
+		if !w.IsOpen(time.Now()) || w.DrawnAt != nil {
+			return ErrWindowClosed
+		}
+		if len(groupIDs) > w.MaxChoices {
+			return ErrTooManyChoices
+		}
+		if !uniqueIDs(groupIDs) {
+			return ErrDuplicateChoice
+		}
+
+		student, err = studentOf(ctx, tx, userID, w.UniversityID)
+		if err != nil {
+			return err
+		}
+
+		// seats are counted under lock of the groups, so concurrent choices can't overfill them
+		groups, err := offeredGroups(ctx, tx, w.SemesterID, student.CourseID, w.Mode == electives.FirstCome)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int64]electives.Group, len(groups))
+		for _, g := range groups {
+			byID[g.ID] = g
+		}
+		for _, id := range groupIDs {
+			if _, ok := byID[id]; !ok {
+				return ErrNotEligible
+			}
+		}
+
+		if w.Mode == electives.Lottery {
+			if _, err := tx.Exec(ctx, qClearPreferences, w.ID, student.ID); err != nil {
+				return fmt.Errorf("failed to clear preferences: %w", err)
+			}
+			for i, id := range groupIDs {
+				if _, err := tx.Exec(ctx, qPrefer, w.ID, student.ID, id, i+1); err != nil {
+					return fmt.Errorf("failed to save preference: %w", err)
+				}
+			}
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, qLeave, student.ID, w.SemesterID, groupIDs); err != nil {
+			return fmt.Errorf("failed to leave elective groups: %w", err)
+		}
+		for _, id := range groupIDs {
+			tag, err := tx.Exec(ctx, qJoin, student.ID, id)
+			if err != nil {
+				return fmt.Errorf("failed to join elective group: %w", err)
+			}
+			// an already enrolled student keeps the seat even if the group is full now
+			if tag.RowsAffected() > 0 && !byID[id].HasSeat() {
+				return &GroupFullError{GroupID: id, Name: byID[id].Name}
+			}
+		}
+		return nil
+	})
+	return student, err
+}
+
+// GetClashes returns lessons of elective groups held at the same day and pair as lessons of the course group
+// in the same semester. Two biweekly lessons are not a clash, like in CreateLesson they alternate.
+func (r *electivesRepository) GetClashes(ctx context.Context, courseGroupID int64, groupIDs []int64) ([]electives.Clash, error) {
+	const q = `
+		SELECT eg.id, el.day::text, cl.pair_number, eus.name, el."interval"::text, gus.name, gl."interval"::text
+		FROM groups.elective_groups AS eg
+		JOIN subjects.university_subjects AS eus ON eg.university_subject_id = eus.id
+		JOIN subjects.elective_group_subjects AS egs ON egs.elective_group_id = eg.id
+		JOIN schedules.groups_schedules AS el ON el.elective_group_subject_id = egs.id
+		JOIN schedules.classes AS cl ON el.class_id = cl.id
+		JOIN schedules.groups_schedules AS gl ON gl.day = el.day AND gl.class_id = el.class_id
+		JOIN subjects.course_group_subjects AS cgs ON gl.course_group_subjet_id = cgs.id
+		JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+		JOIN subjects.university_subjects AS gus ON css.university_subject_id = gus.id
+		WHERE eg.id = ANY($1)
+		  AND cgs.course_group_id = $2
+		  AND css.semester_id = eg.semester_id
+		  AND NOT (el."interval" = 'every two week' AND gl."interval" = 'every two week')
+		ORDER BY eg.id, el.day, cl.pair_number
+	`
+
+	rows, err := r.pool.Query(ctx, q, groupIDs, courseGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clashes: %w", err)
+	}
+	defer rows.Close()
+
+	var clashes []electives.Clash
+	for rows.Next() {
+		var c electives.Clash
+		err := rows.Scan(&c.ElectiveGroupID, &c.Day, &c.PairNumber, &c.ElectiveSubject, &c.ElectiveInterval, &c.GroupSubject, &c.GroupInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clash row: %w", err)
+		}
+		clashes = append(clashes, c)
+	}
+	return clashes, rows.Err()
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// studentOf returns active student of the university with a course group or ErrNotStudent.
+func studentOf(ctx context.Context, db querier, userID, universityID int64) (*electives.Student, error) {
+	const q = `
+		SELECT ps.id, cg.course_id, cg.id
+		FROM personalities.students AS ps
+		JOIN universities.university_departments AS ud ON ps.university_department_id = ud.id
+		JOIN groups.course_groups AS cg ON ps.course_group_id = cg.id
+		WHERE ps.max_user_id = $1
+		  AND ud.university_id = $2
+		  AND NOT ps.is_graduated
+		  AND cg.deleted_at IS NULL
+		ORDER BY ps.id DESC
+		LIMIT 1
+	`
+
+	var s electives.Student
+	err := db.QueryRow(ctx, q, userID, universityID).Scan(&s.ID, &s.CourseID, &s.GroupID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotStudent
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student: %w", err)
+	}
+	return &s, nil
+}
+
+// offeredGroups returns groups of the semester for elective subjects of the course, locked for update if lock.
+func offeredGroups(ctx context.Context, db querier, semesterID, courseID int64, lock bool) ([]electives.Group, error) {
+	q := qElectiveGroups + `
+		WHERE eg.semester_id = $1
+		  AND EXISTS (SELECT 1 FROM subjects.course_semester_subjects css
+		              WHERE css.course_id = $2 AND css.semester_id = eg.semester_id
+		                AND css.university_subject_id = eg.university_subject_id AND css.is_elective)
+		ORDER BY eg.id
+	`
+	if lock {
+		q += ` FOR UPDATE OF eg`
+	}
+
+	rows, err := db.Query(ctx, q, semesterID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get elective groups: %w", err)
+	}
+	return scanElectiveGroups(rows)
+}
+
+func scanElectiveGroups(rows pgx.Rows) ([]electives.Group, error) {
+	defer rows.Close()
+
+	var groups []electives.Group
+	for rows.Next() {
+		var g electives.Group
+		if err := rows.Scan(&g.ID, &g.Name, &g.SemesterID, &g.UniversitySubjectID, &g.SubjectName, &g.Capacity, &g.Enrolled); err != nil {
+			return nil, fmt.Errorf("failed to scan elective group row: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+func scanWindow(row pgx.Row) (*electives.Window, error) {
+	var w electives.Window
+	err := row.Scan(&w.ID, &w.UniversityID, &w.SemesterID, &w.OpensAt, &w.ClosesAt, &w.Mode, &w.MaxChoices, &w.DrawnAt, &w.LotterySeed)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanWindows(rows pgx.Rows) ([]electives.Window, error) {
+	defer rows.Close()
+
+	var windows []electives.Window
+	for rows.Next() {
+		w, err := scanWindow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan selection window row: %w", err)
+		}
+		windows = append(windows, *w)
+	}
+	return windows, rows.Err()
+}
+
+func scanIDs(rows pgx.Rows) ([]int64, error) {
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// uniqueIDs reports whether ids has no duplicates.
+func uniqueIDs(ids []int64) bool {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	return len(slices.Compact(sorted)) == len(ids)
+}
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type electivesFixture struct {
+	userID    int64
+	studentID int64
+	windowID  int64
+	groupIDs  []int64
+}
+
+// newElectivesFixture commits a university with one student and an open first_come window with two groups
+// of the same elective subject, SetChoices runs its own transaction. Rows are removed when the test ends.
+func newElectivesFixture(t *testing.T, pool *pgxpool.Pool) electivesFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	f := electivesFixture{userID: time.Now().UnixNano()}
+	var cityID, uniID, facultyID, departmentID, udID, courseID, groupID, semesterID, subjectID int64
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	steps := []struct {
+		q    string
+		args []any
+		dest *int64
+	}{
+		{`INSERT INTO universities.cities (name) VALUES ('Test') RETURNING id`, nil, &cityID},
+		{`INSERT INTO universities.universities_data (name, city_id) VALUES ('Test', $1) RETURNING id`, []any{&cityID}, &uniID},
+		{`INSERT INTO universities.faculties (university_id, name) VALUES ($1, 'Test') RETURNING id`, []any{&uniID}, &facultyID},
+		{`INSERT INTO universities.departments (name, code) VALUES ('Test', 'T') RETURNING id`, nil, &departmentID},
+		{`INSERT INTO universities.university_departments (university_id, faculty_id, department_id) VALUES ($1, $2, $3) RETURNING id`,
+			[]any{&uniID, &facultyID, &departmentID}, &udID},
+		{`INSERT INTO universities.courses (start_date, end_date, university_department_id) VALUES (now(), now() + interval '1 year', $1) RETURNING id`,
+			[]any{&udID}, &courseID},
+		{`INSERT INTO groups.course_groups (name, course_id) VALUES ('T-1', $1) RETURNING id`, []any{&courseID}, &groupID},
+		{`INSERT INTO users.max_users_data (id, first_name) VALUES ($1, 'Test') RETURNING id`, []any{&f.userID}, &f.userID},
+		{`INSERT INTO personalities.students (max_user_id, university_department_id, course_group_id) VALUES ($1, $2, $3) RETURNING id`,
+			[]any{&f.userID, &udID, &groupID}, &f.studentID},
+		{`INSERT INTO universities.semesters (start_date, end_date, university_id) VALUES (now(), now() + interval '4 months', $1) RETURNING id`,
+			[]any{&uniID}, &semesterID},
+		{`INSERT INTO subjects.university_subjects (university_id, name) VALUES ($1, 'Elective') RETURNING id`, []any{&uniID}, &subjectID},
+	}
+	for _, s := range steps {
+		args := make([]any, len(s.args))
+		for i, a := range s.args {
+			args[i] = *a.(*int64)
+		}
+		if err := tx.QueryRow(ctx, s.q, args...).Scan(s.dest); err != nil {
+			t.Fatalf("failed to insert fixture %q: %v", s.q, err)
+		}
+	}
+
+	const qCourseSubject = `
+		INSERT INTO subjects.course_semester_subjects (semester_id, course_id, university_subject_id, is_elective)
+		VALUES ($1, $2, $3, true)
+	`
+	if _, err := tx.Exec(ctx, qCourseSubject, semesterID, courseID, subjectID); err != nil {
+		t.Fatalf("failed to insert course subject: %v", err)
+	}
+
+	// sections of one subject differ by name only
+	for _, name := range []string{"A", "B"} {
+		var id int64
+		const q = `INSERT INTO groups.elective_groups (name, semester_id, university_subject_id) VALUES ($1, $2, $3) RETURNING id`
+		if err := tx.QueryRow(ctx, q, name, semesterID, subjectID).Scan(&id); err != nil {
+			t.Fatalf("failed to insert elective group: %v", err)
+		}
+		f.groupIDs = append(f.groupIDs, id)
+	}
+
+	const qWindow = `
+		INSERT INTO groups.elective_windows (university_id, semester_id, opens_at, closes_at, mode, max_choices)
+		VALUES ($1, $2, now() - interval '1 hour', now() + interval '1 hour', 'first_come', 2)
+		RETURNING id
+	`
+	if err := tx.QueryRow(ctx, qWindow, uniID, semesterID).Scan(&f.windowID); err != nil {
+		t.Fatalf("failed to insert window: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cleanup := []struct {
+			q  string
+			id int64
+		}{
+			{`DELETE FROM groups.students_elective_groups WHERE student_id = $1`, f.studentID},
+			{`DELETE FROM groups.elective_windows WHERE id = $1`, f.windowID},
+			{`DELETE FROM groups.elective_groups WHERE semester_id = $1`, semesterID},
+			{`DELETE FROM subjects.course_semester_subjects WHERE semester_id = $1`, semesterID},
+			{`DELETE FROM subjects.university_subjects WHERE id = $1`, subjectID},
+			{`DELETE FROM universities.semesters WHERE id = $1`, semesterID},
+			{`DELETE FROM personalities.students WHERE id = $1`, f.studentID},
+			{`DELETE FROM users.max_users_data WHERE id = $1`, f.userID},
+			{`DELETE FROM groups.course_groups WHERE id = $1`, groupID},
+			{`DELETE FROM universities.courses WHERE id = $1`, courseID},
+			{`DELETE FROM universities.university_departments WHERE id = $1`, udID},
+			{`DELETE FROM universities.departments WHERE id = $1`, departmentID},
+			{`DELETE FROM universities.faculties WHERE id = $1`, facultyID},
+			{`DELETE FROM universities.universities_data WHERE id = $1`, uniID},
+			{`DELETE FROM universities.cities WHERE id = $1`, cityID},
+		}
+		for _, c := range cleanup {
+			if _, err := pool.Exec(context.Background(), c.q, c.id); err != nil {
+				t.Errorf("failed to clean up %q: %v", c.q, err)
+			}
+		}
+	})
+	return f
+}
+
+func enrolledGroups(t *testing.T, pool *pgxpool.Pool, studentID int64) []int64 {
+	t.Helper()
+
+	rows, err := pool.Query(context.Background(),
+		`SELECT elective_group_id FROM groups.students_elective_groups WHERE student_id = $1 ORDER BY elective_group_id`, studentID)
+	if err != nil {
+		t.Fatalf("failed to get enrollment: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("failed to scan enrollment: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to get enrollment: %v", err)
+	}
+	return ids
+}
+
+func TestSetChoices(t *testing.T) {
+	pool := testPool(t)
+	f := newElectivesFixture(t, pool)
+	repo := NewElectivesRepository(pool)
+	ctx := context.Background()
+
+	if _, err := repo.SetChoices(ctx, f.userID, f.windowID, f.groupIDs); err != nil {
+		t.Fatalf("SetChoices(both) = %v", err)
+	}
+	if got := enrolledGroups(t, pool, f.studentID); !slices.Equal(got, f.groupIDs) {
+		t.Fatalf("enrolled in %v, want %v", got, f.groupIDs)
+	}
+
+	if _, err := repo.SetChoices(ctx, f.userID, f.windowID, f.groupIDs[1:]); err != nil {
+		t.Fatalf("SetChoices(second) = %v", err)
+	}
+	if got := enrolledGroups(t, pool, f.studentID); !slices.Equal(got, f.groupIDs[1:]) {
+		t.Fatalf("enrolled in %v, want %v", got, f.groupIDs[1:])
+	}
+
+	// nil comes from a JSON body without elective_group_ids and means "no electives"
+	if _, err := repo.SetChoices(ctx, f.userID, f.windowID, nil); err != nil {
+		t.Fatalf("SetChoices(nil) = %v", err)
+	}
+	if got := enrolledGroups(t, pool, f.studentID); len(got) != 0 {
+		t.Fatalf("enrolled in %v after empty choice, want none", got)
+	}
+
+	_, err := repo.SetChoices(ctx, f.userID, f.windowID, []int64{f.groupIDs[0], f.groupIDs[0]})
+	if !errors.Is(err, ErrDuplicateChoice) {
+		t.Fatalf("SetChoices(duplicate) = %v, want ErrDuplicateChoice", err)
+	}
+
+	_, err = repo.SetChoices(ctx, f.userID, f.windowID, []int64{f.groupIDs[0], f.groupIDs[1], -1})
+	if !errors.Is(err, ErrTooManyChoices) {
+		t.Fatalf("SetChoices(three) = %v, want ErrTooManyChoices", err)
+	}
+}
@@ -0,0 +1,419 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exams"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrSessionOutsideSemester = errors.New("exam session dates are outside of the semester")
+	ErrExamOutsideSession     = errors.New("exam date is outside of the exam session")
+)
+
+// ExamConflictError is returned when an exam clashes with another exam of the group, is closer to it
+// than MinGapDays, or its room or examiner is taken.
+type ExamConflictError struct {
+	Kind       string
+	MinGapDays int
+}
+
+func (e *ExamConflictError) Error() string {
+	switch e.Kind {
+	case "group":
+		return "the group already has an exam that day"
+	case "gap":
+		return fmt.Sprintf("exams of the group must be at least %d days apart", e.MinGapDays)
+	case "room":
+		return "a room is taken by an exam, a lesson or a booking at that time"
+	case "examiner":
+		return "the examiner has an exam or a lesson at that time"
+	}
+	return "exam conflict"
+}
+
+// examConflict counts the conflict by kind for metrics like scheduleConflict and returns *ExamConflictError.
+func examConflict(ctx context.Context, kind string, minGapDays int) error {
+	metrics.ScheduleConflicts.WithLabelValues("exam_" + kind).Inc()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("schedules.conflict", "exam_"+kind))
+	return &ExamConflictError{Kind: kind, MinGapDays: minGapDays}
+}
+
+const qSessions = `
+	SELECT es.id, es.university_id, es.semester_id, es.name, es.starts_on, es.ends_on, es.min_gap_days, es.published_at,
+	       (SELECT count(*) FROM schedules.exams e WHERE e.session_id = es.id)
+	FROM schedules.exam_sessions AS es
+`
+
+const qExams = `
+	SELECT e.id, e.session_id, e.course_semester_subject_id, us.name, e.course_group_id, cg.name,
+	       mu.id, mu.first_name, mu.last_name, e.kind, e.date, e.start_time, e.end_time,
+	       array_agg(r.id ORDER BY r.room), array_agg(r.room ORDER BY r.room), es.name
+	FROM schedules.exams AS e
+	JOIN schedules.exam_sessions AS es ON e.session_id = es.id
+	JOIN subjects.course_semester_subjects AS css ON e.course_semester_subject_id = css.id
+	JOIN subjects.university_subjects AS us ON css.university_subject_id = us.id
+	JOIN groups.course_groups AS cg ON e.course_group_id = cg.id
+	JOIN personalities.teachers AS t ON e.examiner_id = t.id
+	JOIN users.max_users_data AS mu ON t.max_user_id = mu.id
+	JOIN schedules.exam_rooms AS er ON er.exam_id = e.id
+	JOIN schedules.rooms AS r ON er.room_id = r.id
+`
+
+const qExamsGroup = `
+	GROUP BY e.id, us.name, cg.name, mu.id, es.name
+	ORDER BY e.date, e.start_time, cg.name, e.id
+`
+
+type examsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExamsRepository(pool *pgxpool.Pool) ExamsRepository {
+	return &examsRepository{pool: pool}
+}
+
+// CreateSession creates exam session of a semester of a university the admin administers,
+// the session must be within the semester.
+func (r *examsRepository) CreateSession(ctx context.Context, adminID int64, s exams.Session) (int64, error) {
+	qSemester := fmt.Sprintf(`
+		SELECT s.university_id, $3::date >= s.start_date::date AND $4::date <= s.end_date::date
+		FROM universities.semesters AS s
+		WHERE s.id = $1
+		  AND %s
+		FOR SHARE
+	`, fmt.Sprintf(adminOf, "s.university_id"))
+	const qInsert = `
+		INSERT INTO schedules.exam_sessions (university_id, semester_id, name, starts_on, ends_on, min_gap_days)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var id int64
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var within bool
+		err := tx.QueryRow(ctx, qSemester, s.SemesterID, adminID, s.StartsOn, s.EndsOn).Scan(&s.UniversityID, &within)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !within {
+			return ErrSessionOutsideSemester
+		}
+
+		return tx.QueryRow(ctx, qInsert, s.UniversityID, s.SemesterID, s.Name, s.StartsOn, s.EndsOn, s.MinGapDays).Scan(&id)
+	})
+	return id, err
+}
+
+// GetSessions returns exam sessions of a university the admin administers, the latest first.
+func (r *examsRepository) GetSessions(ctx context.Context, adminID, universityID int64) ([]exams.Session, error) {
+	q := qSessions + fmt.Sprintf(`
+		WHERE es.university_id = $1
+		  AND %s
+		ORDER BY es.starts_on DESC, es.id DESC
+	`, fmt.Sprintf(adminOf, "es.university_id"))
+
+	rows, err := r.pool.Query(ctx, q, universityID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []exams.Session
+	for rows.Next() {
+		var s exams.Session
+		err := rows.Scan(&s.ID, &s.UniversityID, &s.SemesterID, &s.Name, &s.StartsOn, &s.EndsOn, &s.MinGapDays, &s.PublishedAt, &s.Exams)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// PublishSession shows exams of the session to students, publishing a published session changes nothing.
+func (r *examsRepository) PublishSession(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		UPDATE schedules.exam_sessions AS es
+		SET published_at = COALESCE(es.published_at, now())
+		WHERE es.id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "es.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// CreateExam schedules an exam of a course group in a subject of the session's semester. The group must not
+// have another exam closer than min_gap_days of the session, and neither rooms nor the examiner may be taken
+// by exams, lessons of the semester or confirmed bookings at that time.
+func (r *examsRepository) CreateExam(ctx context.Context, adminID int64, e exams.Exam) (id int64, err error) {
+	qSession := fmt.Sprintf(`
+		SELECT es.university_id, es.semester_id, es.min_gap_days, $3::date BETWEEN es.starts_on AND es.ends_on
+		FROM schedules.exam_sessions AS es
+		WHERE es.id = $1
+		  AND %s
+		FOR SHARE
+	`, fmt.Sprintf(adminOf, "es.university_id"))
+	// locks of the group, the examiner and the rooms order exams of each of them, so two can't pass the checks together
+	const (
+		qGroup = `
+			SELECT cg.id
+			FROM groups.course_groups AS cg
+			JOIN subjects.course_semester_subjects AS css ON css.id = $2 AND css.course_id = cg.course_id
+			JOIN universities.courses AS c ON cg.course_id = c.id
+			JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+			WHERE cg.id = $1
+			  AND css.semester_id = $3
+			  AND ud.university_id = $4
+			  AND cg.deleted_at IS NULL
+			FOR NO KEY UPDATE OF cg
+		`
+		qExaminer = `SELECT 1 FROM personalities.teachers WHERE id = $1 FOR NO KEY UPDATE`
+		qRooms    = `
+			SELECT count(*)
+			FROM (
+				SELECT id
+				FROM schedules.rooms
+				WHERE id = ANY($1)
+				  AND university_id = $2
+				ORDER BY id
+				FOR NO KEY UPDATE
+			) AS r
+		`
+		qInsert = `
+			INSERT INTO schedules.exams (session_id, course_semester_subject_id, course_group_id, examiner_id, kind,
+			                             date, start_time, end_time, university_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id
+		`
+		qInsertRooms = `
+			INSERT INTO schedules.exam_rooms (exam_id, room_id, university_id)
+			SELECT $1::bigint, unnest($2::bigint[]), $3::bigint
+		`
+	)
+
+	err = inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var (
+			universityID, semesterID int64
+			minGapDays               int
+			within                   bool
+		)
+		if err := tx.QueryRow(ctx, qSession, e.SessionID, adminID, e.Date).Scan(&universityID, &semesterID, &minGapDays, &within); err != nil {
+			return err
+		}
+		if !within {
+			return ErrExamOutsideSession
+		}
+
+		err := tx.QueryRow(ctx, qGroup, e.CourseGroupID, e.CourseSemesterSubjectID, semesterID, universityID).Scan(&e.CourseGroupID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrReferenceNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		examinerID, err := teacherOf(ctx, tx, e.ExaminerUserID, universityID)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, qExaminer, examinerID); err != nil {
+			return fmt.Errorf("failed to lock examiner: %w", err)
+		}
+
+		var rooms int
+		if err := tx.QueryRow(ctx, qRooms, e.RoomIDs, universityID).Scan(&rooms); err != nil {
+			return fmt.Errorf("failed to lock rooms: %w", err)
+		}
+		if rooms != len(e.RoomIDs) {
+			return ErrReferenceNotFound
+		}
+
+		if err := checkExamConflicts(ctx, tx, e, examinerID, minGapDays); err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, qInsert, e.SessionID, e.CourseSemesterSubjectID, e.CourseGroupID, examinerID, e.Kind,
+			e.Date, e.StartTime, e.EndTime, universityID).Scan(&id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, qInsertRooms, id, e.RoomIDs, universityID)
+		return err
+	})
+	return id, err
+}
+
+// checkExamConflicts checks the exam against exams of its group, and its rooms and examiner against exams,
+// lessons of semesters covering the date and confirmed bookings overlapping it in time. Week parity of every
+// two week lessons is not stored, so they are there every week.
+func checkExamConflicts(ctx context.Context, tx pgx.Tx, e exams.Exam, examinerID int64, minGapDays int) error {
+	const qGroup = `
+		SELECT abs(e.date - $2::date)
+		FROM schedules.exams AS e
+		WHERE e.course_group_id = $1
+		  AND abs(e.date - $2::date) < $3
+		ORDER BY 1
+		LIMIT 1
+	`
+	// lessons of the date with the pair overlapping the exam, $1 is the date, $2 and $3 are the exam time
+	qLessons := fmt.Sprintf(`
+		SELECT gs.room_id, COALESCE(cgs.teacher_id, egs.teacher_id) AS teacher_id
+		FROM schedules.groups_schedules AS gs
+		JOIN schedules.classes AS c ON gs.class_id = c.id
+		LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+		LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+		LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+		LEFT JOIN groups.elective_groups AS eg ON egs.elective_group_id = eg.id
+		JOIN universities.semesters AS s ON s.id = COALESCE(css.semester_id, eg.semester_id)
+		WHERE gs.day = %s
+		  AND $1::date BETWEEN s.start_date::date AND s.end_date::date
+		  AND c.start_time < $3
+		  AND $2 < c.end_time
+	`, fmt.Sprintf(dayOf, "$1::date"))
+	qRooms := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.exam_rooms AS er
+			JOIN schedules.exams AS e ON er.exam_id = e.id
+			WHERE er.room_id = ANY($4)
+			  AND e.date = $1
+			  AND e.start_time < $3
+			  AND $2 < e.end_time
+		)
+		OR EXISTS (SELECT 1 FROM (` + qLessons + `) AS l WHERE l.room_id = ANY($4))
+		OR EXISTS (
+			SELECT 1
+			FROM schedules.room_bookings AS b
+			JOIN schedules.classes AS c ON b.class_id = c.id
+			WHERE b.room_id = ANY($4)
+			  AND b.date = $1
+			  AND b.status = 'confirmed'
+			  AND c.start_time < $3
+			  AND $2 < c.end_time
+		)
+	`
+	qExaminer := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.exams AS e
+			WHERE e.examiner_id = $4
+			  AND e.date = $1
+			  AND e.start_time < $3
+			  AND $2 < e.end_time
+		)
+		OR EXISTS (SELECT 1 FROM (` + qLessons + `) AS l WHERE l.teacher_id = $4)
+	`
+
+	var gap int
+	err := tx.QueryRow(ctx, qGroup, e.CourseGroupID, e.Date, minGapDays).Scan(&gap)
+	switch {
+	case err == nil && gap == 0:
+		return examConflict(ctx, "group", minGapDays)
+	case err == nil:
+		return examConflict(ctx, "gap", minGapDays)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return fmt.Errorf("failed to check exams of the group: %w", err)
+	}
+
+	var busy bool
+	if err := tx.QueryRow(ctx, qRooms, e.Date, e.StartTime, e.EndTime, e.RoomIDs).Scan(&busy); err != nil {
+		return fmt.Errorf("failed to check rooms: %w", err)
+	}
+	if busy {
+		return examConflict(ctx, "room", minGapDays)
+	}
+
+	if err := tx.QueryRow(ctx, qExaminer, e.Date, e.StartTime, e.EndTime, examinerID).Scan(&busy); err != nil {
+		return fmt.Errorf("failed to check examiner: %w", err)
+	}
+	if busy {
+		return examConflict(ctx, "examiner", minGapDays)
+	}
+	return nil
+}
+
+// GetSessionExams returns exams of a session of a university the admin administers ordered by date and time.
+func (r *examsRepository) GetSessionExams(ctx context.Context, adminID, sessionID int64) ([]exams.Exam, error) {
+	q := qExams + fmt.Sprintf(`
+		WHERE e.session_id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "es.university_id")) + qExamsGroup
+
+	rows, err := r.pool.Query(ctx, q, sessionID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	studentExams, err := scanExams(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]exams.Exam, 0, len(studentExams))
+	for _, e := range studentExams {
+		result = append(result, e.Exam)
+	}
+	return result, nil
+}
+
+func (r *examsRepository) DeleteExam(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		DELETE FROM schedules.exams AS e
+		WHERE e.id = $1
+		  AND %s
+	`, fmt.Sprintf(adminOf, "e.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID)
+	})
+}
+
+// GetStudentExams returns exams of published sessions that are not over for course groups of active students
+// of the MAX user, ordered by date and time.
+func (r *examsRepository) GetStudentExams(ctx context.Context, userID int64) ([]exams.StudentExam, error) {
+	q := qExams + `
+		WHERE e.course_group_id IN (
+		        SELECT ps.course_group_id
+		        FROM personalities.students AS ps
+		        WHERE ps.max_user_id = $1
+		          AND NOT ps.is_graduated
+		    )
+		  AND es.published_at IS NOT NULL
+		  AND es.ends_on >= current_date
+	` + qExamsGroup
+
+	rows, err := r.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanExams(rows)
+}
+
+func scanExams(rows pgx.Rows) ([]exams.StudentExam, error) {
+	defer rows.Close()
+
+	var result []exams.StudentExam
+	for rows.Next() {
+		var e exams.StudentExam
+		err := rows.Scan(&e.ID, &e.SessionID, &e.CourseSemesterSubjectID, &e.Subject, &e.CourseGroupID, &e.Group,
+			&e.ExaminerUserID, &e.ExaminerFirstName, &e.ExaminerLastName, &e.Kind, &e.Date, &e.StartTime, &e.EndTime,
+			&e.RoomIDs, &e.Rooms, &e.Session)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
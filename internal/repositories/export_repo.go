@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exports"
+)
+
+type exportRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportRepository(pool *pgxpool.Pool) ExportRepository {
+	return &exportRepository{pool: pool}
+}
+
+// ExportRoster streams active students of live groups of the university ordered by department, course and group.
+func (r *exportRepository) ExportRoster(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Student) error) error {
+	const q = `
+		SELECT u.id, u.username, u.first_name, u.last_name,
+		       cg.id, cg.name, COALESCE(ud.alias_name, d.name), c.year_of_study, c.start_date, c.end_date
+		FROM personalities.students AS s
+		JOIN users.max_users_data AS u ON u.id = s.max_user_id
+		JOIN groups.course_groups AS cg ON cg.id = s.course_group_id
+		JOIN universities.courses AS c ON c.id = cg.course_id
+		JOIN universities.university_departments AS ud ON ud.id = c.university_department_id
+		JOIN universities.departments AS d ON d.id = ud.department_id
+		WHERE ud.university_id = $1
+		  AND ($2::bigint IS NULL OR cg.id = $2)
+		  AND NOT s.is_graduated
+		  AND cg.deleted_at IS NULL
+		  AND c.deleted_at IS NULL
+		  AND ud.deleted_at IS NULL
+		ORDER BY 7, c.year_of_study, c.start_date, cg.name, u.first_name, u.last_name, u.id
+	`
+
+	return exportRows(ctx, r.pool, adminID, filter.UniversityID, q, []any{filter.UniversityID, filter.GroupID},
+		func(rows pgx.Rows) (s exports.Student, err error) {
+			err = rows.Scan(&s.UserID, &s.Username, &s.FirstName, &s.LastName,
+				&s.GroupID, &s.Group, &s.Department, &s.YearOfStudy, &s.StartDate, &s.EndDate)
+			return s, err
+		}, fn)
+}
+
+func (r *exportRepository) ExportTeachers(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Teacher) error) error {
+	const q = `
+		SELECT u.id, u.username, u.first_name, u.last_name
+		FROM personalities.teachers AS t
+		JOIN users.max_users_data AS u ON u.id = t.max_user_id
+		WHERE t.university_id = $1
+		ORDER BY u.first_name, u.last_name, u.id
+	`
+
+	return exportRows(ctx, r.pool, adminID, filter.UniversityID, q, []any{filter.UniversityID},
+		func(rows pgx.Rows) (t exports.Teacher, err error) {
+			err = rows.Scan(&t.UserID, &t.Username, &t.FirstName, &t.LastName)
+			return t, err
+		}, fn)
+}
+
+// ExportTimetable streams lessons of course and elective groups of the university ordered by group or by room,
+// then by day and pair. The group filter keeps lessons of the course group only.
+func (r *exportRepository) ExportTimetable(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Lesson) error) error {
+	// group is the first column, room is the third one
+	order := "1, gs.day, c.pair_number, 3"
+	if filter.Order == exports.ByRoom {
+		order = "3, gs.day, c.pair_number, 1"
+	}
+	q := fmt.Sprintf(`
+		SELECT COALESCE(cg.name, eg.name), eg.id IS NOT NULL, rms.room,
+		       gs.day::text, c.pair_number, c.start_time, c.end_time, gs."interval"::text,
+		       COALESCE(us.name, eus.name), COALESCE(cgs.subject_type::text, egs.subject_type::text),
+		       concat_ws(' ', mud.first_name, mud.last_name)
+		FROM schedules.groups_schedules AS gs
+		JOIN schedules.classes AS c ON gs.class_id = c.id
+		JOIN schedules.rooms AS rms ON gs.room_id = rms.id
+		LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+		LEFT JOIN groups.course_groups AS cg ON cgs.course_group_id = cg.id
+		LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+		LEFT JOIN subjects.university_subjects AS us ON css.university_subject_id = us.id
+		LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+		LEFT JOIN groups.elective_groups AS eg ON egs.elective_group_id = eg.id
+		LEFT JOIN subjects.university_subjects AS eus ON eg.university_subject_id = eus.id
+		LEFT JOIN personalities.teachers AS t ON t.id = COALESCE(cgs.teacher_id, egs.teacher_id)
+		LEFT JOIN users.max_users_data AS mud ON mud.id = t.max_user_id
+		WHERE c.university_id = $1
+		  AND ($2::bigint IS NULL OR cg.id = $2)
+		  AND ($3::bigint IS NULL OR rms.id = $3)
+		  AND cg.deleted_at IS NULL
+		ORDER BY %s
+	`, order)
+
+	return exportRows(ctx, r.pool, adminID, filter.UniversityID, q, []any{filter.UniversityID, filter.GroupID, filter.RoomID},
+		func(rows pgx.Rows) (l exports.Lesson, err error) {
+			var teacher string
+			err = rows.Scan(&l.Group, &l.Elective, &l.Room, &l.Day, &l.PairNumber, &l.StartTime, &l.EndTime,
+				&l.Interval, &l.Subject, &l.SubjectType, &teacher)
+			if teacher != "" {
+				l.Teacher = &teacher
+			}
+			return l, err
+		}, fn)
+}
+
+// ExportAccessRequests streams requests to join the university from the audit log of users.persons_adds.
+// A request is sent to every admin of the university in one transaction, so audit rows of the same
+// transaction make one request. Accepting does not delete requests, deleting one rejects it.
+func (r *exportRepository) ExportAccessRequests(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.AccessRequest) error) error {
+	const q = `
+		WITH requested AS (
+			SELECT (a.after->>'from_max_user_id')::bigint AS user_id,
+			       a.after->>'role_type' AS role,
+			       min(a.created_at) AS requested_at
+			FROM audit.audit_log AS a
+			WHERE a.university_id = $1
+			  AND a.entity_type = 'users.persons_adds'
+			  AND a.action = 'insert'
+			GROUP BY 1, 2, a.request_id
+		)
+		SELECT r.user_id, u.username, u.first_name, u.last_name, r.role, r.requested_at,
+		       CASE
+		           WHEN CASE r.role
+		                    WHEN 'student' THEN EXISTS (
+		                        SELECT 1
+		                        FROM personalities.students s
+		                        JOIN universities.university_departments ud ON ud.id = s.university_department_id
+		                        WHERE s.max_user_id = r.user_id AND ud.university_id = $1)
+		                    WHEN 'teacher' THEN EXISTS (
+		                        SELECT 1 FROM personalities.teachers t WHERE t.max_user_id = r.user_id AND t.university_id = $1)
+		                    ELSE EXISTS (
+		                        SELECT 1 FROM personalities.administrations pa WHERE pa.max_user_id = r.user_id AND pa.university_id = $1)
+		                END THEN 'accepted'
+		           WHEN EXISTS (
+		               SELECT 1
+		               FROM users.persons_adds pad
+		               JOIN personalities.administrations pa ON pa.id = pad.to_administration_id
+		               WHERE pa.university_id = $1
+		                 AND pad.from_max_user_id = r.user_id
+		                 AND pad.role_type::text = r.role) THEN 'pending'
+		           ELSE 'rejected'
+		       END,
+		       rj.created_at, rj.actor_id
+		FROM requested AS r
+		JOIN users.max_users_data AS u ON u.id = r.user_id
+		LEFT JOIN LATERAL (
+			SELECT a.created_at, a.actor_id
+			FROM audit.audit_log AS a
+			WHERE a.university_id = $1
+			  AND a.entity_type = 'users.persons_adds'
+			  AND a.action = 'delete'
+			  AND (a.before->>'from_max_user_id')::bigint = r.user_id
+			  AND a.before->>'role_type' = r.role
+			  AND a.created_at >= r.requested_at
+			ORDER BY a.id
+			LIMIT 1
+		) AS rj ON true
+		ORDER BY r.requested_at, r.user_id
+	`
+
+	return exportRows(ctx, r.pool, adminID, filter.UniversityID, q, []any{filter.UniversityID},
+		func(rows pgx.Rows) (a exports.AccessRequest, err error) {
+			err = rows.Scan(&a.UserID, &a.Username, &a.FirstName, &a.LastName, &a.Role, &a.RequestedAt,
+				&a.Status, &a.RejectedAt, &a.RejectedBy)
+			if a.Status != "rejected" {
+				a.RejectedAt, a.RejectedBy = nil, nil
+			}
+			return a, err
+		}, fn)
+}
+
+// exportRows checks that the user is an admin of the live university and streams rows of the query to fn
+// one by one, so exports of any size are not kept in memory. Errors of fn stop the export.
+func exportRows[T any](ctx context.Context, pool *pgxpool.Pool, adminID, universityID int64, q string, args []any, scan func(pgx.Rows) (T, error), fn func(T) error) error {
+	qAdmin := fmt.Sprintf(`
+		SELECT u.id
+		FROM universities.universities_data AS u
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "u.id"))
+
+	if err := pool.QueryRow(ctx, qAdmin, universityID, adminID).Scan(&universityID); err != nil {
+		return err
+	}
+
+	rows, err := pool.Query(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
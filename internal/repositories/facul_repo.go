@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 )
@@ -33,6 +34,7 @@ func (f *faculRepository) GetFaculsByUserID(ctx context.Context, id int64) ([]mo
             FROM personalities.administrations
             WHERE max_user_id = $1
         )
+          AND uf.deleted_at IS NULL
     `
 	rows, err := f.pool.Query(ctx, query, id)
 	if err != nil {
@@ -59,21 +61,27 @@ func (f *faculRepository) CreateFaculty(ctx context.Context, userID int64, facul
 	query :=
 		`
 	 INSERT INTO universities.faculties (name,university_id)
-	 VALUES ( 
-	 	$1, 
-		(
-		 SELECT uud.id
-	 	 FROM universities.universities_data AS uud
-	 	 WHERE uud.id = (
-	 	 SELECT pa.university_id
-	 	 FROM personalities.administrations AS pa
-	 	 WHERE pa.max_user_id = $2
-		)
+	 SELECT $1, uud.id
+	 FROM universities.universities_data AS uud
+	 WHERE uud.id = (
+	 	SELECT pa.university_id
+	 	FROM personalities.administrations AS pa
+	 	WHERE pa.max_user_id = $2
 	 )
-	)
+	   AND uud.deleted_at IS NULL
 	`
 
-	_, err := f.pool.Exec(ctx, query, facultyName, userID)
+	err := inAuditedTx(ctx, f.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, facultyName, userID)
+		if err != nil {
+			return err
+		}
+		// the university of the admin is deleted
+		if tag.RowsAffected() == 0 {
+			return ErrReferenceNotFound
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed create new faculty. err: %w", err)
 	}
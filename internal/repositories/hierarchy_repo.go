@@ -0,0 +1,484 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+)
+
+// ErrReferenceNotFound is returned when an entity is moved to a parent that does not exist,
+// is deleted or belongs to another university.
+var ErrReferenceNotFound = errors.New("referenced entity not found")
+
+// StillReferencedError is returned when an entity can't be deleted because active students
+// or scheduled lessons use it or anything below it in the hierarchy.
+type StillReferencedError struct {
+	Entity   string
+	Students int64
+	Lessons  int64
+}
+
+func (e *StillReferencedError) Error() string {
+	var refs []string
+	if e.Students > 0 {
+		refs = append(refs, fmt.Sprintf("%d active students", e.Students))
+	}
+	if e.Lessons > 0 {
+		refs = append(refs, fmt.Sprintf("%d scheduled lessons", e.Lessons))
+	}
+	return fmt.Sprintf("%s is still referenced by %s", e.Entity, strings.Join(refs, " and "))
+}
+
+// adminOf checks that max_users_data.id $2 administrates university in column col.
+const adminOf = `EXISTS (SELECT 1 FROM personalities.administrations pa WHERE pa.max_user_id = $2 AND pa.university_id = %s)`
+
+// hierarchy is a set of entities to soft delete together. It is filled by the deleted entity
+// and expanded down to course groups by expand.
+type hierarchy struct {
+	Universities []int64
+	Faculties    []int64
+	Departments  []int64
+	Courses      []int64
+	Groups       []int64
+}
+
+type hierarchyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewHierarchyRepository(pool *pgxpool.Pool) HierarchyRepository {
+	return &hierarchyRepository{pool: pool}
+}
+
+func (r *hierarchyRepository) GetUniversity(ctx context.Context, adminID, id int64) (*models.UniversitiesData, error) {
+	q := fmt.Sprintf(`
+		SELECT uud.id, uc.name, uud.name, COALESCE(uud.short_name, ''), uud.site_url, uud.description, uud.photo_url
+		FROM universities.universities_data AS uud
+		JOIN universities.cities AS uc ON uud.city_id = uc.id
+		WHERE uud.id = $1
+		  AND uud.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "uud.id"))
+
+	var uni models.UniversitiesData
+	err := r.pool.QueryRow(ctx, q, id, adminID).
+		Scan(&uni.ID, &uni.City, &uni.Name, &uni.ShortName, &uni.SiteUrl, &uni.Description, &uni.PhotoUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &uni, nil
+}
+
+func (r *hierarchyRepository) UpdateUniversity(ctx context.Context, adminID int64, uni models.UniversitiesData) error {
+	q := fmt.Sprintf(`
+		UPDATE universities.universities_data AS uud
+		SET name = $3, short_name = NULLIF($4, ''), site_url = $5, description = $6, photo_url = $7
+		WHERE uud.id = $1
+		  AND uud.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "uud.id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, uni.ID, adminID, uni.Name, uni.ShortName, uni.SiteUrl, uni.Description, uni.PhotoUrl)
+	})
+}
+
+func (r *hierarchyRepository) DeleteUniversity(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		SELECT uud.id
+		FROM universities.universities_data AS uud
+		WHERE uud.id = $1
+		  AND uud.deleted_at IS NULL
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "uud.id"))
+
+	return r.softDelete(ctx, "university", q, adminID, id, func(id int64) hierarchy {
+		return hierarchy{Universities: []int64{id}}
+	})
+}
+
+func (r *hierarchyRepository) GetFaculty(ctx context.Context, adminID, id int64) (*models.Faculties, error) {
+	q := fmt.Sprintf(`
+		SELECT uf.id, uf.name, uf.university_id, uud.name
+		FROM universities.faculties AS uf
+		JOIN universities.universities_data AS uud ON uf.university_id = uud.id
+		WHERE uf.id = $1
+		  AND uf.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "uf.university_id"))
+
+	var faculty models.Faculties
+	if err := r.pool.QueryRow(ctx, q, id, adminID).Scan(&faculty.ID, &faculty.Name, &faculty.UniversityID, &faculty.UniversityName); err != nil {
+		return nil, err
+	}
+	return &faculty, nil
+}
+
+func (r *hierarchyRepository) UpdateFaculty(ctx context.Context, adminID, id int64, name string) error {
+	q := fmt.Sprintf(`
+		UPDATE universities.faculties AS uf
+		SET name = $3
+		WHERE uf.id = $1
+		  AND uf.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "uf.university_id"))
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return execOne(ctx, tx, q, id, adminID, name)
+	})
+}
+
+func (r *hierarchyRepository) DeleteFaculty(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		SELECT uf.id
+		FROM universities.faculties AS uf
+		WHERE uf.id = $1
+		  AND uf.deleted_at IS NULL
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "uf.university_id"))
+
+	return r.softDelete(ctx, "faculty", q, adminID, id, func(id int64) hierarchy {
+		return hierarchy{Faculties: []int64{id}}
+	})
+}
+
+func (r *hierarchyRepository) GetDepartment(ctx context.Context, adminID, id int64) (*models.UniversityDepartment, error) {
+	q := fmt.Sprintf(`
+		SELECT ud.id, d.id, d.name, d.code, ud.alias_name, ud.faculty_id, ud.university_id, ud.study_years
+		FROM universities.university_departments AS ud
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		WHERE ud.id = $1
+		  AND ud.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	var dep models.UniversityDepartment
+	err := r.pool.QueryRow(ctx, q, id, adminID).
+		Scan(&dep.ID, &dep.DepartmentID, &dep.Name, &dep.Code, &dep.AliasName, &dep.FacultyID, &dep.UniversityID, &dep.StudyYears)
+	if err != nil {
+		return nil, err
+	}
+	return &dep, nil
+}
+
+// UpdateDepartment renames university department (alias_name) and moves it to another faculty of the same university,
+// study years are changed only if studyYears is not nil.
+// departments itself is a dictionary shared by universities and is not changed.
+func (r *hierarchyRepository) UpdateDepartment(ctx context.Context, adminID, id int64, aliasName *string, facultyID int64, studyYears *int) error {
+	q := fmt.Sprintf(`
+		UPDATE universities.university_departments AS ud
+		SET alias_name = $3, faculty_id = $4, study_years = COALESCE($5, ud.study_years)
+		WHERE ud.id = $1
+		  AND ud.deleted_at IS NULL
+		  AND %s
+		RETURNING ud.university_id
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+	const qParent = `SELECT university_id FROM universities.faculties WHERE id = $1 AND deleted_at IS NULL`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var universityID int64
+		if err := tx.QueryRow(ctx, q, id, adminID, aliasName, facultyID, studyYears).Scan(&universityID); err != nil {
+			return err
+		}
+		return checkParent(ctx, tx, qParent, facultyID, universityID)
+	})
+}
+
+func (r *hierarchyRepository) DeleteDepartment(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		SELECT ud.id
+		FROM universities.university_departments AS ud
+		WHERE ud.id = $1
+		  AND ud.deleted_at IS NULL
+		  AND %s
+		FOR UPDATE
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	return r.softDelete(ctx, "department", q, adminID, id, func(id int64) hierarchy {
+		return hierarchy{Departments: []int64{id}}
+	})
+}
+
+func (r *hierarchyRepository) GetCourse(ctx context.Context, adminID, id int64) (*models.Course, error) {
+	q := fmt.Sprintf(`
+		SELECT c.id, c.start_date, c.end_date, c.university_department_id, c.year_of_study
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE c.id = $1
+		  AND c.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	var course models.Course
+	if err := r.pool.QueryRow(ctx, q, id, adminID).Scan(&course.ID, &course.StartDate, &course.EndDate, &course.UniversityDepartment, &course.YearOfStudy); err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// UpdateCourse changes course dates and year of study and moves it to another university department of the same university.
+func (r *hierarchyRepository) UpdateCourse(ctx context.Context, adminID int64, course models.Course) error {
+	q := fmt.Sprintf(`
+		UPDATE universities.courses AS c
+		SET start_date = $3, end_date = $4, university_department_id = $5, year_of_study = $6
+		FROM universities.university_departments AS ud
+		WHERE c.id = $1
+		  AND c.deleted_at IS NULL
+		  AND ud.id = c.university_department_id
+		  AND %s
+		RETURNING ud.university_id
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+	const qParent = `SELECT university_id FROM universities.university_departments WHERE id = $1 AND deleted_at IS NULL`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var universityID int64
+		err := tx.QueryRow(ctx, q, course.ID, adminID, course.StartDate, course.EndDate, course.UniversityDepartment, course.YearOfStudy).Scan(&universityID)
+		if err != nil {
+			return err
+		}
+		return checkParent(ctx, tx, qParent, course.UniversityDepartment, universityID)
+	})
+}
+
+func (r *hierarchyRepository) DeleteCourse(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		SELECT c.id
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE c.id = $1
+		  AND c.deleted_at IS NULL
+		  AND %s
+		FOR UPDATE OF c
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	return r.softDelete(ctx, "course", q, adminID, id, func(id int64) hierarchy {
+		return hierarchy{Courses: []int64{id}}
+	})
+}
+
+// GetGroups returns live course groups of admin's universities, of courseID only if it is not 0.
+func (r *hierarchyRepository) GetGroups(ctx context.Context, adminID, courseID int64) ([]models.Groups, error) {
+	q := fmt.Sprintf(`
+		SELECT cg.id, cg.name, cg.course_id, d.code, d.name
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON cg.course_id = c.id
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		WHERE ($1 = 0 OR cg.course_id = $1)
+		  AND cg.deleted_at IS NULL
+		  AND c.deleted_at IS NULL
+		  AND %s
+		ORDER BY cg.name
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	rows, err := r.pool.Query(ctx, q, courseID, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []models.Groups
+	for rows.Next() {
+		var group models.Groups
+		if err := rows.Scan(&group.ID, &group.Name, &group.CourseID, &group.Code, &group.DepartmentName); err != nil {
+			return nil, fmt.Errorf("failed to scan group row: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+func (r *hierarchyRepository) GetGroup(ctx context.Context, adminID, id int64) (*models.Groups, error) {
+	q := fmt.Sprintf(`
+		SELECT cg.id, cg.name, cg.course_id, d.code, d.name
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON cg.course_id = c.id
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	var group models.Groups
+	if err := r.pool.QueryRow(ctx, q, id, adminID).Scan(&group.ID, &group.Name, &group.CourseID, &group.Code, &group.DepartmentName); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// UpdateGroup renames course group and moves it to another course of the same university.
+func (r *hierarchyRepository) UpdateGroup(ctx context.Context, adminID, id int64, name string, courseID int64) error {
+	q := fmt.Sprintf(`
+		UPDATE groups.course_groups AS cg
+		SET name = $3, course_id = $4
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND c.id = cg.course_id
+		  AND %s
+		RETURNING ud.university_id
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+	const qParent = `
+		SELECT ud.university_id
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+	`
+
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var universityID int64
+		if err := tx.QueryRow(ctx, q, id, adminID, name, courseID).Scan(&universityID); err != nil {
+			return err
+		}
+		return checkParent(ctx, tx, qParent, courseID, universityID)
+	})
+}
+
+func (r *hierarchyRepository) DeleteGroup(ctx context.Context, adminID, id int64) error {
+	q := fmt.Sprintf(`
+		SELECT cg.id
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON cg.course_id = c.id
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND %s
+		FOR UPDATE OF cg
+	`, fmt.Sprintf(adminOf, "ud.university_id"))
+
+	return r.softDelete(ctx, "group", q, adminID, id, func(id int64) hierarchy {
+		return hierarchy{Groups: []int64{id}}
+	})
+}
+
+// softDelete locks the entity with qLock (returns its id, checks admin), then marks it and everything
+// below it as deleted. Deletion is refused with StillReferencedError while active students or
+// scheduled lessons use any of these entities.
+func (r *hierarchyRepository) softDelete(ctx context.Context, entity, qLock string, adminID, id int64, root func(id int64) hierarchy) error {
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		var lockedID int64
+		if err := tx.QueryRow(ctx, qLock, id, adminID).Scan(&lockedID); err != nil {
+			return err
+		}
+
+		h := root(lockedID)
+		if err := h.expand(ctx, tx); err != nil {
+			return err
+		}
+
+		ref, err := h.references(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if ref.Students > 0 || ref.Lessons > 0 {
+			ref.Entity = entity
+			return ref
+		}
+
+		return h.markDeleted(ctx, tx)
+	})
+}
+
+// expand adds live children of all entities in h, level by level.
+func (h *hierarchy) expand(ctx context.Context, tx pgx.Tx) error {
+	const q = `
+		WITH f AS (
+			SELECT id FROM universities.faculties
+			WHERE deleted_at IS NULL AND (id = ANY($2) OR university_id = ANY($1))
+		), ud AS (
+			SELECT id FROM universities.university_departments
+			WHERE deleted_at IS NULL AND (id = ANY($3) OR university_id = ANY($1) OR faculty_id IN (SELECT id FROM f))
+		), c AS (
+			SELECT id FROM universities.courses
+			WHERE deleted_at IS NULL AND (id = ANY($4) OR university_department_id IN (SELECT id FROM ud))
+		), cg AS (
+			SELECT id FROM groups.course_groups
+			WHERE deleted_at IS NULL AND (id = ANY($5) OR course_id IN (SELECT id FROM c))
+		)
+		SELECT ARRAY(SELECT id FROM f), ARRAY(SELECT id FROM ud), ARRAY(SELECT id FROM c), ARRAY(SELECT id FROM cg)
+	`
+
+	return tx.QueryRow(ctx, q, h.Universities, h.Faculties, h.Departments, h.Courses, h.Groups).
+		Scan(&h.Faculties, &h.Departments, &h.Courses, &h.Groups)
+}
+
+// references counts active students and scheduled lessons of entities in h. Elective groups belong to
+// a semester of the university rather than to a department or a course group, so their lessons only
+// block deleting the university, their students are counted by departments.
+func (h *hierarchy) references(ctx context.Context, tx pgx.Tx) (*StillReferencedError, error) {
+	const q = `
+		SELECT
+			(SELECT count(*) FROM personalities.students s
+			 WHERE NOT s.is_graduated
+			   AND (s.university_department_id = ANY($2) OR s.course_group_id = ANY($3))),
+			(SELECT count(*) FROM schedules.groups_schedules gs
+			 LEFT JOIN subjects.course_group_subjects cgs ON gs.course_group_subjet_id = cgs.id
+			 LEFT JOIN subjects.elective_group_subjects egs ON gs.elective_group_subject_id = egs.id
+			 LEFT JOIN groups.elective_groups eg ON egs.elective_group_id = eg.id
+			 LEFT JOIN universities.semesters es ON eg.semester_id = es.id
+			 JOIN schedules.classes cl ON gs.class_id = cl.id
+			 WHERE cgs.course_group_id = ANY($3) OR es.university_id = ANY($1) OR cl.university_id = ANY($1))
+	`
+
+	var ref StillReferencedError
+	if err := tx.QueryRow(ctx, q, h.Universities, h.Departments, h.Groups).Scan(&ref.Students, &ref.Lessons); err != nil {
+		return nil, fmt.Errorf("failed to count references: %w", err)
+	}
+	return &ref, nil
+}
+
+func (h *hierarchy) markDeleted(ctx context.Context, tx pgx.Tx) error {
+	steps := []struct {
+		table string
+		ids   []int64
+	}{
+		{"groups.course_groups", h.Groups},
+		{"universities.courses", h.Courses},
+		{"universities.university_departments", h.Departments},
+		{"universities.faculties", h.Faculties},
+		{"universities.universities_data", h.Universities},
+	}
+
+	for _, step := range steps {
+		if len(step.ids) == 0 {
+			continue
+		}
+		q := `UPDATE ` + step.table + ` SET deleted_at = now() WHERE id = ANY($1) AND deleted_at IS NULL`
+		if _, err := tx.Exec(ctx, q, step.ids); err != nil {
+			return fmt.Errorf("failed to delete from %s: %w", step.table, err)
+		}
+	}
+	return nil
+}
+
+// checkParent returns ErrReferenceNotFound unless live parent id belongs to universityID.
+func checkParent(ctx context.Context, tx pgx.Tx, q string, id, universityID int64) error {
+	var parentUniversityID int64
+	err := tx.QueryRow(ctx, q, id).Scan(&parentUniversityID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && parentUniversityID != universityID) {
+		return ErrReferenceNotFound
+	}
+	return err
+}
+
+// execOne runs update and returns pgx.ErrNoRows if it changed nothing.
+func execOne(ctx context.Context, tx pgx.Tx, q string, args ...any) error {
+	tag, err := tx.Exec(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+)
+
+type impersonationRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImpersonationRepository(pool *pgxpool.Pool) ImpersonationRepository {
+	return &impersonationRepository{pool: pool}
+}
+
+// CanImpersonate reports whether actor may act as user: user is not an admin, and every university where
+// user is a teacher or a student is administered by actor. The token is the full identity of user,
+// so a university of user outside of actor's ones would be readable through it.
+func (r *impersonationRepository) CanImpersonate(ctx context.Context, actorID, userID int64) (bool, error) {
+	const q = `
+		WITH target AS (
+			SELECT t.university_id FROM personalities.teachers t WHERE t.max_user_id = $2
+			UNION
+			SELECT ud.university_id FROM personalities.students ps
+			JOIN universities.university_departments ud ON ps.university_department_id = ud.id
+			WHERE ps.max_user_id = $2
+		)
+		SELECT NOT EXISTS (SELECT 1 FROM personalities.administrations a WHERE a.max_user_id = $2)
+		   AND EXISTS (SELECT 1 FROM target)
+		   AND NOT EXISTS (
+			SELECT 1
+			FROM target
+			WHERE NOT EXISTS (
+				SELECT 1
+				FROM personalities.administrations pa
+				WHERE pa.max_user_id = $1
+				  AND pa.university_id = target.university_id
+			)
+		   )
+	`
+
+	var ok bool
+	if err := r.pool.QueryRow(ctx, q, actorID, userID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed CanImpersonate from db. err: %w", err)
+	}
+
+	return ok, nil
+}
+
+func (r *impersonationRepository) LogRequest(ctx context.Context, entry models.ImpersonationAuditEntry) error {
+	const q = `
+		INSERT INTO users.impersonation_audit (actor_id, user_id, method, path, status, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.pool.Exec(ctx, q, entry.ActorID, entry.UserID, entry.Method, entry.Path, entry.Status, toNullString(&entry.RequestID))
+	if err != nil {
+		return fmt.Errorf("failed LogRequest to db. err: %w", err)
+	}
+
+	return nil
+}
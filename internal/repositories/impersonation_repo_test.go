@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCanImpersonate(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+
+	base := time.Now().UnixNano()
+	actorID, adminID, localID, sharedID := base, base+1, base+2, base+3
+	users := []int64{actorID, adminID, localID, sharedID}
+	var cityID, uniA, uniB int64
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, id := range users {
+		testUser(t, tx, id)
+	}
+
+	steps := []struct {
+		q    string
+		args []any
+		dest *int64
+	}{
+		{`INSERT INTO universities.cities (name) VALUES ('Test') RETURNING id`, nil, &cityID},
+		{`INSERT INTO universities.universities_data (name, city_id) VALUES ('Test A', $1) RETURNING id`, []any{&cityID}, &uniA},
+		{`INSERT INTO universities.universities_data (name, city_id) VALUES ('Test B', $1) RETURNING id`, []any{&cityID}, &uniB},
+	}
+	for _, s := range steps {
+		args := make([]any, len(s.args))
+		for i, a := range s.args {
+			args[i] = *a.(*int64)
+		}
+		if err := tx.QueryRow(ctx, s.q, args...).Scan(s.dest); err != nil {
+			t.Fatalf("failed to insert fixture %q: %v", s.q, err)
+		}
+	}
+
+	// actor administers A only, the other admin of A can't be impersonated, the shared teacher also works in B
+	roles := []struct {
+		q      string
+		userID int64
+		uniID  int64
+	}{
+		{`INSERT INTO personalities.administrations (max_user_id, university_id) VALUES ($1, $2)`, actorID, uniA},
+		{`INSERT INTO personalities.administrations (max_user_id, university_id) VALUES ($1, $2)`, adminID, uniA},
+		{`INSERT INTO personalities.teachers (max_user_id, university_id) VALUES ($1, $2)`, localID, uniA},
+		{`INSERT INTO personalities.teachers (max_user_id, university_id) VALUES ($1, $2)`, sharedID, uniA},
+		{`INSERT INTO personalities.teachers (max_user_id, university_id) VALUES ($1, $2)`, sharedID, uniB},
+	}
+	for _, r := range roles {
+		if _, err := tx.Exec(ctx, r.q, r.userID, r.uniID); err != nil {
+			t.Fatalf("failed to insert fixture %q: %v", r.q, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit fixture: %v", err)
+	}
+
+	t.Cleanup(func() {
+		cleanup := []struct {
+			q  string
+			id int64
+		}{
+			{`DELETE FROM personalities.teachers WHERE university_id = $1`, uniA},
+			{`DELETE FROM personalities.teachers WHERE university_id = $1`, uniB},
+			{`DELETE FROM personalities.administrations WHERE university_id = $1`, uniA},
+			{`DELETE FROM universities.universities_data WHERE id = $1`, uniA},
+			{`DELETE FROM universities.universities_data WHERE id = $1`, uniB},
+			{`DELETE FROM universities.cities WHERE id = $1`, cityID},
+		}
+		for _, c := range cleanup {
+			if _, err := pool.Exec(context.Background(), c.q, c.id); err != nil {
+				t.Errorf("failed to clean up %q: %v", c.q, err)
+			}
+		}
+		for _, id := range users {
+			if _, err := pool.Exec(context.Background(), `DELETE FROM users.max_users_data WHERE id = $1`, id); err != nil {
+				t.Errorf("failed to clean up user %d: %v", id, err)
+			}
+		}
+	})
+
+	repo := NewImpersonationRepository(pool)
+	tests := []struct {
+		name   string
+		userID int64
+		want   bool
+	}{
+		{"teacher of the actor's university", localID, true},
+		{"admin of the actor's university", adminID, false},
+		{"teacher of the actor's and another university", sharedID, false},
+		{"the actor itself", actorID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.CanImpersonate(ctx, actorID, tt.userID)
+			if err != nil {
+				t.Fatalf("CanImpersonate = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("CanImpersonate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/imports"
+)
+
+// RowError is a row of an imported file referencing missing data or clashing with existing one.
+type RowError struct {
+	Column  string
+	Message string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Column, e.Message)
+}
+
+type importRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewImportRepository(pool *pgxpool.Pool) ImportRepository {
+	return &importRepository{pool: pool}
+}
+
+func (r *importRepository) ImportRooms(ctx context.Context, adminID, universityID int64, mode imports.Mode, rooms []imports.Room) (*imports.Result, error) {
+	const q = `
+		INSERT INTO schedules.rooms (university_id, room)
+		VALUES ($1, $2)
+		ON CONFLICT (university_id, room) DO NOTHING
+		RETURNING id
+	`
+
+	return importRows(ctx, r.pool, adminID, universityID, rooms, func(room imports.Room) int { return room.Row },
+		func(tx pgx.Tx, room imports.Room) (imports.Outcome, error) {
+			var id int64
+			err := tx.QueryRow(ctx, q, universityID, room.Name).Scan(&id)
+			return inserted(err, mode, "room")
+		})
+}
+
+// ImportClasses imports the bell schedule, upsert changes times of existing pairs.
+func (r *importRepository) ImportClasses(ctx context.Context, adminID, universityID int64, mode imports.Mode, classes []imports.Class) (*imports.Result, error) {
+	const qCreate = `
+		INSERT INTO schedules.classes (university_id, pair_number, start_time, end_time)
+		VALUES ($1, $2, $3::time, $4::time)
+		ON CONFLICT (university_id, pair_number) DO NOTHING
+		RETURNING true
+	`
+	// no row is returned for unchanged pairs, xmax is 0 for inserted ones
+	const qUpsert = `
+		INSERT INTO schedules.classes AS cl (university_id, pair_number, start_time, end_time)
+		VALUES ($1, $2, $3::time, $4::time)
+		ON CONFLICT (university_id, pair_number) DO UPDATE
+		SET start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time
+		WHERE (cl.start_time, cl.end_time) IS DISTINCT FROM (EXCLUDED.start_time, EXCLUDED.end_time)
+		RETURNING cl.xmax = 0
+	`
+
+	q := qCreate
+	if mode == imports.Upsert {
+		q = qUpsert
+	}
+
+	return importRows(ctx, r.pool, adminID, universityID, classes, func(class imports.Class) int { return class.Row },
+		func(tx pgx.Tx, class imports.Class) (imports.Outcome, error) {
+			var created bool
+			err := tx.QueryRow(ctx, q, universityID, class.PairNumber, class.StartTime, class.EndTime).Scan(&created)
+			if err == nil && !created {
+				return imports.Updated, nil
+			}
+			return inserted(err, mode, "pair_number")
+		})
+}
+
+func (r *importRepository) ImportSubjects(ctx context.Context, adminID, universityID int64, mode imports.Mode, subjects []imports.Subject) (*imports.Result, error) {
+	const q = `
+		INSERT INTO subjects.university_subjects (university_id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (university_id, name) DO NOTHING
+		RETURNING id
+	`
+
+	return importRows(ctx, r.pool, adminID, universityID, subjects, func(subject imports.Subject) int { return subject.Row },
+		func(tx pgx.Tx, subject imports.Subject) (imports.Outcome, error) {
+			var id int64
+			err := tx.QueryRow(ctx, q, universityID, subject.Name).Scan(&id)
+			return inserted(err, mode, "name")
+		})
+}
+
+// ImportGroups imports course groups, courses are found by department, dates and year of study and created if missing.
+func (r *importRepository) ImportGroups(ctx context.Context, adminID, universityID int64, mode imports.Mode, groups []imports.Group) (*imports.Result, error) {
+	const qDepartment = `
+		SELECT ud.id
+		FROM universities.university_departments AS ud
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		WHERE ud.university_id = $1
+		  AND ud.deleted_at IS NULL
+		  AND (d.code = $2 OR ud.alias_name = $2)
+		LIMIT 2
+	`
+	const qGroup = `
+		INSERT INTO groups.course_groups (name, course_id)
+		VALUES ($1, $2)
+		ON CONFLICT (name, course_id) WHERE deleted_at IS NULL DO NOTHING
+		RETURNING id
+	`
+
+	return importRows(ctx, r.pool, adminID, universityID, groups, func(group imports.Group) int { return group.Row },
+		func(tx pgx.Tx, group imports.Group) (imports.Outcome, error) {
+			rows, err := tx.Query(ctx, qDepartment, universityID, group.Department)
+			if err != nil {
+				return 0, err
+			}
+			ids, err := scanIDs(rows)
+			if err != nil {
+				return 0, err
+			}
+			switch len(ids) {
+			case 0:
+				return 0, &RowError{Column: "department", Message: "department not found"}
+			case 1:
+			default:
+				return 0, &RowError{Column: "department", Message: "several departments have this code, use alias name"}
+			}
+
+			courseID, _, err := findOrCreateCourse(ctx, tx, ids[0], group.StartDate, group.EndDate, group.YearOfStudy)
+			if err != nil {
+				return 0, err
+			}
+
+			var id int64
+			err = tx.QueryRow(ctx, qGroup, group.Name, courseID).Scan(&id)
+			return inserted(err, mode, "name")
+		})
+}
+
+// ImportRoster makes MAX users students of groups or teachers of the university, as accepted access requests do.
+func (r *importRepository) ImportRoster(ctx context.Context, adminID, universityID int64, mode imports.Mode, entries []imports.RosterEntry) (*imports.Result, error) {
+	const (
+		qStudent = `
+			INSERT INTO personalities.students (max_user_id, university_department_id, course_group_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (max_user_id, course_group_id) DO NOTHING
+			RETURNING id
+		`
+		qTeacher = `
+			INSERT INTO personalities.teachers (max_user_id, university_id)
+			VALUES ($1, $2)
+			ON CONFLICT (max_user_id, university_id) DO NOTHING
+			RETURNING id
+		`
+	)
+
+	return importRows(ctx, r.pool, adminID, universityID, entries, func(entry imports.RosterEntry) int { return entry.Row },
+		func(tx pgx.Tx, entry imports.RosterEntry) (imports.Outcome, error) {
+			userID, err := rosterUser(ctx, tx, entry)
+			if err != nil {
+				return 0, err
+			}
+
+			var id int64
+			switch entry.Role {
+			case imports.Teacher:
+				err = tx.QueryRow(ctx, qTeacher, userID, universityID).Scan(&id)
+			default:
+				groupID, departmentID, gErr := rosterGroup(ctx, tx, universityID, entry)
+				if gErr != nil {
+					return 0, gErr
+				}
+				err = tx.QueryRow(ctx, qStudent, userID, departmentID, groupID).Scan(&id)
+			}
+
+			outcome, err := inserted(err, mode, "user")
+			if err != nil || outcome != imports.Created {
+				return outcome, err
+			}
+			// roles changed -> tokens issued before must be re-issued
+			return outcome, bumpTokenVersion(ctx, tx, userID)
+		})
+}
+
+// importRows applies rows of admin's university in one transaction. Every row is applied in a savepoint:
+// a row failing with *RowError or a database error is rolled back and reported, other errors abort the import.
+func importRows[T any](ctx context.Context, pool *pgxpool.Pool, adminID, universityID int64, rows []T, rowOf func(T) int, apply func(pgx.Tx, T) (imports.Outcome, error)) (*imports.Result, error) {
+	q := fmt.Sprintf(`
+		SELECT u.id
+		FROM universities.universities_data AS u
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+		  AND %s
+	`, fmt.Sprintf(adminOf, "u.id"))
+
+	result := &imports.Result{}
+	err := inAuditedTx(ctx, pool, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, q, universityID, adminID).Scan(&universityID); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			sp, err := tx.Begin(ctx)
+			if err != nil {
+				return err
+			}
+
+			outcome, err := apply(sp, row)
+			if err == nil {
+				if err := sp.Commit(ctx); err != nil {
+					return err
+				}
+				result.Add(outcome)
+				continue
+			}
+
+			var rowErr *RowError
+			var pgErr *pgconn.PgError
+			if !errors.As(err, &rowErr) && !errors.As(err, &pgErr) {
+				_ = sp.Rollback(ctx)
+				return err
+			}
+			if err := sp.Rollback(ctx); err != nil {
+				return err
+			}
+			result.Failures = append(result.Failures, imports.Failure{Row: rowOf(row), Err: err})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// inserted converts the result of INSERT ... ON CONFLICT DO NOTHING RETURNING, no row is an existing natural key:
+// an error of column for Create mode and unchanged row for Upsert.
+func inserted(err error, mode imports.Mode, column string) (imports.Outcome, error) {
+	switch {
+	case err == nil:
+		return imports.Created, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return 0, err
+	case mode == imports.Upsert:
+		return imports.Unchanged, nil
+	default:
+		return 0, &RowError{Column: column, Message: "already exists"}
+	}
+}
+
+// findOrCreateCourse returns live course of the department with the dates and year of study, creating it if missing.
+func findOrCreateCourse(ctx context.Context, tx pgx.Tx, departmentID int64, startDate, endDate time.Time, yearOfStudy *int) (int64, bool, error) {
+	const qFind = `
+		SELECT id
+		FROM universities.courses
+		WHERE university_department_id = $1
+		  AND start_date = $2
+		  AND end_date = $3
+		  AND year_of_study IS NOT DISTINCT FROM $4
+		  AND deleted_at IS NULL
+	`
+	const qCreate = `
+		INSERT INTO universities.courses (university_department_id, start_date, end_date, year_of_study)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int64
+	err := tx.QueryRow(ctx, qFind, departmentID, startDate, endDate, yearOfStudy).Scan(&id)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return id, false, err
+	}
+	err = tx.QueryRow(ctx, qCreate, departmentID, startDate, endDate, yearOfStudy).Scan(&id)
+	return id, true, err
+}
+
+// rosterUser finds the MAX user of the roster entry, a user given by id is created if missing and first name is known.
+func rosterUser(ctx context.Context, tx pgx.Tx, entry imports.RosterEntry) (int64, error) {
+	const (
+		qByName = `SELECT id FROM users.max_users_data WHERE lower(username) = lower($1) LIMIT 2`
+		qExists = `SELECT EXISTS (SELECT 1 FROM users.max_users_data WHERE id = $1)`
+		qCreate = `
+			INSERT INTO users.max_users_data (id, first_name, last_name)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (id) DO NOTHING
+		`
+	)
+
+	if entry.UserID == 0 {
+		rows, err := tx.Query(ctx, qByName, entry.Username)
+		if err != nil {
+			return 0, err
+		}
+		ids, err := scanIDs(rows)
+		if err != nil {
+			return 0, err
+		}
+		switch len(ids) {
+		case 0:
+			return 0, &RowError{Column: "username", Message: "unknown username, the user has to open the bot first or be given by max_user_id"}
+		case 1:
+			return ids[0], nil
+		default:
+			return 0, &RowError{Column: "username", Message: "several users have this username, use max_user_id"}
+		}
+	}
+
+	if entry.FirstName != "" {
+		var lastName *string
+		if entry.LastName != "" {
+			lastName = &entry.LastName
+		}
+		_, err := tx.Exec(ctx, qCreate, entry.UserID, entry.FirstName, lastName)
+		return entry.UserID, err
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, qExists, entry.UserID).Scan(&exists); err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, &RowError{Column: "first_name", Message: "unknown MAX user, first_name is required to create it"}
+	}
+	return entry.UserID, nil
+}
+
+// rosterGroup finds the group of the roster entry in the university: by id or by name among groups of current courses,
+// that is not finished and not rolled over.
+func rosterGroup(ctx context.Context, tx pgx.Tx, universityID int64, entry imports.RosterEntry) (int64, int64, error) {
+	const q = `
+		SELECT cg.id, c.university_department_id
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON cg.course_id = c.id
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		WHERE ud.university_id = $1
+		  AND (cg.id = $2 OR ($2 = 0 AND cg.name = $3 AND c.rollover_id IS NULL AND c.end_date > now()))
+		  AND cg.deleted_at IS NULL
+		  AND c.deleted_at IS NULL
+		LIMIT 2
+	`
+
+	rows, err := tx.Query(ctx, q, universityID, entry.GroupID, entry.Group)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var groupID, departmentID int64
+	found := 0
+	for rows.Next() {
+		if err := rows.Scan(&groupID, &departmentID); err != nil {
+			return 0, 0, err
+		}
+		found++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	column := "group"
+	if entry.GroupID != 0 {
+		column = "group_id"
+	}
+	switch found {
+	case 0:
+		return 0, 0, &RowError{Column: column, Message: "group not found"}
+	case 1:
+		return groupID, departmentID, nil
+	default:
+		return 0, 0, &RowError{Column: column, Message: "several current groups have this name, use group_id"}
+	}
+}
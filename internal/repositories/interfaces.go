@@ -6,7 +6,16 @@ import (
 
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	personalities2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/http/personalities"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/audit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/bookings"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/electives"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exams"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/imports"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/personalities"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/rollover"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/schedules"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/subjects"
 )
@@ -25,6 +34,31 @@ type RefreshTokenRepository interface {
 	DeleteByUser(userID int) error
 }
 
+type TokenVersionRepository interface {
+	GetTokenVersion(ctx context.Context, userID int64) (int64, error)
+	BumpTokenVersion(ctx context.Context, userID int64) error
+	ListenTokenVersions(ctx context.Context, onReady func(), onBump func(userID int64)) error
+}
+
+type JWTKeysRepository interface {
+	GetActiveKeys(ctx context.Context, alg string) ([]models.JWTKey, error)
+	RotateKey(ctx context.Context, alg string, staleBefore time.Time, generate func() (models.JWTKey, error)) (bool, error)
+}
+
+type ImpersonationRepository interface {
+	CanImpersonate(ctx context.Context, actorID, userID int64) (bool, error)
+	LogRequest(ctx context.Context, entry models.ImpersonationAuditEntry) error
+}
+
+type RateLimitRepository interface {
+	Take(ctx context.Context, key string, rate, burst float64) (allowed bool, tokens float64, err error)
+	DeleteIdle(ctx context.Context, before time.Time) error
+}
+
+type AuditRepository interface {
+	GetAuditLog(ctx context.Context, filter audit.Filter) ([]audit.Entry, error)
+}
+
 type UniRepository interface {
 	GetAllUniversities(ctx context.Context) ([]models.UniversitiesData, error)
 
@@ -34,7 +68,7 @@ type UniRepository interface {
 
 	CreateNewDepartment(ctx context.Context, departmentName, departmentCode, aliasName string, facultyID, universityID int64) error
 
-	CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64) error
+	CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64, yearOfStudy *int) error
 
 	GetAllCoursesByUniversityID(ctx context.Context, universityID int64) ([]models.Course, error)
 
@@ -58,6 +92,140 @@ type PersonalitiesRepository interface {
 	GetAllTeachersForUniversity(ctx context.Context, universityID int64) ([]models.User, error)
 }
 
+// HierarchyRepository reads, updates and soft deletes universities, faculties, university departments,
+// courses and groups. Entities of universities not administrated by adminID are reported as pgx.ErrNoRows.
+type HierarchyRepository interface {
+	GetUniversity(ctx context.Context, adminID, id int64) (*models.UniversitiesData, error)
+	UpdateUniversity(ctx context.Context, adminID int64, uni models.UniversitiesData) error
+	DeleteUniversity(ctx context.Context, adminID, id int64) error
+
+	GetFaculty(ctx context.Context, adminID, id int64) (*models.Faculties, error)
+	UpdateFaculty(ctx context.Context, adminID, id int64, name string) error
+	DeleteFaculty(ctx context.Context, adminID, id int64) error
+
+	GetDepartment(ctx context.Context, adminID, id int64) (*models.UniversityDepartment, error)
+	UpdateDepartment(ctx context.Context, adminID, id int64, aliasName *string, facultyID int64, studyYears *int) error
+	DeleteDepartment(ctx context.Context, adminID, id int64) error
+
+	GetCourse(ctx context.Context, adminID, id int64) (*models.Course, error)
+	UpdateCourse(ctx context.Context, adminID int64, course models.Course) error
+	DeleteCourse(ctx context.Context, adminID, id int64) error
+
+	GetGroups(ctx context.Context, adminID, courseID int64) ([]models.Groups, error)
+	GetGroup(ctx context.Context, adminID, id int64) (*models.Groups, error)
+	UpdateGroup(ctx context.Context, adminID, id int64, name string, courseID int64) error
+	DeleteGroup(ctx context.Context, adminID, id int64) error
+}
+
+// CurriculumRepository manages subjects of courses by semester and teachers assigned to course and elective groups.
+type CurriculumRepository interface {
+	GetSemesters(ctx context.Context, adminID int64) ([]curriculum.Semester, error)
+
+	AddCourseSubject(ctx context.Context, adminID int64, cs curriculum.CourseSubject) (int64, error)
+	GetCourseSubjects(ctx context.Context, adminID, courseID, semesterID int64) ([]curriculum.CourseSubject, error)
+	UpdateCourseSubjectHours(ctx context.Context, adminID, id int64, hours *int) error
+	DeleteCourseSubject(ctx context.Context, adminID, id int64) error
+
+	GetGroupCurriculum(ctx context.Context, adminID, groupID, semesterID int64) ([]curriculum.CurriculumItem, error)
+	AssignGroupSubject(ctx context.Context, adminID int64, gs curriculum.GroupSubject) (int64, error)
+	UpdateGroupSubject(ctx context.Context, adminID int64, gs curriculum.GroupSubject) error
+	DeleteGroupSubject(ctx context.Context, adminID, id int64) error
+
+	AssignElectiveSubject(ctx context.Context, adminID int64, es curriculum.ElectiveSubject) (int64, error)
+	DeleteElectiveSubject(ctx context.Context, adminID, id int64) error
+}
+
+// ElectivesRepository manages elective groups with their capacity, selection windows of semesters
+// and enrollment of students by first come or lottery.
+type ElectivesRepository interface {
+	CreateGroup(ctx context.Context, adminID int64, group electives.Group) (int64, error)
+	GetGroups(ctx context.Context, adminID, semesterID int64) ([]electives.Group, error)
+	UpdateGroup(ctx context.Context, adminID int64, group electives.Group) error
+	DeleteGroup(ctx context.Context, adminID, id int64) error
+
+	CreateWindow(ctx context.Context, adminID int64, w electives.Window) (int64, error)
+	GetWindows(ctx context.Context, adminID int64) ([]electives.Window, error)
+	UpdateWindow(ctx context.Context, adminID int64, w electives.Window) error
+	DrawLottery(ctx context.Context, adminID, windowID, seed int64, draw DrawFunc) (*electives.Window, int, error)
+
+	GetStudentWindows(ctx context.Context, userID int64) ([]electives.Window, error)
+	GetStudentWindow(ctx context.Context, userID, windowID int64) (*electives.Window, *electives.Student, []electives.Group, error)
+	GetChoices(ctx context.Context, studentID int64, w electives.Window) (*electives.Choices, error)
+	SetChoices(ctx context.Context, userID, windowID int64, groupIDs []int64) (*electives.Student, error)
+	GetClashes(ctx context.Context, courseGroupID int64, groupIDs []int64) ([]electives.Clash, error)
+}
+
+// AdmissionsRepository manages admission campaigns and programs, applications of applicants with
+// their status history and enrollment of accepted applicants as students.
+type AdmissionsRepository interface {
+	CreateCampaign(ctx context.Context, adminID int64, c admissions.Campaign) (int64, error)
+	GetCampaigns(ctx context.Context, adminID int64) ([]admissions.Campaign, error)
+	UpdateCampaign(ctx context.Context, adminID int64, c admissions.Campaign) error
+
+	AddProgram(ctx context.Context, adminID int64, p admissions.Program) (int64, error)
+	GetPrograms(ctx context.Context, adminID, campaignID int64) ([]admissions.Program, error)
+	UpdateProgramSeats(ctx context.Context, adminID, id int64, seats int) error
+	DeleteProgram(ctx context.Context, adminID, id int64) error
+
+	GetRankedList(ctx context.Context, adminID, programID int64) ([]admissions.Application, error)
+	SetScore(ctx context.Context, adminID, applicationID int64, score int) error
+	ChangeStatus(ctx context.Context, adminID, applicationID int64, status admissions.Status, comment *string) error
+	Enroll(ctx context.Context, adminID, applicationID, courseGroupID int64) (int64, error)
+
+	GetOpenCampaigns(ctx context.Context, universityID int64) ([]admissions.Campaign, error)
+	GetOpenPrograms(ctx context.Context, campaignID int64) ([]admissions.Program, error)
+	Apply(ctx context.Context, userID, programID int64) (int64, error)
+	GetApplicantApplications(ctx context.Context, userID int64) ([]admissions.ApplicantApplication, error)
+
+	ClaimNotifications(ctx context.Context, limit, maxAttempts int, notifyFor time.Duration) ([]admissions.Notification, error)
+	MarkNotified(ctx context.Context, id int64, delivered bool) error
+}
+
+// ImportRepository applies rows of imported files to a university in one transaction per file,
+// rows failing with *RowError or a database error are reported and skipped.
+type ImportRepository interface {
+	ImportRooms(ctx context.Context, adminID, universityID int64, mode imports.Mode, rooms []imports.Room) (*imports.Result, error)
+	ImportClasses(ctx context.Context, adminID, universityID int64, mode imports.Mode, classes []imports.Class) (*imports.Result, error)
+	ImportSubjects(ctx context.Context, adminID, universityID int64, mode imports.Mode, subjects []imports.Subject) (*imports.Result, error)
+	ImportGroups(ctx context.Context, adminID, universityID int64, mode imports.Mode, groups []imports.Group) (*imports.Result, error)
+	ImportRoster(ctx context.Context, adminID, universityID int64, mode imports.Mode, entries []imports.RosterEntry) (*imports.Result, error)
+}
+
+// ExportRepository streams data of a university to fn row by row after checking that the user is its admin.
+type ExportRepository interface {
+	ExportRoster(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Student) error) error
+	ExportTeachers(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Teacher) error) error
+	ExportTimetable(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.Lesson) error) error
+	ExportAccessRequests(ctx context.Context, adminID int64, filter exports.Filter, fn func(exports.AccessRequest) error) error
+}
+
+// RolloverRepository moves universities to the next academic year.
+type RolloverRepository interface {
+	Rollover(ctx context.Context, adminID int64, params rollover.Params, dryRun bool) (*rollover.Report, error)
+}
+
+// BookingsRepository manages bookings of rooms requested by members of universities and decided by admins.
+// Confirmed bookings and lessons of the semester are checked against each other like lessons are.
+type BookingsRepository interface {
+	CreateBooking(ctx context.Context, userID int64, b bookings.Booking) (int64, bookings.Status, error)
+	GetUserBookings(ctx context.Context, userID int64) ([]bookings.Booking, error)
+	GetBookings(ctx context.Context, adminID int64, filter bookings.Filter) ([]bookings.Booking, error)
+	Decide(ctx context.Context, adminID, id int64, status bookings.Status) error
+	Cancel(ctx context.Context, userID, id int64) error
+}
+
+// ExamsRepository manages exam sessions of semesters and exams of course groups in them. Exams are checked
+// against each other and against lessons and bookings of their rooms and examiners.
+type ExamsRepository interface {
+	CreateSession(ctx context.Context, adminID int64, s exams.Session) (int64, error)
+	GetSessions(ctx context.Context, adminID, universityID int64) ([]exams.Session, error)
+	PublishSession(ctx context.Context, adminID, id int64) error
+	CreateExam(ctx context.Context, adminID int64, e exams.Exam) (int64, error)
+	GetSessionExams(ctx context.Context, adminID, sessionID int64) ([]exams.Exam, error)
+	DeleteExam(ctx context.Context, adminID, id int64) error
+	GetStudentExams(ctx context.Context, userID int64) ([]exams.StudentExam, error)
+}
+
 type FaculRepository interface {
 	GetFaculsByUserID(ctx context.Context, id int64) ([]models.Faculties, error)
 	CreateFaculty(ctx context.Context, id int64, facultyName string) error
@@ -81,4 +249,7 @@ type SchedulesRepository interface {
 	CreateLesson(ctx context.Context, req schedules.CreateLesson) (int64, error)
 	DeleteLesson(ctx context.Context, lessonID int64) error
 	GetUserSchedule(ctx context.Context, userID int64) ([]schedules.UserScheduleItem, error)
+	GetTimetable(ctx context.Context, adminID int64, kind schedules.TimetableKind, id int64) (*schedules.Timetable, error)
+	IsUniversityMember(ctx context.Context, userID, universityID int64) (bool, error)
+	GetRoomOccupancy(ctx context.Context, universityID int64, weekStart time.Time, roomID *int64) ([]schedules.Occupancy, error)
 }
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+)
+
+// jwtKeysLockID is a pg advisory lock key, so only one instance rotates keys at a time.
+const jwtKeysLockID = 7_310_027
+
+type jwtKeysRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewJWTKeysRepository(pool *pgxpool.Pool) JWTKeysRepository {
+	return &jwtKeysRepository{pool: pool}
+}
+
+func (r *jwtKeysRepository) GetActiveKeys(ctx context.Context, alg string) ([]models.JWTKey, error) {
+	const q = `
+		SELECT kid, alg, private_key, public_key, created_at, expires_at
+		FROM users.jwt_keys
+		WHERE alg = $1
+		  AND expires_at > now()
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, q, alg)
+	if err != nil {
+		return nil, fmt.Errorf("failed GetActiveKeys from db. err: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []models.JWTKey
+	for rows.Next() {
+		var key models.JWTKey
+		if err := rows.Scan(&key.KID, &key.Alg, &key.PrivateKey, &key.PublicKey, &key.CreatedAt, &key.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed GetActiveKeys from db in scan. err: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed GetActiveKeys during iteration. err: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RotateKey inserts a key made by generate if there is no key of alg created after staleBefore.
+// Expired keys are removed in the same transaction.
+func (r *jwtKeysRepository) RotateKey(ctx context.Context, alg string, staleBefore time.Time, generate func() (models.JWTKey, error)) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, jwtKeysLockID); err != nil {
+		return false, fmt.Errorf("failed to lock jwt keys: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM users.jwt_keys WHERE expires_at <= now()`); err != nil {
+		return false, fmt.Errorf("failed to delete expired jwt keys: %w", err)
+	}
+
+	var fresh bool
+	const qFresh = `SELECT EXISTS (SELECT 1 FROM users.jwt_keys WHERE alg = $1 AND created_at > $2)`
+	if err := tx.QueryRow(ctx, qFresh, alg, staleBefore).Scan(&fresh); err != nil {
+		return false, fmt.Errorf("failed to check jwt keys: %w", err)
+	}
+	if fresh {
+		return false, tx.Commit(ctx)
+	}
+
+	key, err := generate()
+	if err != nil {
+		return false, fmt.Errorf("failed to generate jwt key: %w", err)
+	}
+
+	const qInsert = `
+		INSERT INTO users.jwt_keys (kid, alg, private_key, public_key, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := tx.Exec(ctx, qInsert, key.KID, key.Alg, key.PrivateKey, key.PublicKey, key.CreatedAt, key.ExpiresAt); err != nil {
+		return false, fmt.Errorf("failed to insert jwt key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
@@ -19,7 +19,7 @@ func NewPersonalitiesRepo(pool *pgxpool.Pool) *PersonalitiesRepo {
 }
 
 func (r *PersonalitiesRepo) RequestUniversityAccess(ctx context.Context, uniAccess personalities.UniversityAccess) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, r.pool)
 	if err != nil {
 		return err
 	}
@@ -58,7 +58,7 @@ func (r *PersonalitiesRepo) RequestUniversityAccess(ctx context.Context, uniAcce
 }
 
 func (r *PersonalitiesRepo) DeleteRequest(ctx context.Context, requestID int64) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, r.pool)
 	if err != nil {
 		return err
 	}
@@ -128,7 +128,7 @@ func (r *PersonalitiesRepo) GetAccessRequest(ctx context.Context, userID, limit,
 }
 
 func (r *PersonalitiesRepo) AddNewUser(ctx context.Context, request personalities2.AcceptAccessRequest) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, r.pool)
 	if err != nil {
 		return err
 	}
@@ -174,6 +174,12 @@ func (r *PersonalitiesRepo) AddNewUser(ctx context.Context, request personalitie
 		return err
 	}
 
+	// roles changed -> tokens issued before must be re-issued
+	err = bumpTokenVersion(ctx, tx, request.UserID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -192,9 +198,10 @@ func (r *PersonalitiesRepo) GetAllUniversitiesForPerson(ctx context.Context, use
 			SELECT pa.university_id FROM personalities.administrations pa WHERE pa.max_user_id = $1
 			UNION
 			SELECT ud.university_id FROM personalities.students ps 
-			JOIN universities.university_departments ud ON ps.university_deparment_id = ud.id
+			JOIN universities.university_departments ud ON ps.university_department_id = ud.id
 			WHERE ps.max_user_id = $1
 		)
+		  AND uud.deleted_at IS NULL
 	`
 
 	rows, err := r.pool.Query(ctx, qGetAllUniversitiesForPerson, userID)
@@ -224,6 +231,7 @@ func (r *PersonalitiesRepo) GetAllFacultiesForUniversity(ctx context.Context, un
 		FROM universities.faculties AS uf
 		JOIN universities.universities_data AS uud ON uf.university_id = uud.id
 		WHERE uf.university_id = $1
+		  AND uf.deleted_at IS NULL
 		ORDER BY uf.name
 	`
 
@@ -260,6 +268,7 @@ func (r *PersonalitiesRepo) GetAllDepartmentsForFaculty(ctx context.Context, fac
 		JOIN universities.faculties AS uf ON ud.faculty_id = uf.id
 		JOIN universities.universities_data AS uud ON ud.university_id = uud.id
 		WHERE ud.faculty_id = $1
+		  AND ud.deleted_at IS NULL
 		ORDER BY ud_main.name
 	`
 
@@ -296,6 +305,8 @@ func (r *PersonalitiesRepo) GetAllGroupsForDepartment(ctx context.Context, depar
 		WHERE 
 			c.university_department_id = $1
 			AND c.end_date > CURRENT_TIMESTAMP
+			AND c.deleted_at IS NULL
+			AND cg.deleted_at IS NULL
 		ORDER BY 
 			cg.name
 	`
@@ -326,7 +337,7 @@ func (r *PersonalitiesRepo) GetAllStudentsForGroup(ctx context.Context, groupID
 			u.last_name,
 			u.username,
 			u.is_bot,
-			COALESCE(EXTRACT(EPOCH FROM u.last_activity)::int, 0) as last_activity_time,
+			COALESCE(u.last_activity, 0) as last_activity_time,
 			u.description,
 			u.avatar_url,
 			u.full_avatar_url
@@ -368,20 +379,7 @@ func (r *PersonalitiesRepo) GetAllTeachersForUniversity(ctx context.Context, uni
 			u.full_avatar_url
 		FROM users.max_users_data AS u
 		JOIN personalities.teachers AS t ON u.id = t.max_user_id
-		WHERE t.id IN (
-			SELECT DISTINCT cgs.teacher_id 
-			FROM subjects.course_group_subjects cgs
-			JOIN groups.course_groups cg ON cgs.course_group_id = cg.id
-			JOIN universities.courses c ON cg.course_id = c.id
-			JOIN universities.university_departments ud ON c.university_department_id = ud.id
-			WHERE ud.university_id = $1
-			UNION
-			SELECT DISTINCT egs.teacher_id
-			FROM subjects.elective_group_subjects egs
-			JOIN groups.elective_groups eg ON egs.elective_group_id = eg.id
-			JOIN universities.semesters s ON eg.semester_id = s.id
-			WHERE s.university_id = $1
-		)
+		WHERE t.university_id = $1
 		ORDER BY u.first_name, u.last_name
 	`
 
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type rateLimitRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRateLimitRepository(pool *pgxpool.Pool) RateLimitRepository {
+	return &rateLimitRepository{pool: pool}
+}
+
+// Take takes a token from bucket key refilled by rate tokens/sec up to burst, see users.rate_limit_take.
+func (r *rateLimitRepository) Take(ctx context.Context, key string, rate, burst float64) (bool, float64, error) {
+	const q = `SELECT allowed, tokens FROM users.rate_limit_take($1, $2, $3)`
+
+	var (
+		allowed bool
+		tokens  float64
+	)
+	if err := r.pool.QueryRow(ctx, q, key, rate, burst).Scan(&allowed, &tokens); err != nil {
+		return false, 0, fmt.Errorf("failed Take rate limit token. err: %w", err)
+	}
+
+	return allowed, tokens, nil
+}
+
+// DeleteIdle removes buckets not used since before, they are full anyway.
+func (r *rateLimitRepository) DeleteIdle(ctx context.Context, before time.Time) error {
+	const q = `DELETE FROM users.rate_limit_buckets WHERE updated_at < $1`
+
+	if _, err := r.pool.Exec(ctx, q, before); err != nil {
+		return fmt.Errorf("failed DeleteIdle rate limit buckets. err: %w", err)
+	}
+
+	return nil
+}
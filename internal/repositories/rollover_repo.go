@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/rollover"
+)
+
+var ErrRenameNotPromoted = errors.New("renamed group is not promoted by the rollover")
+
+// GroupNameTakenError is returned when a promoted group has the name of a group of the next year course.
+type GroupNameTakenError struct {
+	GroupID int64
+	Name    string
+}
+
+func (e *GroupNameTakenError) Error() string {
+	return fmt.Sprintf("group %q already exists in the next year course", e.Name)
+}
+
+type rolloverRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRolloverRepository(pool *pgxpool.Pool) RolloverRepository {
+	return &rolloverRepository{pool: pool}
+}
+
+// Rollover moves the university to the next academic year in one transaction: groups of finished courses
+// are promoted to courses of the next year of study, created if missing, students of final year courses
+// are graduated and lessons of finished semesters are archived. A dry run does the same and rolls back,
+// so its report is exactly what a real run would do.
+func (r *rolloverRepository) Rollover(ctx context.Context, adminID int64, params rollover.Params, dryRun bool) (*rollover.Report, error) {
+	// serializes rollovers of the university, FK checks of other writers are not blocked
+	qLock := fmt.Sprintf(`
+		SELECT u.id
+		FROM universities.universities_data AS u
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+		  AND %s
+		FOR NO KEY UPDATE
+	`, fmt.Sprintf(adminOf, "u.id"))
+	const qInsert = `
+		INSERT INTO universities.rollovers (university_id, start_date, end_date, promoted_groups, graduated_students, archived_lessons, created_by)
+		VALUES ($1, $2, $3, 0, 0, 0, $4)
+		RETURNING id
+	`
+	const qCounts = `
+		UPDATE universities.rollovers
+		SET promoted_groups = $2, graduated_students = $3, archived_lessons = $4
+		WHERE id = $1
+	`
+
+	tx, err := beginAudited(ctx, r.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := tx.QueryRow(ctx, qLock, params.UniversityID, adminID).Scan(&params.UniversityID); err != nil {
+		return nil, err
+	}
+
+	report := &rollover.Report{
+		DryRun:       dryRun,
+		UniversityID: params.UniversityID,
+		StartDate:    params.StartDate,
+		EndDate:      params.EndDate,
+	}
+	if err := tx.QueryRow(ctx, qInsert, params.UniversityID, params.StartDate, params.EndDate, adminID).Scan(&report.ID); err != nil {
+		return nil, err
+	}
+
+	if err := finishedCourses(ctx, tx, report); err != nil {
+		return nil, err
+	}
+
+	promoted := make(map[int64]bool)
+	for i := range report.Courses {
+		course := &report.Courses[i]
+		if course.IsFinalYear() {
+			course.Action = rollover.Graduated
+			err = graduate(ctx, tx, course, report)
+		} else {
+			course.Action = rollover.Promoted
+			err = promote(ctx, tx, course, params, report)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if course.Action == rollover.Promoted {
+			for _, g := range course.Groups {
+				promoted[g.ID] = true
+			}
+		}
+	}
+	for groupID := range params.Renames {
+		if !promoted[groupID] {
+			return nil, ErrRenameNotPromoted
+		}
+	}
+
+	if report.ArchivedLessons, err = archiveLessons(ctx, tx, report); err != nil {
+		return nil, err
+	}
+
+	err = execOne(ctx, tx, qCounts, report.ID, report.PromotedGroups, report.GraduatedStudents, report.ArchivedLessons)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		report.ID = 0
+		return report, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// finishedCourses locks live courses of the university finished before the new academic year and not rolled over
+// yet and marks them as rolled over by the report. Courses without year of study are only reported as skipped.
+func finishedCourses(ctx context.Context, tx pgx.Tx, report *rollover.Report) error {
+	const q = `
+		SELECT c.id, c.university_department_id, d.name, c.year_of_study, ud.study_years
+		FROM universities.courses AS c
+		JOIN universities.university_departments AS ud ON c.university_department_id = ud.id
+		JOIN universities.departments AS d ON ud.department_id = d.id
+		WHERE ud.university_id = $1
+		  AND c.end_date <= $2
+		  AND c.rollover_id IS NULL
+		  AND c.deleted_at IS NULL
+		  AND ud.deleted_at IS NULL
+		ORDER BY d.name, c.year_of_study, c.id
+		FOR UPDATE OF c
+	`
+	const qMark = `UPDATE universities.courses SET rollover_id = $2 WHERE id = ANY($1)`
+
+	rows, err := tx.Query(ctx, q, report.UniversityID, report.StartDate)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var course rollover.Course
+		var year *int
+		if err := rows.Scan(&course.ID, &course.UniversityDepartmentID, &course.DepartmentName, &year, &course.StudyYears); err != nil {
+			return err
+		}
+		if year == nil {
+			report.Skipped = append(report.Skipped, rollover.Skipped{
+				CourseID:               course.ID,
+				UniversityDepartmentID: course.UniversityDepartmentID,
+				DepartmentName:         course.DepartmentName,
+			})
+			continue
+		}
+		course.YearOfStudy = *year
+		report.Courses = append(report.Courses, course)
+		ids = append(ids, course.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, qMark, ids, report.ID)
+	return err
+}
+
+// courseGroups locks live groups of the course with their active students.
+func courseGroups(ctx context.Context, tx pgx.Tx, courseID int64) ([]rollover.Group, error) {
+	const q = `
+		SELECT cg.id, cg.name,
+		       (SELECT count(*) FROM personalities.students s WHERE s.course_group_id = cg.id AND NOT s.is_graduated)
+		FROM groups.course_groups AS cg
+		WHERE cg.course_id = $1
+		  AND cg.deleted_at IS NULL
+		ORDER BY cg.name
+		FOR UPDATE OF cg
+	`
+
+	rows, err := tx.Query(ctx, q, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []rollover.Group
+	for rows.Next() {
+		var g rollover.Group
+		if err := rows.Scan(&g.ID, &g.Name, &g.Students); err != nil {
+			return nil, err
+		}
+		g.NewName = g.Name
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// graduate marks active students of final year course groups as graduated, the groups stay in the course.
+func graduate(ctx context.Context, tx pgx.Tx, course *rollover.Course, report *rollover.Report) error {
+	const q = `
+		UPDATE personalities.students AS s
+		SET is_graduated = true
+		FROM groups.course_groups AS cg
+		WHERE s.course_group_id = cg.id
+		  AND cg.course_id = $1
+		  AND cg.deleted_at IS NULL
+		  AND NOT s.is_graduated
+	`
+
+	groups, err := courseGroups(ctx, tx, course.ID)
+	if err != nil {
+		return err
+	}
+	course.Groups = groups
+
+	tag, err := tx.Exec(ctx, q, course.ID)
+	if err != nil {
+		return err
+	}
+	report.GraduatedStudents += int(tag.RowsAffected())
+	return nil
+}
+
+// promote moves course groups with their students to the next year course of the department,
+// renaming them by params.Renames.
+func promote(ctx context.Context, tx pgx.Tx, course *rollover.Course, params rollover.Params, report *rollover.Report) error {
+	const qTaken = `SELECT EXISTS (SELECT 1 FROM groups.course_groups WHERE course_id = $1 AND name = $2 AND deleted_at IS NULL)`
+	const qMove = `UPDATE groups.course_groups SET course_id = $2, name = $3 WHERE id = $1`
+
+	var err error
+	year := course.YearOfStudy + 1
+	course.NewCourseID, course.NewCourseCreated, err = findOrCreateCourse(ctx, tx, course.UniversityDepartmentID, params.StartDate, params.EndDate, &year)
+	if err != nil {
+		return err
+	}
+
+	groups, err := courseGroups(ctx, tx, course.ID)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		g := &groups[i]
+		if name, ok := params.Renames[g.ID]; ok {
+			g.NewName = name
+		}
+
+		var taken bool
+		if err := tx.QueryRow(ctx, qTaken, course.NewCourseID, g.NewName).Scan(&taken); err != nil {
+			return err
+		}
+		if taken {
+			return &GroupNameTakenError{GroupID: g.ID, Name: g.NewName}
+		}
+
+		if err := execOne(ctx, tx, qMove, g.ID, course.NewCourseID, g.NewName); err != nil {
+			return err
+		}
+	}
+	course.Groups = groups
+	report.PromotedGroups += len(groups)
+	return nil
+}
+
+// archiveLessons moves lessons of the university semesters finished before the new academic year to the archive.
+func archiveLessons(ctx context.Context, tx pgx.Tx, report *rollover.Report) (int, error) {
+	const q = `
+		WITH finished AS (
+			SELECT gs.id
+			FROM schedules.groups_schedules AS gs
+			LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+			LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+			LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+			LEFT JOIN groups.elective_groups AS eg ON egs.elective_group_id = eg.id
+			JOIN universities.semesters AS s ON s.id = COALESCE(css.semester_id, eg.semester_id)
+			WHERE s.university_id = $1
+			  AND s.end_date <= $2
+		), archived AS (
+			DELETE FROM schedules.groups_schedules AS gs
+			USING finished AS f
+			WHERE gs.id = f.id
+			RETURNING gs.id, gs.course_group_subjet_id, gs.elective_group_subject_id, gs.day, gs.class_id, gs.room_id, gs."interval"
+		)
+		INSERT INTO schedules.archived_groups_schedules
+			(id, course_group_subjet_id, elective_group_subject_id, day, class_id, room_id, "interval", rollover_id)
+		SELECT id, course_group_subjet_id, elective_group_subject_id, day, class_id, room_id, "interval", $3::bigint
+		FROM archived
+	`
+
+	tag, err := tx.Exec(ctx, q, report.UniversityID, report.StartDate, report.ID)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -3,14 +3,27 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/metrics"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/schedules"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var ErrScheduleConflict = errors.New("schedule conflict")
 
+// scheduleConflict counts the conflict by kind for metrics, marks the span and returns ErrScheduleConflict.
+func scheduleConflict(ctx context.Context, kind string) error {
+	metrics.ScheduleConflicts.WithLabelValues(kind).Inc()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("schedules.conflict", kind))
+	return ErrScheduleConflict
+}
+
 type SchedulesRepo struct {
 	pool *pgxpool.Pool
 }
@@ -31,12 +44,14 @@ func (r *SchedulesRepo) CreateClass(ctx context.Context, class schedules.Class)
 	`
 
 	var id int64
-	err := r.pool.QueryRow(ctx, q,
-		class.UniversityID,
-		class.PairNumber,
-		class.StartTime,
-		class.EndTime,
-	).Scan(&id)
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, q,
+			class.UniversityID,
+			class.PairNumber,
+			class.StartTime,
+			class.EndTime,
+		).Scan(&id)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -46,8 +61,10 @@ func (r *SchedulesRepo) CreateClass(ctx context.Context, class schedules.Class)
 
 func (r *SchedulesRepo) DeleteClass(ctx context.Context, class_id int64) error {
 	const q = `DELETE FROM schedules.classes WHERE id = $1`
-	_, err := r.pool.Exec(ctx, q, class_id)
-	return err
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, q, class_id)
+		return err
+	})
 }
 
 func (r *SchedulesRepo) GetClassesByUniversity(ctx context.Context, universityID int64) ([]schedules.Class, error) {
@@ -93,7 +110,9 @@ func (r *SchedulesRepo) CreateRoom(ctx context.Context, room schedules.Room) (in
 	`
 
 	var id int64
-	err := r.pool.QueryRow(ctx, q, room.UniversityID, room.Room).Scan(&id)
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, q, room.UniversityID, room.Room).Scan(&id)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -103,8 +122,10 @@ func (r *SchedulesRepo) CreateRoom(ctx context.Context, room schedules.Room) (in
 
 func (r *SchedulesRepo) DeleteRoom(ctx context.Context, room_id int64) error {
 	const q = `DELETE FROM schedules.rooms WHERE id = $1`
-	_, err := r.pool.Exec(ctx, q, room_id)
-	return err
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, q, room_id)
+		return err
+	})
 }
 
 func (r *SchedulesRepo) GetRoomsByUniversity(ctx context.Context, universityID int64) ([]schedules.Room, error) {
@@ -139,16 +160,22 @@ func (r *SchedulesRepo) GetRoomsByUniversity(ctx context.Context, universityID i
 
 // CreateLesson делает проверки:
 // - аудитория свободна (НО лекция может пересекаться с другими лекциями);
+// - на аудиторию нет подтверждённой брони в этот слот в пределах семестра;
+// - аудитория и преподаватель не заняты экзаменами в этот день недели в пределах семестра;
 // - преподаватель не занят (игнорируем лекции для проверки, чтобы один лекционный слот на много групп проходил);
 // - группа / студенты не заняты (лекции считаются обычными занятиями);
 // и потом вставляет запись в schedules.groups_schedules.
-func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLesson) (int64, error) {
+func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLesson) (id int64, err error) {
+	// span groups the conflict queries below, each of them is a child span of pgx tracer
+	ctx, span := tracing.Start(ctx, "SchedulesRepo.CreateLesson")
+	defer func() { tracing.End(span, err) }()
+
 	if (req.CourseGroupSubjectID == nil && req.ElectiveGroupSubjectID == nil) ||
 		(req.CourseGroupSubjectID != nil && req.ElectiveGroupSubjectID != nil) {
 		return 0, errors.New("exactly one of course_group_subject_id or elective_group_subject_id must be set")
 	}
 
-	tx, err := r.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, r.pool)
 	if err != nil {
 		return 0, err
 	}
@@ -168,33 +195,36 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 	day := string(req.Day)
 	interval := string(req.Interval)
 
-	// Получаем teacher_id, group/elective_group и тип предмета (lecture/practice/…)
+	// Получаем teacher_id, group/elective_group, тип предмета (lecture/practice/…) и семестр
 	var (
 		teacherID       int64
 		courseGroupID   *int64
 		electiveGroupID *int64
 		subjectType     string
+		semesterID      int64
 	)
 
 	if req.CourseGroupSubjectID != nil {
 		const q = `
-			SELECT teacher_id, course_group_id, subject_type::text
-			FROM subjects.course_group_subjects
-			WHERE id = $1;
+			SELECT cgs.teacher_id, cgs.course_group_id, cgs.subject_type::text, css.semester_id
+			FROM subjects.course_group_subjects AS cgs
+			JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+			WHERE cgs.id = $1;
 		`
 		var cgID int64
-		if err = tx.QueryRow(ctx, q, *req.CourseGroupSubjectID).Scan(&teacherID, &cgID, &subjectType); err != nil {
+		if err = tx.QueryRow(ctx, q, *req.CourseGroupSubjectID).Scan(&teacherID, &cgID, &subjectType, &semesterID); err != nil {
 			return 0, err
 		}
 		courseGroupID = &cgID
 	} else {
 		const q = `
-			SELECT teacher_id, elective_group_id, subject_type::text
-			FROM subjects.elective_group_subjects
-			WHERE id = $1;
+			SELECT egs.teacher_id, egs.elective_group_id, egs.subject_type::text, eg.semester_id
+			FROM subjects.elective_group_subjects AS egs
+			JOIN groups.elective_groups AS eg ON egs.elective_group_id = eg.id
+			WHERE egs.id = $1;
 		`
 		var egID int64
-		if err = tx.QueryRow(ctx, q, *req.ElectiveGroupSubjectID).Scan(&teacherID, &egID, &subjectType); err != nil {
+		if err = tx.QueryRow(ctx, q, *req.ElectiveGroupSubjectID).Scan(&teacherID, &egID, &subjectType, &semesterID); err != nil {
 			return 0, err
 		}
 		electiveGroupID = &egID
@@ -224,7 +254,70 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 		return 0, err
 	}
 	if checkIntervalConflict(interval, roomTwoweek, roomOther) {
-		return 0, ErrScheduleConflict
+		return 0, scheduleConflict(ctx, "room")
+	}
+
+	// 1.1. Подтверждённые брони аудитории.
+	//
+	// Пара не ставится поверх будущей брони в этот день недели в пределах семестра предмета.
+	// Блокировка аудитории упорядочивает пары и брони одной аудитории между собой,
+	// блокировка преподавателя — пары и экзамены преподавателя (шаг 1.2), как в CreateExam.
+	// Порядок тот же, что в CreateExam: сначала преподаватель, затем аудитория.
+	const (
+		qLockTeacher = `SELECT 1 FROM personalities.teachers WHERE id = $1 FOR NO KEY UPDATE;`
+		qLockRoom    = `SELECT 1 FROM schedules.rooms WHERE id = $1 FOR NO KEY UPDATE;`
+	)
+	qBooked := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.room_bookings AS b
+			JOIN universities.semesters AS s ON s.id = $4
+			WHERE b.room_id = $1
+			  AND b.class_id = $2
+			  AND b.status = 'confirmed'
+			  AND b.date >= current_date
+			  AND b.date BETWEEN s.start_date::date AND s.end_date::date
+			  AND %s = $3::schedules.day_type
+		);
+	`, fmt.Sprintf(dayOf, "b.date"))
+
+	if _, err = tx.Exec(ctx, qLockTeacher, teacherID); err != nil {
+		return 0, err
+	}
+	if _, err = tx.Exec(ctx, qLockRoom, req.RoomID); err != nil {
+		return 0, err
+	}
+	var booked bool
+	if err = tx.QueryRow(ctx, qBooked, req.RoomID, req.ClassID, day, semesterID).Scan(&booked); err != nil {
+		return 0, err
+	}
+	if booked {
+		return 0, scheduleConflict(ctx, "booking")
+	}
+
+	// 1.2. Будущие экзамены в этой аудитории или у этого преподавателя, пересекающиеся с парой по времени.
+	qExams := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM schedules.exams AS e
+			JOIN schedules.classes AS c ON c.id = $2
+			JOIN universities.semesters AS s ON s.id = $4
+			WHERE (e.examiner_id = $5
+			       OR EXISTS (SELECT 1 FROM schedules.exam_rooms AS er WHERE er.exam_id = e.id AND er.room_id = $1))
+			  AND e.date >= current_date
+			  AND e.date BETWEEN s.start_date::date AND s.end_date::date
+			  AND %s = $3::schedules.day_type
+			  AND e.start_time < c.end_time
+			  AND c.start_time < e.end_time
+		);
+	`, fmt.Sprintf(dayOf, "e.date"))
+
+	var examined bool
+	if err = tx.QueryRow(ctx, qExams, req.RoomID, req.ClassID, day, semesterID, teacherID).Scan(&examined); err != nil {
+		return 0, err
+	}
+	if examined {
+		return 0, scheduleConflict(ctx, "exam")
 	}
 
 	// 2. Преподаватель.
@@ -251,7 +344,7 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 		return 0, err
 	}
 	if checkIntervalConflict(interval, teacherTwoWeek, teacherOther) {
-		return 0, ErrScheduleConflict
+		return 0, scheduleConflict(ctx, "teacher")
 	}
 
 	// 3. Конфликты по группе/студентам.
@@ -278,7 +371,7 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 			return 0, err
 		}
 		if checkIntervalConflict(interval, groupTwoweek, groupOther) {
-			return 0, ErrScheduleConflict
+			return 0, scheduleConflict(ctx, "group")
 		}
 
 		// 3.2. Студенты этой группы уже имеют элективы в этот слот.
@@ -306,7 +399,7 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 			return 0, err
 		}
 		if checkIntervalConflict(interval, studElectTwoweek, studElectOther) {
-			return 0, ErrScheduleConflict
+			return 0, scheduleConflict(ctx, "students")
 		}
 	}
 
@@ -333,7 +426,7 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 			return 0, err
 		}
 		if checkIntervalConflict(interval, studGroupTwoWeek, studGroupOther) {
-			return 0, ErrScheduleConflict
+			return 0, scheduleConflict(ctx, "students")
 		}
 	}
 
@@ -351,7 +444,6 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 		RETURNING id;
 	`
 
-	var id int64
 	err = tx.QueryRow(ctx, qInsert,
 		req.CourseGroupSubjectID,
 		req.ElectiveGroupSubjectID,
@@ -369,8 +461,10 @@ func (r *SchedulesRepo) CreateLesson(ctx context.Context, req schedules.CreateLe
 
 func (r *SchedulesRepo) DeleteLesson(ctx context.Context, lessonID int64) error {
 	const q = `DELETE FROM schedules.groups_schedules WHERE id = $1`
-	_, err := r.pool.Exec(ctx, q, lessonID)
-	return err
+	return inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, q, lessonID)
+		return err
+	})
 }
 
 // GetUserSchedule — возвращает расписание по max_user_id.
@@ -475,6 +569,8 @@ func (r *SchedulesRepo) getStudentSchedule(ctx context.Context, studentID int64)
 		  ON gs.room_id = rms.id
 		LEFT JOIN subjects.course_group_subjects cgs
 		  ON gs.course_group_subjet_id = cgs.id
+		LEFT JOIN groups.course_groups cg
+		  ON cg.id = cgs.course_group_id
 		LEFT JOIN subjects.course_semester_subjects css
 		  ON cgs.course_semester_subject_id = css.id
 		LEFT JOIN subjects.university_subjects us
@@ -500,6 +596,7 @@ func (r *SchedulesRepo) getStudentSchedule(ctx context.Context, studentID int64)
 						)
 				  )
 			)
+			AND cg.deleted_at IS NULL
 		ORDER BY gs.day, c.pair_number;
 	`
 
@@ -559,6 +656,8 @@ func (r *SchedulesRepo) getTeacherSchedule(ctx context.Context, teacherID int64)
 		  ON gs.room_id = rms.id
 		LEFT JOIN subjects.course_group_subjects cgs
 		  ON gs.course_group_subjet_id = cgs.id
+		LEFT JOIN groups.course_groups cg
+		  ON cg.id = cgs.course_group_id
 		LEFT JOIN subjects.course_semester_subjects css
 		  ON cgs.course_semester_subject_id = css.id
 		LEFT JOIN subjects.university_subjects us
@@ -576,6 +675,7 @@ func (r *SchedulesRepo) getTeacherSchedule(ctx context.Context, teacherID int64)
 				WHERE tt.id = $1
 				  AND (cgs.teacher_id = tt.id OR egs.teacher_id = tt.id)
 			)
+			AND cg.deleted_at IS NULL
 		ORDER BY gs.day, c.pair_number;
 	`
 
@@ -610,3 +710,203 @@ func (r *SchedulesRepo) getTeacherSchedule(ctx context.Context, teacherID int64)
 
 	return result, nil
 }
+
+// timetableOwners find the owner of a timetable by id, its university and name, the user must be an admin of it.
+var timetableOwners = map[schedules.TimetableKind]string{
+	schedules.GroupTimetable: `
+		SELECT ud.university_id, cg.name, COALESCE(ud.alias_name, d.name), c.year_of_study
+		FROM groups.course_groups AS cg
+		JOIN universities.courses AS c ON c.id = cg.course_id
+		JOIN universities.university_departments AS ud ON ud.id = c.university_department_id
+		JOIN universities.departments AS d ON d.id = ud.department_id
+		WHERE cg.id = $1
+		  AND cg.deleted_at IS NULL
+		  AND ` + fmt.Sprintf(adminOf, "ud.university_id"),
+	schedules.TeacherTimetable: `
+		SELECT t.university_id, concat_ws(' ', mu.first_name, mu.last_name), NULL::text, NULL::int
+		FROM personalities.teachers AS t
+		JOIN users.max_users_data AS mu ON mu.id = t.max_user_id
+		WHERE t.id = $1
+		  AND ` + fmt.Sprintf(adminOf, "t.university_id"),
+	schedules.RoomTimetable: `
+		SELECT r.university_id, r.room, NULL::text, NULL::int
+		FROM schedules.rooms AS r
+		WHERE r.id = $1
+		  AND ` + fmt.Sprintf(adminOf, "r.university_id"),
+}
+
+// timetableLessons filter lessons of the timetable owner
+var timetableLessons = map[schedules.TimetableKind]string{
+	schedules.GroupTimetable:   "l.course_group_id = $2",
+	schedules.TeacherTimetable: "l.teacher_id = $2",
+	schedules.RoomTimetable:    "l.room_id = $2",
+}
+
+// GetTimetable returns the week grid of a course group, a teacher or a room with the university branding.
+// Week parity of every two week lessons is numbered in the group of the lesson, so it is the same
+// in timetables of the group, the teacher and the room.
+func (r *SchedulesRepo) GetTimetable(ctx context.Context, adminID int64, kind schedules.TimetableKind, id int64) (*schedules.Timetable, error) {
+	const qBranding = `
+		SELECT u.name, u.short_name, ci.name, u.site_url
+		FROM universities.universities_data AS u
+		LEFT JOIN universities.cities AS ci ON ci.id = u.city_id
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+	`
+	q := fmt.Sprintf(`
+		WITH lessons AS (
+			SELECT gs.id, gs.day, gs.class_id, gs.room_id,
+			       cgs.course_group_id, egs.elective_group_id,
+			       COALESCE(cgs.teacher_id, egs.teacher_id) AS teacher_id,
+			       COALESCE(cgs.subject_type, egs.subject_type)::text AS subject_type,
+			       css.university_subject_id AS subject_id,
+			       CASE WHEN gs."interval" = 'every two week'
+			            THEN (row_number() OVER (
+			                      PARTITION BY gs.day, gs.class_id, gs."interval", cgs.course_group_id, egs.elective_group_id
+			                      ORDER BY gs.id) - 1) %% 2 + 1
+			            ELSE 0
+			       END AS week
+			FROM schedules.groups_schedules AS gs
+			JOIN schedules.classes AS c ON c.id = gs.class_id
+			LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+			LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+			LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+			WHERE c.university_id = $1
+		)
+		SELECT l.day::text, c.pair_number, l.week,
+		       COALESCE(us.name, eus.name), l.subject_type,
+		       NULLIF(concat_ws(' ', mu.first_name, mu.last_name), ''),
+		       rms.room, COALESCE(cg.name, eg.name, '')
+		FROM lessons AS l
+		JOIN schedules.classes AS c ON c.id = l.class_id
+		JOIN schedules.rooms AS rms ON rms.id = l.room_id
+		LEFT JOIN groups.course_groups AS cg ON cg.id = l.course_group_id
+		LEFT JOIN groups.elective_groups AS eg ON eg.id = l.elective_group_id
+		LEFT JOIN subjects.university_subjects AS us ON us.id = l.subject_id
+		LEFT JOIN subjects.university_subjects AS eus ON eus.id = eg.university_subject_id
+		LEFT JOIN personalities.teachers AS t ON t.id = l.teacher_id
+		LEFT JOIN users.max_users_data AS mu ON mu.id = t.max_user_id
+		WHERE %s
+		  AND cg.deleted_at IS NULL
+		ORDER BY l.day, c.pair_number, l.week, l.id
+	`, timetableLessons[kind])
+
+	qOwner, ok := timetableOwners[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown timetable kind %q", kind)
+	}
+
+	timetable := &schedules.Timetable{Kind: kind}
+	var universityID int64
+	err := r.pool.QueryRow(ctx, qOwner, id, adminID).Scan(&universityID, &timetable.Owner, &timetable.Department, &timetable.YearOfStudy)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &timetable.University
+	if err := r.pool.QueryRow(ctx, qBranding, universityID).Scan(&b.Name, &b.ShortName, &b.City, &b.SiteURL); err != nil {
+		return nil, err
+	}
+
+	if timetable.Classes, err = r.GetClassesByUniversity(ctx, universityID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, q, universityID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l schedules.TimetableLesson
+		if err := rows.Scan(&l.Day, &l.PairNumber, &l.Week, &l.Subject, &l.SubjectType, &l.Teacher, &l.Room, &l.Group); err != nil {
+			return nil, err
+		}
+		timetable.Lessons = append(timetable.Lessons, l)
+	}
+	return timetable, rows.Err()
+}
+
+// IsUniversityMember reports whether the user is an admin, a teacher or an active student of the university.
+func (r *SchedulesRepo) IsUniversityMember(ctx context.Context, userID, universityID int64) (bool, error) {
+	// adminOf takes the admin as $2, here it is the university
+	const q = `SELECT ` + qUniversityMember + `
+		OR EXISTS (SELECT 1 FROM personalities.administrations pa WHERE pa.max_user_id = $1 AND pa.university_id = $2)
+	`
+
+	var member bool
+	if err := r.pool.QueryRow(ctx, q, userID, universityID).Scan(&member); err != nil {
+		return false, fmt.Errorf("failed to check membership: %w", err)
+	}
+	return member, nil
+}
+
+// GetRoomOccupancy returns lessons and pending or confirmed bookings of rooms of the university in the week
+// from weekStart, ordered by room, date and pair. Lessons take the dates of their day in the week that fall
+// into the semester of the subject, every two week lessons are on every week as their parity is not stored.
+func (r *SchedulesRepo) GetRoomOccupancy(ctx context.Context, universityID int64, weekStart time.Time, roomID *int64) ([]schedules.Occupancy, error) {
+	q := fmt.Sprintf(`
+		WITH days AS (
+			SELECT d::date AS date, %s AS day
+			FROM generate_series($2::date, $2::date + 6, interval '1 day') AS d
+		),
+		occupancy AS (
+			SELECT gs.room_id, days.date, gs.class_id,
+			       gs.id AS lesson_id, gs."interval"::text AS "interval",
+			       COALESCE(us.name, eus.name) AS subject,
+			       COALESCE(cgs.subject_type, egs.subject_type)::text AS subject_type,
+			       COALESCE(cg.name, eg.name) AS group_name,
+			       NULL::bigint AS booking_id, '' AS title, '' AS purpose, '' AS status
+			FROM schedules.groups_schedules AS gs
+			JOIN schedules.rooms AS rms ON rms.id = gs.room_id
+			JOIN days ON days.day = gs.day
+			LEFT JOIN subjects.course_group_subjects AS cgs ON gs.course_group_subjet_id = cgs.id
+			LEFT JOIN subjects.course_semester_subjects AS css ON cgs.course_semester_subject_id = css.id
+			LEFT JOIN subjects.university_subjects AS us ON us.id = css.university_subject_id
+			LEFT JOIN groups.course_groups AS cg ON cg.id = cgs.course_group_id
+			LEFT JOIN subjects.elective_group_subjects AS egs ON gs.elective_group_subject_id = egs.id
+			LEFT JOIN groups.elective_groups AS eg ON eg.id = egs.elective_group_id
+			LEFT JOIN subjects.university_subjects AS eus ON eus.id = eg.university_subject_id
+			JOIN universities.semesters AS s ON s.id = COALESCE(css.semester_id, eg.semester_id)
+			WHERE rms.university_id = $1
+			  AND ($3::bigint IS NULL OR gs.room_id = $3)
+			  AND days.date BETWEEN s.start_date::date AND s.end_date::date
+			  AND cg.deleted_at IS NULL
+			UNION ALL
+			SELECT b.room_id, b.date, b.class_id,
+			       NULL, '', NULL, '', NULL,
+			       b.id, b.title, b.purpose, b.status
+			FROM schedules.room_bookings AS b
+			WHERE b.university_id = $1
+			  AND ($3::bigint IS NULL OR b.room_id = $3)
+			  AND b.date BETWEEN $2::date AND $2::date + 6
+			  AND b.status IN ('pending', 'confirmed')
+		)
+		SELECT o.room_id, o.date, c.pair_number, o.class_id, c.start_time, c.end_time,
+		       o.lesson_id, o."interval", o.subject, o.subject_type, o.group_name,
+		       o.booking_id, o.title, o.purpose, o.status
+		FROM occupancy AS o
+		JOIN schedules.classes AS c ON c.id = o.class_id
+		ORDER BY o.room_id, o.date, c.pair_number, o.booking_id NULLS FIRST, o.lesson_id
+	`, fmt.Sprintf(dayOf, "d::date"))
+
+	rows, err := r.pool.Query(ctx, q, universityID, weekStart, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []schedules.Occupancy
+	for rows.Next() {
+		var o schedules.Occupancy
+		err := rows.Scan(&o.RoomID, &o.Date, &o.PairNumber, &o.ClassID, &o.StartTime, &o.EndTime,
+			&o.LessonID, &o.Interval, &o.Subject, &o.SubjectType, &o.Group,
+			&o.BookingID, &o.Title, &o.Purpose, &o.Status)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, rows.Err()
+}
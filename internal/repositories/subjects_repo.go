@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/subjects"
 )
@@ -16,7 +17,7 @@ func NewSubjectRepo(pool *pgxpool.Pool) *SubjectRepo {
 }
 
 func (r *SubjectRepo) Create(ctx context.Context, name string, uniID int64) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, r.pool)
 	if err != nil {
 		return err
 	}
@@ -76,7 +77,10 @@ func (r *SubjectRepo) Delete(ctx context.Context, id int64) error {
 
 	qDeleteSubject := `DELETE FROM subjects.university_subjects WHERE id = $1`
 
-	_, err := r.pool.Exec(ctx, qDeleteSubject, id)
+	err := inAuditedTx(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, qDeleteSubject, id)
+		return err
+	})
 
 	if err != nil {
 		return err
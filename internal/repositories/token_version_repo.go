@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TokenVersionChannel is the Postgres NOTIFY channel with max_user_id payload,
+// fired every time a user's token version is bumped.
+const TokenVersionChannel = "token_version"
+
+type tokenVersionRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTokenVersionRepository(pool *pgxpool.Pool) TokenVersionRepository {
+	return &tokenVersionRepository{pool: pool}
+}
+
+func (r *tokenVersionRepository) GetTokenVersion(ctx context.Context, userID int64) (int64, error) {
+	const q = `SELECT token_version FROM users.max_users_data WHERE id = $1`
+
+	var version int64
+	err := r.pool.QueryRow(ctx, q, userID).Scan(&version)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed GetTokenVersion from db. err: %w", err)
+	}
+
+	return version, nil
+}
+
+func (r *tokenVersionRepository) BumpTokenVersion(ctx context.Context, userID int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := bumpTokenVersion(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListenTokenVersions blocks on LISTEN token_version and calls onBump for every notification.
+// onReady is called once the connection is subscribed. It returns when ctx is done or the connection breaks.
+func (r *tokenVersionRepository) ListenTokenVersions(ctx context.Context, onReady func(), onBump func(userID int64)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire listen connection: %w", err)
+	}
+
+	// LISTEN state must not leak back into the pool.
+	pgConn := conn.Hijack()
+	defer func() {
+		_ = pgConn.Close(context.Background())
+	}()
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+TokenVersionChannel); err != nil {
+		return fmt.Errorf("failed to listen %s: %w", TokenVersionChannel, err)
+	}
+	onReady()
+
+	for {
+		n, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		userID, err := strconv.ParseInt(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		onBump(userID)
+	}
+}
+
+// bumpTokenVersion increments the user's token version inside tx.
+// The notification is delivered to listeners only after tx commits.
+func bumpTokenVersion(ctx context.Context, tx pgx.Tx, userID int64) error {
+	const qBump = `UPDATE users.max_users_data SET token_version = token_version + 1 WHERE id = $1`
+	if _, err := tx.Exec(ctx, qBump, userID); err != nil {
+		return fmt.Errorf("failed to bump token version: %w", err)
+	}
+
+	// pgx can't encode int64 as text, the payload is formatted here
+	const qNotify = `SELECT pg_notify($1, $2)`
+	if _, err := tx.Exec(ctx, qNotify, TokenVersionChannel, strconv.FormatInt(userID, 10)); err != nil {
+		return fmt.Errorf("failed to notify token version: %w", err)
+	}
+	return nil
+}
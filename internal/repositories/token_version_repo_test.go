@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBumpTokenVersion(t *testing.T) {
+	pool := testPool(t)
+	tx := testTx(t, pool)
+	ctx := context.Background()
+
+	const userID = 900_000_001
+	testUser(t, tx, userID)
+
+	for want := int64(1); want <= 2; want++ {
+		if err := bumpTokenVersion(ctx, tx, userID); err != nil {
+			t.Fatalf("bumpTokenVersion: %v", err)
+		}
+
+		var version int64
+		if err := tx.QueryRow(ctx, `SELECT token_version FROM users.max_users_data WHERE id = $1`, userID).Scan(&version); err != nil {
+			t.Fatal(err)
+		}
+		if version != want {
+			t.Errorf("token_version = %d, want %d", version, want)
+		}
+	}
+}
+
+func TestBumpTokenVersionNotifies(t *testing.T) {
+	pool := testPool(t)
+	repo := NewTokenVersionRepository(pool)
+
+	const userID = 900_000_002
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := pool.Exec(ctx, `INSERT INTO users.max_users_data (id, first_name) VALUES ($1, 'Test')`, userID); err != nil {
+		t.Fatalf("failed to insert user: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users.max_users_data WHERE id = $1`, userID)
+	})
+
+	ready := make(chan struct{})
+	bumped := make(chan int64, 1)
+	go func() {
+		_ = repo.ListenTokenVersions(ctx, func() { close(ready) }, func(id int64) {
+			if id == userID {
+				bumped <- id
+			}
+		})
+	}()
+
+	select {
+	case <-ready:
+	case <-ctx.Done():
+		t.Fatal("listener is not ready")
+	}
+
+	if err := repo.BumpTokenVersion(ctx, userID); err != nil {
+		t.Fatalf("BumpTokenVersion: %v", err)
+	}
+
+	select {
+	case <-bumped:
+	case <-ctx.Done():
+		t.Fatal("no notification after bump")
+	}
+}
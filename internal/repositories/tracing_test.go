@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestScheduleConflictSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = provider.Shutdown(t.Context())
+	})
+
+	e := echo.New()
+	e.Use(otelecho.Middleware("test"))
+	e.POST("/schedules/lessons", func(c echo.Context) (err error) {
+		ctx, span := tracing.Start(c.Request().Context(), "SchedulesRepo.CreateLesson")
+		defer func() { tracing.End(span, err) }()
+
+		if err := scheduleConflict(ctx, "room"); !errors.Is(err, ErrScheduleConflict) {
+			t.Fatalf("scheduleConflict = %v, want ErrScheduleConflict", err)
+		}
+		return c.NoContent(http.StatusConflict)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/schedules/lessons", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	// the child ends first, so it is exported before the route span
+	repo, route := spans[0], spans[1]
+
+	if route.Name != "POST /schedules/lessons" {
+		t.Errorf("route span name = %q", route.Name)
+	}
+	if route.Parent.IsValid() {
+		t.Errorf("route span has parent %s, want root", route.Parent.SpanID())
+	}
+	if repo.Name != "SchedulesRepo.CreateLesson" {
+		t.Errorf("repository span name = %q", repo.Name)
+	}
+	if repo.Parent.SpanID() != route.SpanContext.SpanID() {
+		t.Errorf("repository span parent = %s, want %s", repo.Parent.SpanID(), route.SpanContext.SpanID())
+	}
+	if repo.SpanContext.TraceID() != route.SpanContext.TraceID() {
+		t.Errorf("repository span trace = %s, want %s", repo.SpanContext.TraceID(), route.SpanContext.TraceID())
+	}
+
+	want := attribute.String("schedules.conflict", "room")
+	found := false
+	for _, kv := range repo.Attributes {
+		if kv == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("repository span attributes = %v, want %v", repo.Attributes, want)
+	}
+}
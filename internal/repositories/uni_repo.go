@@ -30,6 +30,7 @@ func (u *uniRepository) GetUniInfoByUserID(ctx context.Context, id int64) (*mode
             FROM personalities.administrations
             WHERE max_user_id = $1
         )
+          AND uud.deleted_at IS NULL
     `
 
 	err := u.pool.QueryRow(ctx, query, id).Scan(&uniData.ID, &uniData.City, &uniData.Name, &uniData.ShortName, &uniData.SiteUrl, &uniData.Description, &uniData.PhotoUrl)
@@ -49,6 +50,7 @@ func (u *uniRepository) GetAllUniversities(ctx context.Context) ([]models.Univer
         FROM universities.universities_data AS uud
         JOIN universities.cities AS uc
 		ON uud.city_id = uc.id
+		WHERE uud.deleted_at IS NULL
 	`
 	rows, err := u.pool.Query(ctx, query)
 	if err != nil {
@@ -86,9 +88,7 @@ func (u *uniRepository) CreateSemestersForUniversity(ctx context.Context, uniID
 	VALUES ($1,$2,$3)
 	`
 
-	tx, err := u.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.ReadCommitted,
-	})
+	tx, err := beginAudited(ctx, u.pool)
 
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -118,7 +118,7 @@ func (u *uniRepository) CreateSemestersForUniversity(ctx context.Context, uniID
 }
 
 func (u *uniRepository) CreateNewDepartment(ctx context.Context, departmentName, departmentCode, aliasName string, facultyID, universityID int64) error {
-	tx, err := u.pool.Begin(ctx)
+	tx, err := beginAudited(ctx, u.pool)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -141,12 +141,21 @@ func (u *uniRepository) CreateNewDepartment(ctx context.Context, departmentName,
 
 	insertLinkQuery := `
 		INSERT INTO universities.university_departments (university_id, faculty_id, department_id, alias_name)
-		VALUES ($1, $2, $3, $4)
+		SELECT f.university_id, f.id, $3, $4
+		FROM universities.faculties AS f
+		JOIN universities.universities_data AS uud ON uud.id = f.university_id
+		WHERE f.id = $2
+		  AND f.university_id = $1
+		  AND f.deleted_at IS NULL
+		  AND uud.deleted_at IS NULL
 	`
-	_, err = tx.Exec(ctx, insertLinkQuery, universityID, facultyID, departmentID, aliasName)
+	tag, err := tx.Exec(ctx, insertLinkQuery, universityID, facultyID, departmentID, aliasName)
 	if err != nil {
 		return fmt.Errorf("failed to create university department link: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to create university department link: %w", ErrReferenceNotFound)
+	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -155,13 +164,25 @@ func (u *uniRepository) CreateNewDepartment(ctx context.Context, departmentName,
 	return nil
 }
 
-func (u *uniRepository) CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64) error {
+func (u *uniRepository) CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64, yearOfStudy *int) error {
 	query := `
-		INSERT INTO universities.courses (start_date, end_date, university_department_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO universities.courses (start_date, end_date, university_department_id, year_of_study)
+		SELECT $1, $2, ud.id, $4
+		FROM universities.university_departments AS ud
+		WHERE ud.id = $3
+		  AND ud.deleted_at IS NULL
 	`
 
-	_, err := u.pool.Exec(ctx, query, startDate, endDate, universityDepartmentID)
+	err := inAuditedTx(ctx, u.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, startDate, endDate, universityDepartmentID, yearOfStudy)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrReferenceNotFound
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create course: %w", err)
 	}
@@ -173,10 +194,11 @@ func (u *uniRepository) GetAllCoursesByUniversityID(ctx context.Context, univers
 	var courses []models.Course
 
 	query := `
-		SELECT c.id, c.start_date, c.end_date, c.university_department_id
+		SELECT c.id, c.start_date, c.end_date, c.university_department_id, c.year_of_study
 		FROM universities.courses c
 		JOIN universities.university_departments ud ON c.university_department_id = ud.id
 		WHERE ud.university_id = $1
+		  AND c.deleted_at IS NULL
 		ORDER BY c.start_date DESC
 	`
 
@@ -188,7 +210,7 @@ func (u *uniRepository) GetAllCoursesByUniversityID(ctx context.Context, univers
 
 	for rows.Next() {
 		var course models.Course
-		err := rows.Scan(&course.ID, &course.StartDate, &course.EndDate, &course.UniversityDepartment)
+		err := rows.Scan(&course.ID, &course.StartDate, &course.EndDate, &course.UniversityDepartment, &course.YearOfStudy)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan course row: %w", err)
 		}
@@ -205,10 +227,22 @@ func (u *uniRepository) GetAllCoursesByUniversityID(ctx context.Context, univers
 func (u *uniRepository) CreateNewGroup(ctx context.Context, groupName string, courseID int64) error {
 	query := `
 		INSERT INTO groups.course_groups (name, course_id)
-		VALUES ($1, $2)
+		SELECT $1, c.id
+		FROM universities.courses AS c
+		WHERE c.id = $2
+		  AND c.deleted_at IS NULL
 	`
 
-	_, err := u.pool.Exec(ctx, query, groupName, courseID)
+	err := inAuditedTx(ctx, u.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, groupName, courseID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrReferenceNotFound
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create group: %w", err)
 	}
@@ -222,7 +256,10 @@ func (u *uniRepository) CreateNewEvent(ctx context.Context, event models.Event)
 		VALUES ($1, $2, $3, $4)
 	`
 
-	_, err := u.pool.Exec(ctx, query, event.UniversityID, event.Title, event.Description, event.PhotoUrl)
+	err := inAuditedTx(ctx, u.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, query, event.UniversityID, event.Title, event.Description, event.PhotoUrl)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create event: %w", err)
 	}
@@ -0,0 +1,24 @@
+// Package reqctx carries request scoped data through context.Context
+// from http middlewares down to services and repositories.
+package reqctx
+
+import "context"
+
+type actorKey struct{}
+
+// Actor is the authenticated user making the request.
+type Actor struct {
+	UserID int64
+	// ImpersonatorID is the admin acting as UserID, 0 if the request is not impersonated.
+	ImpersonatorID int64
+	RequestID      string
+}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
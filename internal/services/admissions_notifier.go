@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+const (
+	notifyEvery       = 30 * time.Second
+	notifyBatch       = 50
+	notifyMaxAttempts = 5
+	// notifyFor is how long a claimed batch belongs to this replica, a crashed one gives it up after that
+	notifyFor = 5 * time.Minute
+)
+
+// Messenger delivers text messages to MAX users, it is the bot.
+type Messenger interface {
+	NotifyUser(ctx context.Context, userID int64, text string) error
+}
+
+// AdmissionsNotifier sends applicants status changes of their applications. Changes are recorded
+// in the same transaction as the status, so none is lost while the bot is down.
+type AdmissionsNotifier struct {
+	repo      repositories.AdmissionsRepository
+	messenger Messenger
+	logger    logging.Logger
+}
+
+func NewAdmissionsNotifier(repo repositories.AdmissionsRepository, messenger Messenger, logger logging.Logger) *AdmissionsNotifier {
+	return &AdmissionsNotifier{repo: repo, messenger: messenger, logger: logger}
+}
+
+// Run sends pending notifications every notifyEvery until ctx is done.
+func (n *AdmissionsNotifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(notifyEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.sendPending(ctx)
+		}
+	}
+}
+
+func (n *AdmissionsNotifier) sendPending(ctx context.Context) {
+	notifications, err := n.repo.ClaimNotifications(ctx, notifyBatch, notifyMaxAttempts, notifyFor)
+	if err != nil {
+		n.logger.Errorf("[AdmissionsNotifier] failed to get notifications: %v", err)
+		return
+	}
+
+	for _, notification := range notifications {
+		err := n.messenger.NotifyUser(ctx, notification.UserID, statusMessage(notification))
+		if err != nil {
+			n.logger.Errorf("[AdmissionsNotifier] failed to notify user %d about application %d: %v", notification.UserID, notification.ApplicationID, err)
+		}
+		if err := n.repo.MarkNotified(ctx, notification.ID, err == nil); err != nil {
+			n.logger.Errorf("[AdmissionsNotifier] %v", err)
+		}
+	}
+}
+
+var statusNames = map[admissions.Status]string{
+	admissions.Submitted:   "подано",
+	admissions.UnderReview: "на рассмотрении",
+	admissions.Accepted:    "принято",
+	admissions.Rejected:    "отклонено",
+	admissions.Waitlisted:  "в листе ожидания",
+}
+
+func statusMessage(n admissions.Notification) string {
+	text := fmt.Sprintf("Статус вашего заявления в %s на направление «%s»: %s.", n.University, n.Department, statusNames[n.Status])
+	if n.Comment != nil && *n.Comment != "" {
+		text += "\nКомментарий: " + *n.Comment
+	}
+	return text
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/admissions"
+	admissions2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/admissions"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// AdmissionsService manages admission campaigns of universities: programs with seats, applications of
+// applicants moving through statuses and enrollment of accepted applicants as students.
+type AdmissionsService struct {
+	repo repositories.AdmissionsRepository
+}
+
+func NewAdmissionsService(repo repositories.AdmissionsRepository) *AdmissionsService {
+	return &AdmissionsService{repo: repo}
+}
+
+func (s *AdmissionsService) CreateCampaign(ctx context.Context, adminID int64, request admissions.CreateCampaignRequest) (int64, error) {
+	id, err := s.repo.CreateCampaign(ctx, adminID, admissions2.Campaign{
+		UniversityID: request.UniversityID,
+		Name:         request.Name,
+		StartsAt:     request.StartsAt,
+		EndsAt:       request.EndsAt,
+	})
+	return id, FromDB(err)
+}
+
+func (s *AdmissionsService) GetCampaigns(ctx context.Context, adminID int64) ([]admissions.CampaignResponse, error) {
+	campaigns, err := s.repo.GetCampaigns(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]admissions.CampaignResponse, 0, len(campaigns))
+	for _, c := range campaigns {
+		response = append(response, campaignResponse(c))
+	}
+	return response, nil
+}
+
+func (s *AdmissionsService) UpdateCampaign(ctx context.Context, adminID, id int64, request admissions.UpdateCampaignRequest) error {
+	return FromDB(s.repo.UpdateCampaign(ctx, adminID, admissions2.Campaign{
+		ID:       id,
+		Name:     request.Name,
+		StartsAt: request.StartsAt,
+		EndsAt:   request.EndsAt,
+	}))
+}
+
+func (s *AdmissionsService) AddProgram(ctx context.Context, adminID, campaignID int64, request admissions.AddProgramRequest) (int64, error) {
+	id, err := s.repo.AddProgram(ctx, adminID, admissions2.Program{
+		CampaignID:             campaignID,
+		UniversityDepartmentID: request.UniversityDepartmentID,
+		Seats:                  request.Seats,
+	})
+	return id, FromDB(err)
+}
+
+func (s *AdmissionsService) GetPrograms(ctx context.Context, adminID, campaignID int64) ([]admissions.ProgramResponse, error) {
+	programs, err := s.repo.GetPrograms(ctx, adminID, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return programResponses(programs), nil
+}
+
+func (s *AdmissionsService) UpdateProgram(ctx context.Context, adminID, id int64, request admissions.UpdateProgramRequest) error {
+	return FromDB(s.repo.UpdateProgramSeats(ctx, adminID, id, request.Seats))
+}
+
+func (s *AdmissionsService) DeleteProgram(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteProgram(ctx, adminID, id))
+}
+
+func (s *AdmissionsService) GetRankedList(ctx context.Context, adminID, programID int64) ([]admissions.RankedApplicationResponse, error) {
+	applications, err := s.repo.GetRankedList(ctx, adminID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]admissions.RankedApplicationResponse, 0, len(applications))
+	for _, a := range applications {
+		ranked := admissions.RankedApplicationResponse{
+			ID:        a.ID,
+			Rank:      a.Rank,
+			UserID:    a.UserID,
+			FirstName: a.FirstName,
+			Score:     a.Score,
+			Status:    string(a.Status),
+			StudentID: a.StudentID,
+			CreatedAt: a.CreatedAt,
+		}
+		if a.LastName != nil {
+			ranked.LastName = *a.LastName
+		}
+		response = append(response, ranked)
+	}
+	return response, nil
+}
+
+// SetScore records the verified score of the applicant, it places the application in the ranked list.
+func (s *AdmissionsService) SetScore(ctx context.Context, adminID, applicationID int64, request admissions.SetScoreRequest) error {
+	return FromDB(s.repo.SetScore(ctx, adminID, applicationID, request.Score))
+}
+
+// ChangeStatus moves application to the next status, the applicant is notified by the bot.
+func (s *AdmissionsService) ChangeStatus(ctx context.Context, adminID, applicationID int64, request admissions.ChangeStatusRequest) error {
+	return FromDB(s.repo.ChangeStatus(ctx, adminID, applicationID, admissions2.Status(request.Status), request.Comment))
+}
+
+// Enroll creates student from an accepted application.
+func (s *AdmissionsService) Enroll(ctx context.Context, adminID, applicationID int64, request admissions.EnrollRequest) (*admissions.EnrollResponse, error) {
+	studentID, err := s.repo.Enroll(ctx, adminID, applicationID, request.CourseGroupID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return &admissions.EnrollResponse{StudentID: studentID}, nil
+}
+
+// GetOpenCampaigns returns campaigns accepting applications with their programs.
+func (s *AdmissionsService) GetOpenCampaigns(ctx context.Context, universityID int64) ([]admissions.CampaignProgramsResponse, error) {
+	campaigns, err := s.repo.GetOpenCampaigns(ctx, universityID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]admissions.CampaignProgramsResponse, 0, len(campaigns))
+	for _, c := range campaigns {
+		programs, err := s.repo.GetOpenPrograms(ctx, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, admissions.CampaignProgramsResponse{
+			CampaignResponse: campaignResponse(c),
+			Programs:         programResponses(programs),
+		})
+	}
+	return response, nil
+}
+
+func (s *AdmissionsService) Apply(ctx context.Context, userID int64, request admissions.ApplyRequest) (int64, error) {
+	id, err := s.repo.Apply(ctx, userID, request.ProgramID)
+	return id, FromDB(err)
+}
+
+func (s *AdmissionsService) GetApplications(ctx context.Context, userID int64) ([]admissions.ApplicationResponse, error) {
+	applications, err := s.repo.GetApplicantApplications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]admissions.ApplicationResponse, 0, len(applications))
+	for _, a := range applications {
+		history := make([]admissions.StatusChangeResponse, 0, len(a.History))
+		for _, sc := range a.History {
+			change := admissions.StatusChangeResponse{
+				ToStatus:  string(sc.ToStatus),
+				CreatedAt: sc.CreatedAt,
+			}
+			if sc.FromStatus != nil {
+				change.FromStatus = string(*sc.FromStatus)
+			}
+			if sc.Comment != nil {
+				change.Comment = *sc.Comment
+			}
+			history = append(history, change)
+		}
+
+		response = append(response, admissions.ApplicationResponse{
+			ID:        a.ID,
+			Status:    string(a.Status),
+			Score:     a.Score,
+			Rank:      a.Rank,
+			CreatedAt: a.CreatedAt,
+			Campaign:  campaignResponse(a.Campaign),
+			Program:   programResponse(a.Program),
+			History:   history,
+		})
+	}
+	return response, nil
+}
+
+func campaignResponse(c admissions2.Campaign) admissions.CampaignResponse {
+	return admissions.CampaignResponse{
+		ID:           c.ID,
+		UniversityID: c.UniversityID,
+		Name:         c.Name,
+		StartsAt:     c.StartsAt,
+		EndsAt:       c.EndsAt,
+		IsOpen:       c.IsOpen(time.Now()),
+	}
+}
+
+func programResponses(programs []admissions2.Program) []admissions.ProgramResponse {
+	response := make([]admissions.ProgramResponse, 0, len(programs))
+	for _, p := range programs {
+		response = append(response, programResponse(p))
+	}
+	return response
+}
+
+func programResponse(p admissions2.Program) admissions.ProgramResponse {
+	return admissions.ProgramResponse{
+		ID:                     p.ID,
+		CampaignID:             p.CampaignID,
+		UniversityDepartmentID: p.UniversityDepartmentID,
+		FacultyName:            p.FacultyName,
+		DepartmentName:         p.DepartmentName,
+		Seats:                  p.Seats,
+		Accepted:               p.Accepted,
+		Applications:           p.Applications,
+	}
+}
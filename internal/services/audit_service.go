@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	httpaudit "github.com/max-main-team/backend_hackaton_MAX/internal/models/http/audit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/audit"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+type AuditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+func (s *AuditService) GetAuditLog(ctx context.Context, filter audit.Filter) (*httpaudit.AuditLogResponse, error) {
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	entries, err := s.auditRepo.GetAuditLog(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := httpaudit.AuditLogResponse{Data: []httpaudit.Entry{}}
+	if int64(len(entries)) > limit {
+		response.HasMore = true
+		entries = entries[:limit]
+	}
+
+	for _, e := range entries {
+		response.Data = append(response.Data, httpaudit.Entry{
+			ID:             e.ID,
+			ActorID:        e.ActorID,
+			ImpersonatorID: e.ImpersonatorID,
+			Action:         e.Action,
+			EntityType:     e.EntityType,
+			EntityID:       e.EntityID,
+			Before:         e.Before,
+			After:          e.After,
+			RequestID:      e.RequestID,
+			CreatedAt:      e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &response, nil
+}
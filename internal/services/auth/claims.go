@@ -12,5 +12,12 @@ type Claims struct {
 	Description    string `json:"description"`
 	AvatarUrl      string `json:"avatar_url"`
 	FullAvatarUrl  string `json:"full_avatar_url"`
+	TokenVersion   int64  `json:"token_version"`
+	// Act is set in impersonation tokens, it identifies the admin acting as the user (RFC 8693).
+	Act *Actor `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
+
+type Actor struct {
+	ID int64 `json:"sub"`
+}
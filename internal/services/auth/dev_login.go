@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	config "github.com/max-main-team/backend_hackaton_MAX/cfg"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+const defaultDevUserID = 1
+
+var ErrDevUserNotFound = errors.New("dev login user not found")
+
+// DevLogin issues tokens without MAX init data, for local development and integration tests.
+// It exists only if server.is_devel and auth.dev_login are set and the binary is not built with -tags production.
+type DevLogin struct {
+	users     repositories.UserRepository
+	jwt       *JWTService
+	fixtureID int64
+}
+
+// NewDevLogin returns nil if dev login is not enabled in config,
+// and an error if it is enabled where it is not allowed.
+func NewDevLogin(cfg config.Config, users repositories.UserRepository, jwt *JWTService) (*DevLogin, error) {
+	if !cfg.AuthConfig.DevLogin {
+		return nil, nil
+	}
+	if !devLoginBuildAllowed {
+		return nil, errors.New("auth.dev_login is not available in production build")
+	}
+	if !cfg.Server.IsDevel {
+		return nil, errors.New("auth.dev_login requires server.is_devel")
+	}
+
+	fixtureID := cfg.AuthConfig.DevUserID
+	if fixtureID == 0 {
+		fixtureID = defaultDevUserID
+	}
+
+	return &DevLogin{
+		users:     users,
+		jwt:       jwt,
+		fixtureID: fixtureID,
+	}, nil
+}
+
+// User returns user with given max_users_data.id, or the fixture user if userID is 0.
+// The fixture user is created on first use, any other user must already exist.
+func (d *DevLogin) User(ctx context.Context, userID int64) (*models.User, error) {
+	if userID == 0 {
+		userID = d.fixtureID
+	}
+
+	user, err := d.users.GetUserByID(ctx, userID)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if userID != d.fixtureID {
+		e := services.NotFound("user not found")
+		e.Err = fmt.Errorf("%w: %d", ErrDevUserNotFound, userID)
+		return nil, e
+	}
+
+	userName := "dev"
+	user = &models.User{
+		ID:        userID,
+		FirstName: "Dev",
+		UserName:  &userName,
+	}
+	if err := d.users.CreateNewUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create dev user: %w", err)
+	}
+	return user, nil
+}
+
+// AccessToken returns an access token of user, see User.
+func (d *DevLogin) AccessToken(ctx context.Context, userID int64) (string, error) {
+	user, err := d.User(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return d.jwt.GenerateToken(ctx,
+		int(user.ID),
+		user.LastActivityTime,
+		user.FirstName,
+		user.LastName,
+		user.UserName,
+		user.Description,
+		user.AvatarUrl,
+		user.FullAvatarUrl,
+		user.IsBot,
+	)
+}
@@ -0,0 +1,6 @@
+//go:build !production
+
+package auth
+
+// devLoginBuildAllowed is false in builds with -tags production, there dev login can't be enabled by config.
+const devLoginBuildAllowed = true
@@ -0,0 +1,5 @@
+//go:build production
+
+package auth
+
+const devLoginBuildAllowed = false
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
+)
+
+// ImpersonationMiddleware writes every impersonated request to users.impersonation_audit
+// and refuses mutating requests: impersonation is read only. Must be used after JWTMiddleware.
+func ImpersonationMiddleware(repo repositories.ImpersonationRepository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			actorID, ok := GetActorFromContext(c)
+			if !ok {
+				return next(c)
+			}
+
+			log := logging.FromContext(c.Request().Context())
+			req := c.Request()
+
+			var err error
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				err = next(c)
+			default:
+				log.Errorf("[ImpersonationMiddleware] refused %s %s actor_id=%d", req.Method, req.URL.Path, actorID)
+				err = echo.NewHTTPError(http.StatusForbidden, "not allowed while impersonating")
+			}
+
+			entry := models.ImpersonationAuditEntry{
+				ActorID:   actorID,
+				Method:    req.Method,
+				Path:      req.URL.RequestURI(),
+				Status:    responseStatus(c, err),
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			if user := GetUserFromContext(c); user != nil {
+				entry.UserID = user.ID
+			}
+
+			// request context may be cancelled already, the audit entry must be written anyway
+			if logErr := repo.LogRequest(context.WithoutCancel(req.Context()), entry); logErr != nil {
+				log.Errorf("[ImpersonationMiddleware] failed to write audit: %v", logErr)
+			}
+
+			return err
+		}
+	}
+}
+
+func responseStatus(c echo.Context, err error) int {
+	if err == nil {
+		return c.Response().Status
+	}
+
+	// same order as the http error handler: domain and db errors first, then echo ones
+	var domainErr *services.Error
+	if errors.As(services.FromDB(err), &domainErr) {
+		return domainErr.Status
+	}
+
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
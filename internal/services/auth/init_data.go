@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInitDataInvalid = errors.New("invalid init data")
+	ErrInitDataExpired = errors.New("init data expired")
+	ErrInitDataReplay  = errors.New("init data already used")
+)
+
+// allowed clock skew between MAX servers and us for auth_date in the future
+const initDataClockSkew = time.Minute
+
+// InitDataValidator checks MAX WebApp init data:
+//   - hash is HMAC-SHA256 of data-check-string with key HMAC-SHA256("WebAppData", bot token);
+//   - auth_date is not older than maxAge;
+//   - the same init data (query_id, or hash if there is none) is accepted only once within maxAge.
+//
+// Seen init data is kept in memory of the instance, so behind several instances one init data can be
+// replayed once per instance. maxAge bounds the replay window either way.
+type InitDataValidator struct {
+	secretKey []byte
+	maxAge    time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewInitDataValidator(botToken string, maxAge time.Duration) *InitDataValidator {
+	mac := hmac.New(sha256.New, []byte("WebAppData"))
+	mac.Write([]byte(botToken))
+
+	return &InitDataValidator{
+		secretKey: mac.Sum(nil),
+		maxAge:    maxAge,
+		now:       time.Now,
+		seen:      make(map[string]time.Time),
+	}
+}
+
+// Validate checks init data passed as form values (every field of init data plus hash).
+// Every field must be passed once: with repeated fields the signed data-check-string is ambiguous.
+func (v *InitDataValidator) Validate(form url.Values) error {
+	for _, values := range form {
+		if len(values) != 1 {
+			return ErrInitDataInvalid
+		}
+	}
+
+	receivedHash, err := hex.DecodeString(form.Get("hash"))
+	if err != nil || len(receivedHash) == 0 {
+		return ErrInitDataInvalid
+	}
+
+	if !hmac.Equal(v.Sign(form), receivedHash) {
+		return ErrInitDataInvalid
+	}
+
+	authDate, err := strconv.ParseInt(form.Get("auth_date"), 10, 64)
+	if err != nil {
+		return ErrInitDataInvalid
+	}
+
+	now := v.now()
+	issued := time.Unix(authDate, 0)
+	if issued.After(now.Add(initDataClockSkew)) || now.Sub(issued) > v.maxAge {
+		return ErrInitDataExpired
+	}
+
+	key := form.Get("query_id")
+	if key == "" {
+		key = form.Get("hash")
+	}
+	if !v.markSeen(key, issued.Add(v.maxAge), now) {
+		return ErrInitDataReplay
+	}
+
+	return nil
+}
+
+// Sign returns HMAC of data-check-string: all fields except hash as key=value sorted by key, joined by \n.
+func (v *InitDataValidator) Sign(form url.Values) []byte {
+	pairs := make([]string, 0, len(form))
+	for key, values := range form {
+		if key == "hash" || len(values) == 0 {
+			continue
+		}
+		pairs = append(pairs, key+"="+values[0])
+	}
+	sort.Strings(pairs)
+
+	mac := hmac.New(sha256.New, v.secretKey)
+	mac.Write([]byte(strings.Join(pairs, "\n")))
+	return mac.Sum(nil)
+}
+
+func (v *InitDataValidator) markSeen(key string, expires, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if now.Sub(v.lastSweep) > time.Minute {
+		for k, exp := range v.seen {
+			if exp.Before(now) {
+				delete(v.seen, k)
+			}
+		}
+		v.lastSweep = now
+	}
+
+	if exp, ok := v.seen[key]; ok && exp.After(now) {
+		return false
+	}
+	v.seen[key] = expires
+	return true
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const testBotToken = "123456:test-bot-token"
+
+// testInitData is signed with testBotToken, the hash is computed independently of InitDataValidator.Sign.
+func testInitData() url.Values {
+	return url.Values{
+		"auth_date": {"1760000000"},
+		"query_id":  {"AAHdF6IQAAAAAN0XohDhrOrc"},
+		"user":      {`{"id":42,"first_name":"Ivan"}`},
+		"hash":      {"45ec69038c224c14c9bc2b782eec74bcc319cdb5652aba5da3b4f50cb027c931"},
+	}
+}
+
+func TestInitDataValidatorValidate(t *testing.T) {
+	authDate := time.Unix(1760000000, 0)
+
+	tests := []struct {
+		name   string
+		modify func(form url.Values)
+		now    time.Time
+		want   error
+	}{
+		{
+			name: "valid",
+			now:  authDate.Add(time.Minute),
+		},
+		{
+			name:   "tampered user",
+			modify: func(form url.Values) { form.Set("user", `{"id":1,"first_name":"Ivan"}`) },
+			now:    authDate.Add(time.Minute),
+			want:   ErrInitDataInvalid,
+		},
+		{
+			name: "bad hash",
+			modify: func(form url.Values) {
+				form.Set("hash", "55ec69038c224c14c9bc2b782eec74bcc319cdb5652aba5da3b4f50cb027c931")
+			},
+			now:  authDate.Add(time.Minute),
+			want: ErrInitDataInvalid,
+		},
+		{
+			name:   "hash is not hex",
+			modify: func(form url.Values) { form.Set("hash", "not-a-hash") },
+			now:    authDate.Add(time.Minute),
+			want:   ErrInitDataInvalid,
+		},
+		{
+			name:   "missing hash",
+			modify: func(form url.Values) { form.Del("hash") },
+			now:    authDate.Add(time.Minute),
+			want:   ErrInitDataInvalid,
+		},
+		{
+			name:   "duplicated hash",
+			modify: func(form url.Values) { form.Add("hash", form.Get("hash")) },
+			now:    authDate.Add(time.Minute),
+			want:   ErrInitDataInvalid,
+		},
+		{
+			name:   "duplicated field",
+			modify: func(form url.Values) { form.Add("user", `{"id":1,"first_name":"Ivan"}`) },
+			now:    authDate.Add(time.Minute),
+			want:   ErrInitDataInvalid,
+		},
+		{
+			name: "expired auth_date",
+			now:  authDate.Add(time.Hour + time.Second),
+			want: ErrInitDataExpired,
+		},
+		{
+			name: "auth_date in the future",
+			now:  authDate.Add(-2 * initDataClockSkew),
+			want: ErrInitDataExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewInitDataValidator(testBotToken, time.Hour)
+			v.now = func() time.Time { return tt.now }
+
+			form := testInitData()
+			if tt.modify != nil {
+				tt.modify(form)
+			}
+
+			if err := v.Validate(form); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitDataValidatorReplay(t *testing.T) {
+	v := NewInitDataValidator(testBotToken, time.Hour)
+	v.now = func() time.Time { return time.Unix(1760000000, 0).Add(time.Minute) }
+
+	if err := v.Validate(testInitData()); err != nil {
+		t.Fatalf("first Validate() = %v", err)
+	}
+	if err := v.Validate(testInitData()); !errors.Is(err, ErrInitDataReplay) {
+		t.Errorf("second Validate() = %v, want %v", err, ErrInitDataReplay)
+	}
+}
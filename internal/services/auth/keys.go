@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+
+	keyringRefreshPeriod = time.Minute
+)
+
+type verificationKey struct {
+	alg       string
+	publicKey crypto.PublicKey
+}
+
+// Keyring holds asymmetric signing keys shared by all instances through db.
+// The newest key signs new tokens, older keys stay valid for verification until they expire,
+// which gives issued tokens an overlap period after every rotation.
+type Keyring struct {
+	repo     repositories.JWTKeysRepository
+	logger   logging.Logger
+	alg      string
+	rotation time.Duration
+	overlap  time.Duration
+
+	mu         sync.RWMutex
+	signingKID string
+	signingKey crypto.PrivateKey
+	keys       map[string]verificationKey
+	order      []string
+}
+
+func NewKeyring(repo repositories.JWTKeysRepository, logger logging.Logger, alg string, rotation, overlap time.Duration) (*Keyring, error) {
+	if alg != AlgRS256 && alg != AlgEdDSA {
+		return nil, fmt.Errorf("unsupported signing alg %q", alg)
+	}
+
+	return &Keyring{
+		repo:     repo,
+		logger:   logger,
+		alg:      alg,
+		rotation: rotation,
+		overlap:  overlap,
+		keys:     make(map[string]verificationKey),
+	}, nil
+}
+
+// Load rotates the signing key if it is stale and reloads all active keys from db.
+func (k *Keyring) Load(ctx context.Context) error {
+	rotated, err := k.repo.RotateKey(ctx, k.alg, time.Now().Add(-k.rotation), k.generate)
+	if err != nil {
+		return err
+	}
+	if rotated {
+		k.logger.Print(ctx, "jwt signing key rotated", "alg", k.alg)
+	}
+
+	stored, err := k.repo.GetActiveKeys(ctx, k.alg)
+	if err != nil {
+		return err
+	}
+	if len(stored) == 0 {
+		return fmt.Errorf("no active %s keys", k.alg)
+	}
+
+	keys := make(map[string]verificationKey, len(stored))
+	order := make([]string, 0, len(stored))
+	for _, key := range stored {
+		pub, err := x509.ParsePKIXPublicKey(key.PublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to parse public key %s: %w", key.KID, err)
+		}
+		keys[key.KID] = verificationKey{alg: key.Alg, publicKey: pub}
+		order = append(order, key.KID)
+	}
+
+	// keys are sorted by created_at desc
+	signing, err := x509.ParsePKCS8PrivateKey(stored[0].PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse private key %s: %w", stored[0].KID, err)
+	}
+
+	k.mu.Lock()
+	k.signingKID = stored[0].KID
+	k.signingKey = signing
+	k.keys = keys
+	k.order = order
+	k.mu.Unlock()
+
+	return nil
+}
+
+// Run reloads keys periodically, so a rotation made by any instance is picked up.
+func (k *Keyring) Run(ctx context.Context) {
+	ticker := time.NewTicker(keyringRefreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := k.Load(ctx); err != nil {
+				k.logger.Errorf("[Keyring] failed to reload jwt keys: %v", err)
+			}
+		}
+	}
+}
+
+func (k *Keyring) SigningMethod() jwt.SigningMethod {
+	if k.alg == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+func (k *Keyring) SigningKey() (string, crypto.PrivateKey) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.signingKID, k.signingKey
+}
+
+func (k *Keyring) VerificationKey(kid, alg string) (crypto.PublicKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok || key.alg != alg {
+		return nil, false
+	}
+	return key.publicKey, true
+}
+
+// JWK is a public key in RFC 7517 format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns all public keys that may have signed a still valid token.
+func (k *Keyring) JWKS() JWKS {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := JWKS{Keys: make([]JWK, 0, len(k.order))}
+	for _, kid := range k.order {
+		key := k.keys[kid]
+		jwk := JWK{KeyID: kid, Use: "sig", Algorithm: key.alg}
+
+		switch pub := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func (k *Keyring) generate() (models.JWTKey, error) {
+	var (
+		private crypto.PrivateKey
+		public  crypto.PublicKey
+	)
+
+	switch k.alg {
+	case AlgEdDSA:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return models.JWTKey{}, err
+		}
+		private, public = priv, pub
+	default:
+		priv, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return models.JWTKey{}, err
+		}
+		private, public = priv, &priv.PublicKey
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return models.JWTKey{}, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return models.JWTKey{}, err
+	}
+
+	now := time.Now()
+	return models.JWTKey{
+		KID:        uuid.NewString(),
+		Alg:        k.alg,
+		PrivateKey: privateDER,
+		PublicKey:  publicDER,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(k.rotation + k.overlap),
+	}, nil
+}
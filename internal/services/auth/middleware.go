@@ -1,29 +1,29 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
 	"strings"
-	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
-	"github.com/vmkteam/embedlog"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/reqctx"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/services"
 )
 
-const UserKey = "user"
+const (
+	UserKey  = "user"
+	ActorKey = "actor"
+)
 
 func (s *JWTService) JWTMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 
-			log := c.Get("logger").(embedlog.Logger)
+			log := logging.FromContext(c.Request().Context())
 
 			reqID := c.Response().Header().Get(echo.HeaderXRequestID)
-			if reqID == "" {
-				reqID = fmt.Sprintf("%d", time.Now().UnixNano())
-				c.Response().Header().Set(echo.HeaderXRequestID, reqID)
-			}
 
 			method := c.Request().Method
 			path := c.Request().RequestURI
@@ -33,20 +33,30 @@ func (s *JWTService) JWTMiddleware() echo.MiddlewareFunc {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
 				log.Errorf("[JWTMiddleware] AUTH_FAIL %s %s reason=missing_auth_header", method, path)
-				return echo.NewHTTPError(http.StatusUnauthorized, "Missing authorization header")
+				return services.Unauthorized("missing authorization header")
 			}
 
 			parts := strings.SplitN(authHeader, " ", 2)
 			if len(parts) != 2 || parts[0] != "Bearer" {
 				log.Errorf("[JWTMiddleware] AUTH_FAIL %s %s reason=invalid_auth_format", method, path)
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authorization format")
+				return services.Unauthorized("invalid authorization format")
 			}
 
 			tokenString := parts[1]
 			claims, err := s.ParseToken(tokenString)
 			if err != nil {
-				log.Errorf("[JWTMiddleware] AUTH_FAIL token parse failed: %v. token input: %v", err, tokenString)
-				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token: "+err.Error())
+				// the cause is logged only, jwt errors tell clients how tokens are checked
+				log.Errorf("[JWTMiddleware] AUTH_FAIL %s %s reason=token_parse err=%v", method, path, err)
+				return services.Unauthorized("invalid token")
+			}
+
+			if err := s.CheckTokenVersion(c.Request().Context(), claims); err != nil {
+				if errors.Is(err, ErrTokenRevoked) {
+					log.Errorf("[JWTMiddleware] AUTH_FAIL %s %s reason=token_revoked max_id_user=%d", method, path, claims.ID)
+					return services.Unauthorized("token revoked")
+				}
+				log.Errorf("[JWTMiddleware] AUTH_FAIL %s %s reason=token_version_check err=%v", method, path, err)
+				return fmt.Errorf("failed to check token version: %w", err)
 			}
 			user := &models.User{
 				ID:               int64(claims.ID),
@@ -61,6 +71,22 @@ func (s *JWTService) JWTMiddleware() echo.MiddlewareFunc {
 			}
 			c.Set(UserKey, user)
 
+			actor := reqctx.Actor{UserID: user.ID, RequestID: reqID}
+			log = log.With("user_id", user.ID)
+			if claims.Act != nil {
+				actor.ImpersonatorID = claims.Act.ID
+				log = log.With("impersonator_id", claims.Act.ID)
+			}
+
+			ctx := reqctx.WithActor(c.Request().Context(), actor)
+			c.SetRequest(c.Request().WithContext(logging.WithContext(ctx, log)))
+
+			if claims.Act != nil {
+				c.Set(ActorKey, claims.Act.ID)
+				log.Printf("[JWTMiddleware] AUTH_SUCCESS %s %s max_id_user=%d actor_id=%d", method, path, claims.ID, claims.Act.ID)
+				return next(c)
+			}
+
 			log.Printf("[JWTMiddleware] AUTH_SUCCESS %s %s max_id_user=%d", method, path, claims.ID)
 			return next(c)
 		}
@@ -73,3 +99,9 @@ func GetUserFromContext(c echo.Context) *models.User {
 	}
 	return nil
 }
+
+// GetActorFromContext returns id of the admin acting as the current user, false if the request is not impersonated.
+func GetActorFromContext(c echo.Context) (int64, bool) {
+	actorID, ok := c.Get(ActorKey).(int64)
+	return actorID, ok
+}
@@ -1,45 +1,65 @@
 package auth
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"log"
-	"sort"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	config "github.com/max-main-team/backend_hackaton_MAX/cfg"
-	"github.com/max-main-team/backend_hackaton_MAX/internal/http/dto"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 )
 
+var ErrTokenRevoked = errors.New("token revoked")
+
 type JWTService struct {
 	secret []byte
 	Expiry time.Duration
+	// ImpersonationExpiry is lifetime of tokens issued to admins acting as another user.
+	ImpersonationExpiry time.Duration
+	versions            *TokenVersionCache
+	// keyring is nil for HS256, then tokens are signed with secret.
+	keyring *Keyring
+	// legacyUntil is when HS256 tokens stop being accepted with a keyring, zero if they are not.
+	legacyUntil time.Time
 }
 
 func (s *JWTService) RefreshExpiry() time.Duration {
 	return s.Expiry * 24
 }
-func NewJWTService(cfg config.Config) *JWTService {
+func NewJWTService(cfg config.Config, versions *TokenVersionCache, keyring *Keyring) *JWTService {
 
-	if cfg.AuthConfig.JWTSecret == "" {
+	if keyring == nil && cfg.AuthConfig.JWTSecret == "" {
 		log.Fatal("JWT secret is empty! Check your config file")
 	}
-	log.Printf("JWTService created with secret length: %d", len(cfg.AuthConfig.JWTSecret))
+	log.Printf("JWTService created with alg: %s, secret length: %d", cfg.AuthConfig.SigningAlg, len(cfg.AuthConfig.JWTSecret))
+
+	// the time is checked by cfg.Validate
+	var legacyUntil time.Time
+	if keyring != nil && cfg.AuthConfig.AcceptLegacyHS256 {
+		legacyUntil, _ = time.Parse(time.RFC3339, cfg.AuthConfig.LegacyHS256Until)
+	}
 
 	return &JWTService{
-		secret: []byte(cfg.AuthConfig.JWTSecret),
-		Expiry: time.Duration(cfg.AuthConfig.JWTAccessExpiry) * time.Hour,
+		secret:      []byte(cfg.AuthConfig.JWTSecret),
+		legacyUntil: legacyUntil,
+		Expiry:      time.Duration(cfg.AuthConfig.JWTAccessExpiry) * time.Hour,
+
+		ImpersonationExpiry: time.Duration(cfg.AuthConfig.ImpersonationExpiry) * time.Minute,
+		versions:            versions,
+		keyring:             keyring,
 	}
 }
 
-func (s *JWTService) GenerateToken(ID int, LastAstiveName int, FirstName string, LastName *string, UserName *string, Description *string, AvatarUrl *string, FullAvatarUrl *string, IsBot bool) (string, error) {
+func (s *JWTService) GenerateToken(ctx context.Context, ID int, LastAstiveName int, FirstName string, LastName *string, UserName *string, Description *string, AvatarUrl *string, FullAvatarUrl *string, IsBot bool) (string, error) {
+	version, err := s.versions.Current(ctx, int64(ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get token version: %w", err)
+	}
+
 	claims := &Claims{
 		ID:             ID,
 		FirstName:      FirstName,
@@ -50,25 +70,88 @@ func (s *JWTService) GenerateToken(ID int, LastAstiveName int, FirstName string,
 		Description:    getStringValue(Description),
 		AvatarUrl:      getStringValue(AvatarUrl),
 		FullAvatarUrl:  getStringValue(FullAvatarUrl),
+		TokenVersion:   version,
 
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.Expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "max_app_api",
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secret)
+	return s.sign(claims)
+}
+
+// GenerateImpersonationToken issues a short-lived access token of user with act claim of actor.
+// No refresh token is issued, the admin has to start impersonation again when it expires.
+func (s *JWTService) GenerateImpersonationToken(ctx context.Context, user *models.User, actorID int64) (string, error) {
+	version, err := s.versions.Current(ctx, user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token version: %w", err)
+	}
+
+	claims := &Claims{
+		ID:             int(user.ID),
+		FirstName:      user.FirstName,
+		LastName:       getStringValue(user.LastName),
+		UserName:       getStringValue(user.UserName),
+		IsBot:          user.IsBot,
+		LastAstiveName: user.LastActivityTime,
+		Description:    getStringValue(user.Description),
+		AvatarUrl:      getStringValue(user.AvatarUrl),
+		FullAvatarUrl:  getStringValue(user.FullAvatarUrl),
+		TokenVersion:   version,
+		Act:            &Actor{ID: actorID},
+
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.ImpersonationExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "max_app_api",
+		},
+	}
+
+	return s.sign(claims)
+}
+
+func (s *JWTService) sign(claims *Claims) (string, error) {
+	if s.keyring == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString(s.secret)
+	}
+
+	kid, key := s.keyring.SigningKey()
+	token := jwt.NewWithClaims(s.keyring.SigningMethod(), claims)
+	token.Header["kid"] = kid
+	return token.SignedString(key)
 }
 
+// ParseToken verifies tokens signed with the configured alg: HS256 with jwt_secret or any active key
+// of the keyring. With a keyring HS256 tokens are accepted only until auth.legacy_hs256_until,
+// otherwise anyone holding the shared secret could mint tokens.
 func (s *JWTService) ParseToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method == jwt.SigningMethodHS256 {
+			if s.keyring != nil && !time.Now().Before(s.legacyUntil) {
+				return nil, jwt.ErrSignatureInvalid
+			}
+			if len(s.secret) == 0 {
+				return nil, jwt.ErrSignatureInvalid
+			}
+			return s.secret, nil
+		}
+
+		if s.keyring == nil {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return s.secret, nil
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keyring.VerificationKey(kid, token.Method.Alg())
+		if !ok {
+			return nil, jwt.ErrTokenUnverifiable
+		}
+		return key, nil
 	})
 
 	if err != nil {
@@ -81,9 +164,9 @@ func (s *JWTService) ParseToken(tokenString string) (*Claims, error) {
 
 	return nil, jwt.ErrTokenInvalidClaims
 }
-func (s *JWTService) GenerateTokenPair(ID int, LastAstiveName int, FirstName string, LastName *string, UserName *string, Description *string, AvatarUrl *string, FullAvatarUrl *string, IsBot bool) (accessToken, refreshToken string, err error) {
+func (s *JWTService) GenerateTokenPair(ctx context.Context, ID int, LastAstiveName int, FirstName string, LastName *string, UserName *string, Description *string, AvatarUrl *string, FullAvatarUrl *string, IsBot bool) (accessToken, refreshToken string, err error) {
 
-	accessToken, err = s.GenerateToken(ID, LastAstiveName, FirstName, LastName, UserName, Description, AvatarUrl, FullAvatarUrl, IsBot)
+	accessToken, err = s.GenerateToken(ctx, ID, LastAstiveName, FirstName, LastName, UserName, Description, AvatarUrl, FullAvatarUrl, IsBot)
 	if err != nil {
 		return "", "", err
 	}
@@ -95,59 +178,29 @@ func (s *JWTService) GenerateTokenPair(ID int, LastAstiveName int, FirstName str
 	return accessToken, signed, nil
 }
 
-func ValidateInitData(initData *dto.WebAppInitData, botToken string) bool {
-
-	dataCheckString := createDataCheckString(initData)
-
-	secretKey := createSecretKey(botToken)
-
-	computedHash := computeSignature(secretKey, dataCheckString)
-
-	return computedHash == initData.Hash
-}
-
-func createDataCheckString(initData *dto.WebAppInitData) string {
-	params := make(map[string]string)
-
-	params["auth_date"] = strconv.FormatInt(int64(initData.AuthDate), 10)
-	params["query_id"] = initData.QueryID
-
-	if initData.StartParam != "" {
-		params["start_param"] = initData.StartParam
-	}
-
-	userJSON, _ := json.Marshal(initData.User)
-	params["user"] = string(userJSON)
-
-	if initData.Chat.ID != 0 {
-		chatJSON, _ := json.Marshal(initData.Chat)
-		params["chat"] = string(chatJSON)
-	}
-
-	var keys []string
-	for key := range params {
-		keys = append(keys, key)
+// CheckTokenVersion returns ErrTokenRevoked if claims were issued before the last role change of the user.
+func (s *JWTService) CheckTokenVersion(ctx context.Context, claims *Claims) error {
+	current, err := s.versions.Current(ctx, int64(claims.ID))
+	if err != nil {
+		return fmt.Errorf("failed to get token version: %w", err)
 	}
-	sort.Strings(keys)
-
-	var pairs []string
-	for _, key := range keys {
-		pairs = append(pairs, fmt.Sprintf("%s=%s", key, params[key]))
+	if claims.TokenVersion < current {
+		return ErrTokenRevoked
 	}
-
-	return strings.Join(pairs, "\n")
+	return nil
 }
 
-func createSecretKey(botToken string) []byte {
-	mac := hmac.New(sha256.New, []byte("WebAppData"))
-	mac.Write([]byte(botToken))
-	return mac.Sum(nil)
+// JWKS returns public keys for independent token verification. Empty for HS256.
+func (s *JWTService) JWKS() JWKS {
+	if s.keyring == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return s.keyring.JWKS()
 }
 
-func computeSignature(secretKey []byte, dataCheckString string) string {
-	mac := hmac.New(sha256.New, secretKey)
-	mac.Write([]byte(dataCheckString))
-	return hex.EncodeToString(mac.Sum(nil))
+// RevokeUserTokens invalidates all access tokens issued to user so far.
+func (s *JWTService) RevokeUserTokens(ctx context.Context, userID int64) error {
+	return s.versions.Revoke(ctx, userID)
 }
 
 func getStringValue(s *string) string {
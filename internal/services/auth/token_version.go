@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+const listenRetryDelay = 5 * time.Second
+
+// TokenVersionCache keeps current token versions of users in memory.
+// Entries are invalidated by Postgres NOTIFY, so every instance sees a bump right after commit.
+// While the listener is disconnected the cache is bypassed and versions are read from db.
+type TokenVersionCache struct {
+	repo   repositories.TokenVersionRepository
+	logger logging.Logger
+
+	mu       sync.RWMutex
+	versions map[int64]int64
+	// generation is bumped by every invalidation, a version read from db is cached only
+	// if no invalidation happened while it was read, otherwise it may be stale already
+	generation uint64
+	listening  atomic.Bool
+}
+
+func NewTokenVersionCache(repo repositories.TokenVersionRepository, logger logging.Logger) *TokenVersionCache {
+	return &TokenVersionCache{
+		repo:     repo,
+		logger:   logger,
+		versions: make(map[int64]int64),
+	}
+}
+
+// Current returns the current token version of user.
+func (c *TokenVersionCache) Current(ctx context.Context, userID int64) (int64, error) {
+	if !c.listening.Load() {
+		return c.repo.GetTokenVersion(ctx, userID)
+	}
+
+	c.mu.RLock()
+	version, ok := c.versions[userID]
+	generation := c.generation
+	c.mu.RUnlock()
+	if ok {
+		return version, nil
+	}
+
+	version, err := c.repo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.versions[userID] = version
+	}
+	c.mu.Unlock()
+
+	return version, nil
+}
+
+// Revoke bumps user's token version, so all issued access tokens stop working.
+func (c *TokenVersionCache) Revoke(ctx context.Context, userID int64) error {
+	if err := c.repo.BumpTokenVersion(ctx, userID); err != nil {
+		return err
+	}
+	c.invalidate(userID)
+	return nil
+}
+
+// Run listens for token version bumps until ctx is done, reconnecting on errors.
+func (c *TokenVersionCache) Run(ctx context.Context) {
+	for {
+		err := c.repo.ListenTokenVersions(ctx, c.onListen, c.invalidate)
+		c.listening.Store(false)
+
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Errorf("[TokenVersionCache] listener stopped: %v. retry in %s", err, listenRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// onListen drops everything cached so far: notifications could be missed while disconnected.
+func (c *TokenVersionCache) onListen() {
+	c.mu.Lock()
+	c.versions = make(map[int64]int64)
+	c.generation++
+	c.mu.Unlock()
+
+	c.listening.Store(true)
+}
+
+func (c *TokenVersionCache) invalidate(userID int64) {
+	c.mu.Lock()
+	delete(c.versions, userID)
+	c.generation++
+	c.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/logging"
+	"github.com/vmkteam/embedlog"
+)
+
+// versionsRepo returns version and runs onRead while the version is being read.
+type versionsRepo struct {
+	version int64
+	reads   int
+	onRead  func()
+}
+
+func (r *versionsRepo) GetTokenVersion(context.Context, int64) (int64, error) {
+	r.reads++
+	version := r.version
+	if r.onRead != nil {
+		r.onRead()
+	}
+	return version, nil
+}
+
+func (r *versionsRepo) BumpTokenVersion(context.Context, int64) error {
+	r.version++
+	return nil
+}
+
+func (r *versionsRepo) ListenTokenVersions(context.Context, func(), func(int64)) error {
+	return nil
+}
+
+func TestTokenVersionCacheSkipsStaleRead(t *testing.T) {
+	const userID = 1
+	repo := &versionsRepo{}
+	c := NewTokenVersionCache(repo, logging.New(embedlog.NewDevLogger()))
+	c.onListen()
+
+	// the bump is committed and notified after the old version was read, before it is cached
+	repo.onRead = func() {
+		repo.version++
+		c.invalidate(userID)
+	}
+	version, err := c.Current(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 {
+		t.Fatalf("version = %d, want 0 read before the bump", version)
+	}
+
+	repo.onRead = nil
+	version, err = c.Current(context.Background(), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 1 {
+		t.Errorf("version = %d, want 1: stale version was cached", version)
+	}
+
+	if _, err := c.Current(context.Background(), userID); err != nil {
+		t.Fatal(err)
+	}
+	if repo.reads != 2 {
+		t.Errorf("reads = %d, want 2: fresh version is not cached", repo.reads)
+	}
+}
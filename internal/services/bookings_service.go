@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/bookings"
+	bookings2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/bookings"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// BookingsService books rooms for meetings, exams and club events. Teachers and students request bookings,
+// admins confirm or reject them, bookings of admins are confirmed at once.
+type BookingsService struct {
+	repo repositories.BookingsRepository
+}
+
+func NewBookingsService(repo repositories.BookingsRepository) *BookingsService {
+	return &BookingsService{repo: repo}
+}
+
+func (s *BookingsService) CreateBooking(ctx context.Context, userID int64, request bookings.CreateBookingRequest) (*bookings.CreateBookingResponse, error) {
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		return nil, Validation("invalid date", FieldError{Field: "date", Code: "datetime", Message: "must be a date in format " + dateLayout})
+	}
+	today := time.Now().Format(dateLayout)
+	if request.Date < today {
+		return nil, Validation("booking date has passed", FieldError{Field: "date", Code: "gte", Message: "must not be before " + today})
+	}
+
+	id, status, err := s.repo.CreateBooking(ctx, userID, bookings2.Booking{
+		RoomID:  request.RoomID,
+		ClassID: request.ClassID,
+		Date:    date,
+		Purpose: bookings2.Purpose(request.Purpose),
+		Title:   request.Title,
+		Comment: request.Comment,
+	})
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return &bookings.CreateBookingResponse{ID: id, Status: string(status)}, nil
+}
+
+func (s *BookingsService) GetUserBookings(ctx context.Context, userID int64) ([]bookings.BookingResponse, error) {
+	result, err := s.repo.GetUserBookings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return bookingResponses(result), nil
+}
+
+func (s *BookingsService) GetBookings(ctx context.Context, adminID int64, request bookings.BookingsRequest) ([]bookings.BookingResponse, error) {
+	filter := bookings2.Filter{
+		UniversityID: request.UniversityID,
+		RoomID:       request.RoomID,
+	}
+	if request.Status != nil {
+		status := bookings2.Status(*request.Status)
+		filter.Status = &status
+	}
+
+	var err error
+	if filter.From, err = parseDate("from", request.From); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseDate("to", request.To); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.GetBookings(ctx, adminID, filter)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return bookingResponses(result), nil
+}
+
+func (s *BookingsService) Confirm(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.Decide(ctx, adminID, id, bookings2.Confirmed))
+}
+
+func (s *BookingsService) Reject(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.Decide(ctx, adminID, id, bookings2.Rejected))
+}
+
+func (s *BookingsService) Cancel(ctx context.Context, userID, id int64) error {
+	return FromDB(s.repo.Cancel(ctx, userID, id))
+}
+
+// parseDate parses an optional date of the field.
+func parseDate(field string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, *value)
+	if err != nil {
+		return nil, Validation("invalid "+field, FieldError{Field: field, Code: "datetime", Message: "must be a date in format " + dateLayout})
+	}
+	return &date, nil
+}
+
+func bookingResponses(result []bookings2.Booking) []bookings.BookingResponse {
+	response := make([]bookings.BookingResponse, 0, len(result))
+	for _, b := range result {
+		response = append(response, bookings.BookingResponse{
+			ID:                 b.ID,
+			UniversityID:       b.UniversityID,
+			RoomID:             b.RoomID,
+			Room:               b.Room,
+			ClassID:            b.ClassID,
+			PairNumber:         b.PairNumber,
+			StartTime:          b.StartTime.Format("15:04"),
+			EndTime:            b.EndTime.Format("15:04"),
+			Date:               b.Date.Format(dateLayout),
+			Purpose:            string(b.Purpose),
+			Title:              b.Title,
+			Comment:            b.Comment,
+			Status:             string(b.Status),
+			RequestedBy:        b.RequestedBy,
+			RequesterFirstName: b.RequesterFirstName,
+			RequesterLastName:  b.RequesterLastName,
+			DecidedBy:          b.DecidedBy,
+			DecidedAt:          b.DecidedAt,
+			CreatedAt:          b.CreatedAt,
+		})
+	}
+	return response
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/curriculum"
+	curriculum2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/curriculum"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+const dateLayout = "2006-01-02"
+
+// CurriculumService manages what courses study by semester and who teaches it to course and elective groups.
+type CurriculumService struct {
+	repo repositories.CurriculumRepository
+}
+
+func NewCurriculumService(repo repositories.CurriculumRepository) *CurriculumService {
+	return &CurriculumService{repo: repo}
+}
+
+func (s *CurriculumService) GetSemesters(ctx context.Context, adminID int64) ([]curriculum.SemesterResponse, error) {
+	semesters, err := s.repo.GetSemesters(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]curriculum.SemesterResponse, 0, len(semesters))
+	for _, semester := range semesters {
+		response = append(response, curriculum.SemesterResponse{
+			ID:           semester.ID,
+			UniversityID: semester.UniversityID,
+			StartDate:    semester.StartDate.Format(dateLayout),
+			EndDate:      semester.EndDate.Format(dateLayout),
+		})
+	}
+	return response, nil
+}
+
+func (s *CurriculumService) AddCourseSubject(ctx context.Context, adminID, courseID int64, request curriculum.AddCourseSubjectRequest) (int64, error) {
+	id, err := s.repo.AddCourseSubject(ctx, adminID, curriculum2.CourseSubject{
+		CourseID:            courseID,
+		SemesterID:          request.SemesterID,
+		UniversitySubjectID: request.UniversitySubjectID,
+		IsElective:          request.IsElective,
+		RequiredHours:       request.RequiredHours,
+	})
+	return id, FromDB(err)
+}
+
+func (s *CurriculumService) GetCourseSubjects(ctx context.Context, adminID, courseID, semesterID int64) ([]curriculum.CourseSubjectResponse, error) {
+	subjects, err := s.repo.GetCourseSubjects(ctx, adminID, courseID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]curriculum.CourseSubjectResponse, 0, len(subjects))
+	for _, subject := range subjects {
+		response = append(response, courseSubjectResponse(subject))
+	}
+	return response, nil
+}
+
+func (s *CurriculumService) UpdateCourseSubject(ctx context.Context, adminID, id int64, request curriculum.UpdateCourseSubjectRequest) error {
+	return FromDB(s.repo.UpdateCourseSubjectHours(ctx, adminID, id, request.RequiredHours))
+}
+
+func (s *CurriculumService) DeleteCourseSubject(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteCourseSubject(ctx, adminID, id))
+}
+
+func (s *CurriculumService) GetGroupCurriculum(ctx context.Context, adminID, groupID, semesterID int64) ([]curriculum.CurriculumItemResponse, error) {
+	items, err := s.repo.GetGroupCurriculum(ctx, adminID, groupID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]curriculum.CurriculumItemResponse, 0, len(items))
+	for _, item := range items {
+		itemResponse := curriculum.CurriculumItemResponse{Subject: courseSubjectResponse(item.Subject)}
+		if a := item.Assignment; a != nil {
+			itemResponse.Assignment = &curriculum.AssignmentResponse{
+				ID:          a.ID,
+				SubjectType: string(a.SubjectType),
+				Teacher: curriculum.TeacherResponse{
+					UserID:    a.Teacher.UserID,
+					FirstName: a.Teacher.FirstName,
+				},
+			}
+			if a.Teacher.LastName != nil {
+				itemResponse.Assignment.Teacher.LastName = *a.Teacher.LastName
+			}
+		}
+		response = append(response, itemResponse)
+	}
+	return response, nil
+}
+
+func (s *CurriculumService) AssignGroupSubject(ctx context.Context, adminID, groupID int64, request curriculum.AssignTeacherRequest) (int64, error) {
+	id, err := s.repo.AssignGroupSubject(ctx, adminID, curriculum2.GroupSubject{
+		CourseGroupID:   groupID,
+		CourseSubjectID: request.CourseSubjectID,
+		SubjectType:     curriculum2.SubjectType(request.SubjectType),
+		Teacher:         curriculum2.Teacher{UserID: request.TeacherUserID},
+	})
+	return id, FromDB(err)
+}
+
+func (s *CurriculumService) UpdateGroupSubject(ctx context.Context, adminID, id int64, request curriculum.UpdateAssignmentRequest) error {
+	return FromDB(s.repo.UpdateGroupSubject(ctx, adminID, curriculum2.GroupSubject{
+		ID:          id,
+		SubjectType: curriculum2.SubjectType(request.SubjectType),
+		Teacher:     curriculum2.Teacher{UserID: request.TeacherUserID},
+	}))
+}
+
+func (s *CurriculumService) DeleteGroupSubject(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteGroupSubject(ctx, adminID, id))
+}
+
+func (s *CurriculumService) AssignElectiveSubject(ctx context.Context, adminID, electiveGroupID int64, request curriculum.AssignElectiveTeacherRequest) (int64, error) {
+	id, err := s.repo.AssignElectiveSubject(ctx, adminID, curriculum2.ElectiveSubject{
+		ElectiveGroupID: electiveGroupID,
+		SubjectType:     curriculum2.SubjectType(request.SubjectType),
+		Teacher:         curriculum2.Teacher{UserID: request.TeacherUserID},
+	})
+	return id, FromDB(err)
+}
+
+func (s *CurriculumService) DeleteElectiveSubject(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteElectiveSubject(ctx, adminID, id))
+}
+
+func courseSubjectResponse(cs curriculum2.CourseSubject) curriculum.CourseSubjectResponse {
+	return curriculum.CourseSubjectResponse{
+		ID:                  cs.ID,
+		CourseID:            cs.CourseID,
+		SemesterID:          cs.SemesterID,
+		SemesterStart:       cs.SemesterStart.Format(dateLayout),
+		SemesterEnd:         cs.SemesterEnd.Format(dateLayout),
+		UniversitySubjectID: cs.UniversitySubjectID,
+		SubjectName:         cs.SubjectName,
+		IsElective:          cs.IsElective,
+		RequiredHours:       cs.RequiredHours,
+	}
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/electives"
+	electives2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/electives"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// ElectivesService manages elective groups and their selection windows, students choose electives
+// while a window is open: first come first served or ranked preferences drawn by lottery after it closes.
+type ElectivesService struct {
+	repo repositories.ElectivesRepository
+}
+
+func NewElectivesService(repo repositories.ElectivesRepository) *ElectivesService {
+	return &ElectivesService{repo: repo}
+}
+
+func (s *ElectivesService) CreateGroup(ctx context.Context, adminID int64, request electives.CreateGroupRequest) (int64, error) {
+	id, err := s.repo.CreateGroup(ctx, adminID, electives2.Group{
+		Name:                request.Name,
+		SemesterID:          request.SemesterID,
+		UniversitySubjectID: request.UniversitySubjectID,
+		Capacity:            request.Capacity,
+	})
+	return id, FromDB(err)
+}
+
+func (s *ElectivesService) GetGroups(ctx context.Context, adminID, semesterID int64) ([]electives.GroupResponse, error) {
+	groups, err := s.repo.GetGroups(ctx, adminID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	return groupResponses(groups), nil
+}
+
+func (s *ElectivesService) UpdateGroup(ctx context.Context, adminID, id int64, request electives.UpdateGroupRequest) error {
+	return FromDB(s.repo.UpdateGroup(ctx, adminID, electives2.Group{
+		ID:       id,
+		Name:     request.Name,
+		Capacity: request.Capacity,
+	}))
+}
+
+func (s *ElectivesService) DeleteGroup(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteGroup(ctx, adminID, id))
+}
+
+func (s *ElectivesService) CreateWindow(ctx context.Context, adminID int64, request electives.CreateWindowRequest) (int64, error) {
+	id, err := s.repo.CreateWindow(ctx, adminID, electives2.Window{
+		SemesterID: request.SemesterID,
+		OpensAt:    request.OpensAt,
+		ClosesAt:   request.ClosesAt,
+		Mode:       electives2.Mode(request.Mode),
+		MaxChoices: request.MaxChoices,
+	})
+	return id, FromDB(err)
+}
+
+func (s *ElectivesService) GetWindows(ctx context.Context, adminID int64) ([]electives.WindowResponse, error) {
+	windows, err := s.repo.GetWindows(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return windowResponses(windows), nil
+}
+
+func (s *ElectivesService) UpdateWindow(ctx context.Context, adminID, id int64, request electives.UpdateWindowRequest) error {
+	return FromDB(s.repo.UpdateWindow(ctx, adminID, electives2.Window{
+		ID:         id,
+		OpensAt:    request.OpensAt,
+		ClosesAt:   request.ClosesAt,
+		MaxChoices: request.MaxChoices,
+	}))
+}
+
+// DrawLottery enrolls students of a closed lottery window, the seed is stored with the window
+// so the draw can be reproduced.
+func (s *ElectivesService) DrawLottery(ctx context.Context, adminID, windowID int64, request electives.DrawRequest) (*electives.DrawResponse, error) {
+	seed := rand.Int64()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	w, enrolled, err := s.repo.DrawLottery(ctx, adminID, windowID, seed, func(groups []electives2.Group, preferences []electives2.Preference, taken []electives2.Enrollment, maxChoices int) []electives2.Enrollment {
+		return drawLottery(uint64(seed), groups, preferences, taken, maxChoices)
+	})
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return &electives.DrawResponse{Window: windowResponse(*w), Enrolled: enrolled}, nil
+}
+
+func (s *ElectivesService) GetStudentWindows(ctx context.Context, userID int64) ([]electives.WindowResponse, error) {
+	windows, err := s.repo.GetStudentWindows(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return windowResponses(windows), nil
+}
+
+// GetStudentWindow returns groups the student can choose in the window with their choices.
+// Clashes of the window are for all offered groups, clashes of the choices only for the chosen ones.
+func (s *ElectivesService) GetStudentWindow(ctx context.Context, userID, windowID int64) (*electives.StudentWindowResponse, error) {
+	w, student, groups, err := s.repo.GetStudentWindow(ctx, userID, windowID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+
+	choices, err := s.choices(ctx, student, *w)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]int64, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+	clashes, err := s.clashes(ctx, student.GroupID, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &electives.StudentWindowResponse{
+		Window:  windowResponse(*w),
+		Groups:  groupResponses(groups),
+		Choices: *choices,
+		Clashes: clashes,
+	}, nil
+}
+
+// SetChoices replaces choices of the student in an open window and returns them with clashes.
+func (s *ElectivesService) SetChoices(ctx context.Context, userID, windowID int64, request electives.ChoicesRequest) (*electives.ChoicesResponse, error) {
+	student, err := s.repo.SetChoices(ctx, userID, windowID, request.ElectiveGroupIDs)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+
+	w, _, _, err := s.repo.GetStudentWindow(ctx, userID, windowID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return s.choices(ctx, student, *w)
+}
+
+func (s *ElectivesService) choices(ctx context.Context, student *electives2.Student, w electives2.Window) (*electives.ChoicesResponse, error) {
+	choices, err := s.repo.GetChoices(ctx, student.ID, w)
+	if err != nil {
+		return nil, err
+	}
+
+	clashes, err := s.clashes(ctx, student.GroupID, append(slices.Clone(choices.Enrolled), choices.Preferences...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &electives.ChoicesResponse{
+		Enrolled:    nonNil(choices.Enrolled),
+		Preferences: nonNil(choices.Preferences),
+		Clashes:     clashes,
+	}, nil
+}
+
+func (s *ElectivesService) clashes(ctx context.Context, courseGroupID int64, groupIDs []int64) ([]electives.ClashResponse, error) {
+	response := make([]electives.ClashResponse, 0)
+	if len(groupIDs) == 0 {
+		return response, nil
+	}
+
+	clashes, err := s.repo.GetClashes(ctx, courseGroupID, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range clashes {
+		response = append(response, electives.ClashResponse{
+			ElectiveGroupID:  c.ElectiveGroupID,
+			Day:              c.Day,
+			PairNumber:       c.PairNumber,
+			ElectiveSubject:  c.ElectiveSubject,
+			ElectiveInterval: c.ElectiveInterval,
+			GroupSubject:     c.GroupSubject,
+			GroupInterval:    c.GroupInterval,
+		})
+	}
+	return response, nil
+}
+
+// drawLottery shuffles students by seed and gives them seats in rounds: in each round every student
+// in the drawn order gets their most preferred group with a free seat, until they have maxChoices
+// groups including ones they are already enrolled in. The same input and seed give the same result.
+func drawLottery(seed uint64, groups []electives2.Group, preferences []electives2.Preference, taken []electives2.Enrollment, maxChoices int) []electives2.Enrollment {
+	seats := make(map[int64]*electives2.Group, len(groups))
+	for i := range groups {
+		seats[groups[i].ID] = &groups[i]
+	}
+
+	has := make(map[electives2.Enrollment]bool, len(taken))
+	count := make(map[int64]int)
+	for _, e := range taken {
+		has[e] = true
+		count[e.StudentID]++
+	}
+
+	ranked := make(map[int64][]electives2.Preference)
+	for _, p := range preferences {
+		ranked[p.StudentID] = append(ranked[p.StudentID], p)
+	}
+	students := make([]int64, 0, len(ranked))
+	for id, prefs := range ranked {
+		slices.SortFunc(prefs, func(a, b electives2.Preference) int { return a.Rank - b.Rank })
+		students = append(students, id)
+	}
+	slices.Sort(students)
+
+	r := rand.New(rand.NewPCG(seed, seed))
+	r.Shuffle(len(students), func(i, j int) {
+		students[i], students[j] = students[j], students[i]
+	})
+
+	var drawn []electives2.Enrollment
+	for range maxChoices {
+		for _, student := range students {
+			if count[student] >= maxChoices {
+				continue
+			}
+			for _, p := range ranked[student] {
+				e := electives2.Enrollment{StudentID: student, ElectiveGroupID: p.ElectiveGroupID}
+				g, ok := seats[p.ElectiveGroupID]
+				if has[e] || !ok || !g.HasSeat() {
+					continue
+				}
+				g.Enrolled++
+				has[e] = true
+				count[student]++
+				drawn = append(drawn, e)
+				break
+			}
+		}
+	}
+	return drawn
+}
+
+func groupResponses(groups []electives2.Group) []electives.GroupResponse {
+	response := make([]electives.GroupResponse, 0, len(groups))
+	for _, g := range groups {
+		gr := electives.GroupResponse{
+			ID:                  g.ID,
+			Name:                g.Name,
+			SemesterID:          g.SemesterID,
+			UniversitySubjectID: g.UniversitySubjectID,
+			SubjectName:         g.SubjectName,
+			Capacity:            g.Capacity,
+			Enrolled:            g.Enrolled,
+		}
+		if g.Capacity != nil {
+			free := max(*g.Capacity-g.Enrolled, 0)
+			gr.FreeSeats = &free
+		}
+		response = append(response, gr)
+	}
+	return response
+}
+
+func windowResponses(windows []electives2.Window) []electives.WindowResponse {
+	response := make([]electives.WindowResponse, 0, len(windows))
+	for _, w := range windows {
+		response = append(response, windowResponse(w))
+	}
+	return response
+}
+
+func windowResponse(w electives2.Window) electives.WindowResponse {
+	return electives.WindowResponse{
+		ID:           w.ID,
+		UniversityID: w.UniversityID,
+		SemesterID:   w.SemesterID,
+		OpensAt:      w.OpensAt,
+		ClosesAt:     w.ClosesAt,
+		Mode:         string(w.Mode),
+		MaxChoices:   w.MaxChoices,
+		IsOpen:       w.IsOpen(time.Now()) && w.DrawnAt == nil,
+		DrawnAt:      w.DrawnAt,
+		LotterySeed:  w.LotterySeed,
+	}
+}
+
+func nonNil(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
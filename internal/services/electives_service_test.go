@@ -0,0 +1,102 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	electives2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/electives"
+)
+
+func capacity(n int) *int { return &n }
+
+func TestDrawLottery(t *testing.T) {
+	groups := func() []electives2.Group {
+		return []electives2.Group{
+			{ID: 1, Capacity: capacity(1)},
+			{ID: 2, Capacity: capacity(2)},
+			{ID: 3},
+		}
+	}
+	// three students want group 1 first, it has one seat
+	preferences := []electives2.Preference{
+		{StudentID: 10, ElectiveGroupID: 1, Rank: 1},
+		{StudentID: 10, ElectiveGroupID: 2, Rank: 2},
+		{StudentID: 11, ElectiveGroupID: 1, Rank: 1},
+		{StudentID: 11, ElectiveGroupID: 2, Rank: 2},
+		{StudentID: 12, ElectiveGroupID: 2, Rank: 2},
+		{StudentID: 12, ElectiveGroupID: 1, Rank: 1},
+	}
+
+	t.Run("same seed gives the same draw", func(t *testing.T) {
+		a := drawLottery(42, groups(), preferences, nil, 1)
+		b := drawLottery(42, groups(), slices.Clone(preferences), nil, 1)
+		if !slices.Equal(a, b) {
+			t.Fatalf("draws differ: %v and %v", a, b)
+		}
+	})
+
+	t.Run("capacity and max choices", func(t *testing.T) {
+		for seed := range uint64(20) {
+			drawn := drawLottery(seed, groups(), preferences, nil, 1)
+
+			perGroup := make(map[int64]int)
+			perStudent := make(map[int64]int)
+			for _, e := range drawn {
+				perGroup[e.ElectiveGroupID]++
+				perStudent[e.StudentID]++
+			}
+			if perGroup[1] != 1 || perGroup[2] != 2 {
+				t.Fatalf("seed %d: seats taken %v, want 1 in group 1 and 2 in group 2", seed, perGroup)
+			}
+			for student, n := range perStudent {
+				if n != 1 {
+					t.Fatalf("seed %d: student %d got %d groups, want 1", seed, student, n)
+				}
+			}
+		}
+	})
+
+	t.Run("winner of a seat is random", func(t *testing.T) {
+		winners := make(map[int64]bool)
+		for seed := range uint64(50) {
+			for _, e := range drawLottery(seed, groups(), preferences, nil, 1) {
+				if e.ElectiveGroupID == 1 {
+					winners[e.StudentID] = true
+				}
+			}
+		}
+		if len(winners) != 3 {
+			t.Fatalf("group 1 was won by %v, want every student in some draw", winners)
+		}
+	})
+
+	t.Run("enrolled groups count towards max choices", func(t *testing.T) {
+		taken := []electives2.Enrollment{{StudentID: 10, ElectiveGroupID: 3}}
+		prefs := []electives2.Preference{
+			{StudentID: 10, ElectiveGroupID: 3, Rank: 1},
+			{StudentID: 10, ElectiveGroupID: 2, Rank: 2},
+			{StudentID: 10, ElectiveGroupID: 1, Rank: 3},
+		}
+
+		drawn := drawLottery(1, groups(), prefs, taken, 2)
+		want := []electives2.Enrollment{{StudentID: 10, ElectiveGroupID: 2}}
+		if !slices.Equal(drawn, want) {
+			t.Fatalf("drawn %v, want %v", drawn, want)
+		}
+	})
+
+	t.Run("full and unknown groups are skipped", func(t *testing.T) {
+		full := []electives2.Group{{ID: 1, Capacity: capacity(1), Enrolled: 1}, {ID: 3}}
+		prefs := []electives2.Preference{
+			{StudentID: 10, ElectiveGroupID: 1, Rank: 1},
+			{StudentID: 10, ElectiveGroupID: 99, Rank: 2},
+			{StudentID: 10, ElectiveGroupID: 3, Rank: 3},
+		}
+
+		drawn := drawLottery(1, full, prefs, nil, 1)
+		want := []electives2.Enrollment{{StudentID: 10, ElectiveGroupID: 3}}
+		if !slices.Equal(drawn, want) {
+			t.Fatalf("drawn %v, want %v", drawn, want)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// ErrorCode is a stable error code, the frontend localizes messages by it.
+type ErrorCode string
+
+const (
+	CodeBadRequest        ErrorCode = "bad_request"
+	CodeValidation        ErrorCode = "validation_failed"
+	CodeUnauthorized      ErrorCode = "unauthorized"
+	CodeForbidden         ErrorCode = "forbidden"
+	CodeNotFound          ErrorCode = "not_found"
+	CodeConflict          ErrorCode = "conflict"
+	CodeAlreadyExists     ErrorCode = "already_exists"
+	CodeStillReferenced   ErrorCode = "still_referenced"
+	CodeReferenceNotFound ErrorCode = "reference_not_found"
+	CodeScheduleConflict  ErrorCode = "schedule_conflict"
+	CodeWindowClosed      ErrorCode = "window_closed"
+	CodeGroupFull         ErrorCode = "group_full"
+	CodeTooManyRequests   ErrorCode = "too_many_requests"
+	CodeInternal          ErrorCode = "internal_error"
+)
+
+// FieldError describes an invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field" example:"pair_number"`
+	Code    string `json:"code" example:"min"`
+	Message string `json:"message" example:"must be at least 1"`
+}
+
+// Error is a domain error, it is rendered to the client with its status, code and message.
+// Anything else returned by services is an internal error and is not shown to the client.
+type Error struct {
+	Status  int
+	Code    ErrorCode
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) *Error {
+	return &Error{Status: http.StatusNotFound, Code: CodeNotFound, Message: message}
+}
+
+func Conflict(code ErrorCode, message string) *Error {
+	return &Error{Status: http.StatusConflict, Code: code, Message: message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Status: http.StatusForbidden, Code: CodeForbidden, Message: message}
+}
+
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: CodeValidation, Message: message, Fields: fields}
+}
+
+// Postgres error codes, https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgForeignKeyViolation  = "23503"
+	pgUniqueViolation      = "23505"
+	pgCheckViolation       = "23514"
+	pgNotNullViolation     = "23502"
+	pgInvalidTextRepresent = "22P02"
+)
+
+// FromDB converts errors of repositories to domain errors, other errors are returned as is.
+func FromDB(err error) error {
+	var domainErr *Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+
+	if errors.Is(err, repositories.ErrScheduleConflict) {
+		e := Conflict(CodeScheduleConflict, "schedule conflict (group/teacher/student/room/booking/exam)")
+		e.Err = err
+		return e
+	}
+
+	var refErr *repositories.StillReferencedError
+	if errors.As(err, &refErr) {
+		e := Conflict(CodeStillReferenced, refErr.Error())
+		if refErr.Students > 0 {
+			e.Fields = append(e.Fields, FieldError{Field: "students", Code: "active", Message: fmt.Sprintf("%d active students", refErr.Students)})
+		}
+		if refErr.Lessons > 0 {
+			e.Fields = append(e.Fields, FieldError{Field: "lessons", Code: "scheduled", Message: fmt.Sprintf("%d scheduled lessons", refErr.Lessons)})
+		}
+		e.Err = err
+		return e
+	}
+
+	if errors.Is(err, repositories.ErrElectiveSubject) {
+		e := Conflict(CodeConflict, "subject is elective, teachers are assigned to its elective groups")
+		e.Err = err
+		return e
+	}
+
+	if e := fromAdmissions(err); e != nil {
+		e.Err = err
+		return e
+	}
+
+	if e := fromElectives(err); e != nil {
+		e.Err = err
+		return e
+	}
+
+	if e := fromRollover(err); e != nil {
+		e.Err = err
+		return e
+	}
+
+	if e := fromBookings(err); e != nil {
+		e.Err = err
+		return e
+	}
+
+	if e := fromExams(err); e != nil {
+		e.Err = err
+		return e
+	}
+
+	if errors.Is(err, repositories.ErrReferenceNotFound) {
+		e := Conflict(CodeReferenceNotFound, "referenced entity does not exist")
+		e.Err = err
+		return e
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		e := NotFound("not found")
+		e.Err = err
+		return e
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var e *Error
+	switch pgErr.Code {
+	case pgForeignKeyViolation:
+		// on delete the referencing table is in TableName, on insert/update the referenced one is in Detail
+		if strings.Contains(pgErr.Detail, "still referenced") {
+			e = Conflict(CodeStillReferenced, fmt.Sprintf("entity is still used in %s.%s", pgErr.SchemaName, pgErr.TableName))
+		} else {
+			e = Conflict(CodeReferenceNotFound, "referenced entity does not exist")
+		}
+	case pgUniqueViolation:
+		e = Conflict(CodeAlreadyExists, "entity already exists")
+	case pgCheckViolation, pgNotNullViolation, pgInvalidTextRepresent:
+		e = Validation("invalid value", FieldError{Field: pgErr.ColumnName, Code: pgErr.ConstraintName, Message: "violates " + pgErr.ConstraintName})
+		if pgErr.ColumnName == "" && pgErr.ConstraintName == "" {
+			e.Fields = nil
+		}
+	default:
+		return err
+	}
+
+	e.Err = err
+	return e
+}
+
+// fromElectives converts errors of elective enrollment, it returns nil for other errors.
+func fromElectives(err error) *Error {
+	var fullErr *repositories.GroupFullError
+	switch {
+	case errors.As(err, &fullErr):
+		e := Conflict(CodeGroupFull, fullErr.Error())
+		e.Fields = []FieldError{{Field: "elective_group_ids", Code: "full", Message: fmt.Sprintf("group %d has no free seats", fullErr.GroupID)}}
+		return e
+	case errors.Is(err, repositories.ErrNotStudent):
+		return Forbidden(err.Error())
+	case errors.Is(err, repositories.ErrWindowClosed):
+		return Conflict(CodeWindowClosed, err.Error())
+	case errors.Is(err, repositories.ErrWindowNotClosed), errors.Is(err, repositories.ErrWindowDrawn), errors.Is(err, repositories.ErrNotLottery):
+		return Conflict(CodeConflict, err.Error())
+	case errors.Is(err, repositories.ErrTooManyChoices):
+		return Validation(err.Error(), FieldError{Field: "elective_group_ids", Code: "max", Message: "exceeds max choices of the window"})
+	case errors.Is(err, repositories.ErrDuplicateChoice):
+		return Validation(err.Error(), FieldError{Field: "elective_group_ids", Code: "unique", Message: "must not contain duplicates"})
+	case errors.Is(err, repositories.ErrNotEligible):
+		return Validation(err.Error(), FieldError{Field: "elective_group_ids", Code: "eligible", Message: "must be groups offered to the student's course"})
+	case errors.Is(err, repositories.ErrCapacityBelowSeated):
+		return Validation(err.Error(), FieldError{Field: "capacity", Code: "enrolled", Message: "must not be less than enrolled students"})
+	}
+	return nil
+}
+
+// fromAdmissions converts errors of admissions, it returns nil for other errors.
+func fromAdmissions(err error) *Error {
+	var transitionErr *repositories.TransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return Conflict(CodeConflict, transitionErr.Error())
+	case errors.Is(err, repositories.ErrCampaignClosed), errors.Is(err, repositories.ErrNoSeats),
+		errors.Is(err, repositories.ErrNotAccepted), errors.Is(err, repositories.ErrAlreadyEnrolled),
+		errors.Is(err, repositories.ErrScoreNotVerified), errors.Is(err, repositories.ErrScoreFixed):
+		return Conflict(CodeConflict, err.Error())
+	case errors.Is(err, repositories.ErrSeatsBelowAccepted):
+		return Validation(err.Error(), FieldError{Field: "seats", Code: "accepted", Message: "must not be less than accepted applications"})
+	}
+	return nil
+}
+
+// fromRollover converts errors of academic year rollovers, it returns nil for other errors.
+func fromRollover(err error) *Error {
+	var takenErr *repositories.GroupNameTakenError
+	switch {
+	case errors.As(err, &takenErr):
+		e := Conflict(CodeAlreadyExists, takenErr.Error())
+		e.Fields = []FieldError{{Field: "renames", Code: "unique", Message: fmt.Sprintf("rename group %d", takenErr.GroupID)}}
+		return e
+	case errors.Is(err, repositories.ErrRenameNotPromoted):
+		return Validation(err.Error(), FieldError{Field: "renames", Code: "promoted", Message: "must be groups promoted by the rollover"})
+	}
+	return nil
+}
+
+// fromBookings converts errors of room bookings, it returns nil for other errors.
+func fromBookings(err error) *Error {
+	var transitionErr *repositories.BookingTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return Conflict(CodeConflict, transitionErr.Error())
+	case errors.Is(err, repositories.ErrBookingPast):
+		return Conflict(CodeConflict, err.Error())
+	case errors.Is(err, repositories.ErrNotMember):
+		return Forbidden(err.Error())
+	}
+	return nil
+}
+
+// examConflictFields are request fields of exams by kind of conflict.
+var examConflictFields = map[string]string{
+	"group":    "date",
+	"gap":      "date",
+	"room":     "room_ids",
+	"examiner": "examiner_user_id",
+}
+
+// fromExams converts errors of exam sessions, it returns nil for other errors.
+func fromExams(err error) *Error {
+	var conflictErr *repositories.ExamConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		e := Conflict(CodeScheduleConflict, conflictErr.Error())
+		e.Fields = []FieldError{{Field: examConflictFields[conflictErr.Kind], Code: conflictErr.Kind, Message: conflictErr.Error()}}
+		return e
+	case errors.Is(err, repositories.ErrSessionOutsideSemester):
+		return Validation(err.Error(),
+			FieldError{Field: "starts_on", Code: "semester", Message: "must be within the semester"},
+			FieldError{Field: "ends_on", Code: "semester", Message: "must be within the semester"})
+	case errors.Is(err, repositories.ErrExamOutsideSession):
+		return Validation(err.Error(), FieldError{Field: "date", Code: "session", Message: "must be within the exam session"})
+	}
+	return nil
+}
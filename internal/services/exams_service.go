@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/exams"
+	exams2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exams"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// timeLayout is the layout of times of day in requests and responses.
+const timeLayout = "15:04"
+
+// ExamsService manages exam sessions of semesters and their exams. Students see exams of their groups
+// once the session is published.
+type ExamsService struct {
+	repo repositories.ExamsRepository
+}
+
+func NewExamsService(repo repositories.ExamsRepository) *ExamsService {
+	return &ExamsService{repo: repo}
+}
+
+func (s *ExamsService) CreateSession(ctx context.Context, adminID int64, request exams.CreateSessionRequest) (int64, error) {
+	startsOn, err := time.Parse(dateLayout, request.StartsOn)
+	if err != nil {
+		return 0, Validation("invalid starts_on", FieldError{Field: "starts_on", Code: "datetime", Message: "must be a date in format " + dateLayout})
+	}
+	endsOn, err := time.Parse(dateLayout, request.EndsOn)
+	if err != nil {
+		return 0, Validation("invalid ends_on", FieldError{Field: "ends_on", Code: "datetime", Message: "must be a date in format " + dateLayout})
+	}
+	if endsOn.Before(startsOn) {
+		return 0, Validation("exam session ends before it starts", FieldError{Field: "ends_on", Code: "gtefield", Message: "must not be before starts_on"})
+	}
+
+	minGapDays := 1
+	if request.MinGapDays != nil {
+		minGapDays = *request.MinGapDays
+	}
+
+	id, err := s.repo.CreateSession(ctx, adminID, exams2.Session{
+		SemesterID: request.SemesterID,
+		Name:       request.Name,
+		StartsOn:   startsOn,
+		EndsOn:     endsOn,
+		MinGapDays: minGapDays,
+	})
+	if err != nil {
+		return 0, FromDB(err)
+	}
+	return id, nil
+}
+
+func (s *ExamsService) GetSessions(ctx context.Context, adminID, universityID int64) ([]exams.SessionResponse, error) {
+	result, err := s.repo.GetSessions(ctx, adminID, universityID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+
+	response := make([]exams.SessionResponse, 0, len(result))
+	for _, session := range result {
+		response = append(response, exams.SessionResponse{
+			ID:           session.ID,
+			UniversityID: session.UniversityID,
+			SemesterID:   session.SemesterID,
+			Name:         session.Name,
+			StartsOn:     session.StartsOn.Format(dateLayout),
+			EndsOn:       session.EndsOn.Format(dateLayout),
+			MinGapDays:   session.MinGapDays,
+			Published:    session.PublishedAt != nil,
+			PublishedAt:  session.PublishedAt,
+			Exams:        session.Exams,
+		})
+	}
+	return response, nil
+}
+
+func (s *ExamsService) PublishSession(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.PublishSession(ctx, adminID, id))
+}
+
+func (s *ExamsService) CreateExam(ctx context.Context, adminID, sessionID int64, request exams.CreateExamRequest) (int64, error) {
+	date, err := time.Parse(dateLayout, request.Date)
+	if err != nil {
+		return 0, Validation("invalid date", FieldError{Field: "date", Code: "datetime", Message: "must be a date in format " + dateLayout})
+	}
+	startTime, err := time.Parse(timeLayout, request.StartTime)
+	if err != nil {
+		return 0, Validation("invalid start_time", FieldError{Field: "start_time", Code: "datetime", Message: "must be a time in format " + timeLayout})
+	}
+	endTime, err := time.Parse(timeLayout, request.EndTime)
+	if err != nil {
+		return 0, Validation("invalid end_time", FieldError{Field: "end_time", Code: "datetime", Message: "must be a time in format " + timeLayout})
+	}
+	if !endTime.After(startTime) {
+		return 0, Validation("exam ends before it starts", FieldError{Field: "end_time", Code: "gtfield", Message: "must be after start_time"})
+	}
+
+	id, err := s.repo.CreateExam(ctx, adminID, exams2.Exam{
+		SessionID:               sessionID,
+		CourseSemesterSubjectID: request.CourseSemesterSubjectID,
+		CourseGroupID:           request.CourseGroupID,
+		ExaminerUserID:          request.ExaminerUserID,
+		Kind:                    exams2.Kind(request.Kind),
+		Date:                    date,
+		StartTime:               startTime,
+		EndTime:                 endTime,
+		RoomIDs:                 request.RoomIDs,
+	})
+	if err != nil {
+		return 0, FromDB(err)
+	}
+	return id, nil
+}
+
+func (s *ExamsService) GetSessionExams(ctx context.Context, adminID, sessionID int64) ([]exams.ExamResponse, error) {
+	result, err := s.repo.GetSessionExams(ctx, adminID, sessionID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+
+	response := make([]exams.ExamResponse, 0, len(result))
+	for _, e := range result {
+		response = append(response, examResponse(e))
+	}
+	return response, nil
+}
+
+func (s *ExamsService) DeleteExam(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteExam(ctx, adminID, id))
+}
+
+// GetStudentExams returns the exam timetable of a student: exams of published sessions that are not over.
+func (s *ExamsService) GetStudentExams(ctx context.Context, userID int64) ([]exams.StudentExamResponse, error) {
+	result, err := s.repo.GetStudentExams(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]exams.StudentExamResponse, 0, len(result))
+	for _, e := range result {
+		response = append(response, exams.StudentExamResponse{
+			ExamResponse: examResponse(e.Exam),
+			Session:      e.Session,
+		})
+	}
+	return response, nil
+}
+
+func examResponse(e exams2.Exam) exams.ExamResponse {
+	rooms := make([]exams.ExamRoom, 0, len(e.RoomIDs))
+	for i, id := range e.RoomIDs {
+		rooms = append(rooms, exams.ExamRoom{ID: id, Room: e.Rooms[i]})
+	}
+
+	return exams.ExamResponse{
+		ID:                      e.ID,
+		SessionID:               e.SessionID,
+		CourseSemesterSubjectID: e.CourseSemesterSubjectID,
+		Subject:                 e.Subject,
+		CourseGroupID:           e.CourseGroupID,
+		Group:                   e.Group,
+		ExaminerUserID:          e.ExaminerUserID,
+		ExaminerFirstName:       e.ExaminerFirstName,
+		ExaminerLastName:        e.ExaminerLastName,
+		Kind:                    string(e.Kind),
+		Date:                    e.Date.Format(dateLayout),
+		Day:                     strings.ToLower(e.Date.Weekday().String()),
+		StartTime:               e.StartTime.Format(timeLayout),
+		EndTime:                 e.EndTime.Format(timeLayout),
+		Rooms:                   rooms,
+	}
+}
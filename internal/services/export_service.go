@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/exports"
+	exports2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/exports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// ExportService exports rosters, teachers, timetable and access requests of a university as CSV, XLSX or JSON.
+// Rows are written as they are read from the database, nothing is written before the first row or the end
+// of an empty export, so errors of access checks are returned as usual.
+type ExportService struct {
+	repo repositories.ExportRepository
+}
+
+func NewExportService(repo repositories.ExportRepository) *ExportService {
+	return &ExportService{repo: repo}
+}
+
+// OpenExport is called once before the first byte of the file, it sends headers and returns the body writer.
+type OpenExport func(contentType, filename string) io.Writer
+
+var exportContentTypes = map[exports2.Format]string{
+	exports2.CSV:  "text/csv; charset=utf-8",
+	exports2.XLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	exports2.JSON: "application/json; charset=utf-8",
+}
+
+// Export writes the file to the writer returned by open. An error returned after open was called
+// means the file is cut short.
+func (s *ExportService) Export(ctx context.Context, adminID int64, request exports.ExportRequest, open OpenExport) error {
+	kind := exports2.Kind(request.Kind)
+	format := exports2.Format(request.Format)
+	if format == "" {
+		format = exports2.CSV
+	}
+	filter := exports2.Filter{
+		UniversityID: request.UniversityID,
+		GroupID:      request.GroupID,
+		RoomID:       request.RoomID,
+		Order:        exports2.Order(request.By),
+	}
+	if filter.Order == "" {
+		filter.Order = exports2.ByGroup
+	}
+
+	var fields []FieldError
+	if filter.GroupID != nil && kind != exports2.Rosters && kind != exports2.Timetable {
+		fields = append(fields, FieldError{Field: "group_id", Code: "excluded", Message: "applies to rosters and timetable only"})
+	}
+	if filter.RoomID != nil && kind != exports2.Timetable {
+		fields = append(fields, FieldError{Field: "room_id", Code: "excluded", Message: "applies to timetable only"})
+	}
+	if request.By != "" && kind != exports2.Timetable {
+		fields = append(fields, FieldError{Field: "by", Code: "excluded", Message: "applies to timetable only"})
+	}
+	if len(fields) > 0 {
+		return Validation("invalid export parameters", fields...)
+	}
+
+	e := &export{
+		format:   format,
+		sheet:    string(kind),
+		filename: fmt.Sprintf("%s_%d_%s.%s", kind, filter.UniversityID, time.Now().Format("2006-01-02"), format),
+		open:     open,
+	}
+
+	switch kind {
+	case exports2.Rosters:
+		e.columns = []string{"max_user_id", "username", "first_name", "last_name", "role", "group_id", "group",
+			"department", "year_of_study", "start_date", "end_date"}
+		return exportTable(e, func(fn func(exports2.Student) error) error {
+			return s.repo.ExportRoster(ctx, adminID, filter, fn)
+		}, studentCells)
+	case exports2.Teachers:
+		e.columns = []string{"max_user_id", "username", "first_name", "last_name", "role"}
+		return exportTable(e, func(fn func(exports2.Teacher) error) error {
+			return s.repo.ExportTeachers(ctx, adminID, filter, fn)
+		}, teacherCells)
+	case exports2.Timetable:
+		e.columns = []string{"group", "elective", "room", "day", "pair_number", "start_time", "end_time",
+			"interval", "subject", "subject_type", "teacher"}
+		cells := lessonCells
+		if filter.Order == exports2.ByRoom {
+			// the room goes first as rows are grouped by it
+			e.columns[0], e.columns[1], e.columns[2] = "room", "group", "elective"
+			cells = func(l exports2.Lesson) []any {
+				c := lessonCells(l)
+				c[0], c[1], c[2] = c[2], c[0], c[1]
+				return c
+			}
+		}
+		return exportTable(e, func(fn func(exports2.Lesson) error) error {
+			return s.repo.ExportTimetable(ctx, adminID, filter, fn)
+		}, cells)
+	case exports2.AccessRequests:
+		e.columns = []string{"max_user_id", "username", "first_name", "last_name", "role", "requested_at",
+			"status", "rejected_at", "rejected_by"}
+		return exportTable(e, func(fn func(exports2.AccessRequest) error) error {
+			return s.repo.ExportAccessRequests(ctx, adminID, filter, fn)
+		}, accessRequestCells)
+	default:
+		return Validation("unknown export kind", FieldError{Field: "kind", Code: "export_kind", Message: "is invalid"})
+	}
+}
+
+// export is a file being written, the writer is created with the first row.
+type export struct {
+	format   exports2.Format
+	sheet    string
+	filename string
+	columns  []string
+	open     OpenExport
+	w        tableWriter
+}
+
+func (e *export) start() error {
+	if e.w != nil {
+		return nil
+	}
+
+	var err error
+	w := e.open(exportContentTypes[e.format], e.filename)
+	switch e.format {
+	case exports2.XLSX:
+		e.w, err = newXLSXTableWriter(w, e.sheet, e.columns)
+	case exports2.JSON:
+		e.w, err = newJSONTableWriter(w, e.columns)
+	default:
+		e.w, err = newCSVTableWriter(w, e.columns)
+	}
+	return err
+}
+
+// exportTable streams rows of run to the file, errors before the first row are mapped as errors of the database.
+func exportTable[T any](e *export, run func(fn func(T) error) error, cells func(T) []any) error {
+	err := run(func(row T) error {
+		if err := e.start(); err != nil {
+			return err
+		}
+		return e.w.Row(cells(row))
+	})
+	if err != nil {
+		if e.w == nil {
+			return FromDB(err)
+		}
+		return err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.w.Close()
+}
+
+func studentCells(s exports2.Student) []any {
+	return []any{s.UserID, deref(s.Username), s.FirstName, deref(s.LastName), "student", s.GroupID, s.Group,
+		s.Department, deref(s.YearOfStudy), s.StartDate.Format(time.DateOnly), s.EndDate.Format(time.DateOnly)}
+}
+
+func teacherCells(t exports2.Teacher) []any {
+	return []any{t.UserID, deref(t.Username), t.FirstName, deref(t.LastName), "teacher"}
+}
+
+func lessonCells(l exports2.Lesson) []any {
+	return []any{l.Group, l.Elective, l.Room, l.Day, l.PairNumber, l.StartTime.Format("15:04"), l.EndTime.Format("15:04"),
+		l.Interval, deref(l.Subject), l.SubjectType, deref(l.Teacher)}
+}
+
+func accessRequestCells(a exports2.AccessRequest) []any {
+	var rejectedAt any
+	if a.RejectedAt != nil {
+		rejectedAt = a.RejectedAt.Format(time.RFC3339)
+	}
+	return []any{a.UserID, deref(a.Username), a.FirstName, deref(a.LastName), a.Role, a.RequestedAt.Format(time.RFC3339),
+		a.Status, rejectedAt, deref(a.RejectedBy)}
+}
+
+// deref returns the value or untyped nil, so empty cells are empty in every format.
+func deref[T any](p *T) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
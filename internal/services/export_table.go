@@ -0,0 +1,186 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// tableWriter writes an exported table row by row, cells are nil, strings, integers or booleans.
+// The header is written on creation and Close finishes the file.
+type tableWriter interface {
+	Row(cells []any) error
+	Close() error
+}
+
+// escapeFormula keeps text which starts like a formula from running when the file is opened
+// in a spreadsheet: names and comments come from users, "=HYPERLINK(...)" is a valid group name.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// csvTableWriter writes semicolon separated UTF-8 with BOM: this is what spreadsheets with decimal comma open
+// without the import dialog, and what readCSV reads back.
+type csvTableWriter struct {
+	w *csv.Writer
+}
+
+func newCSVTableWriter(w io.Writer, columns []string) (*csvTableWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	t := &csvTableWriter{w: csv.NewWriter(w)}
+	t.w.Comma = ';'
+	return t, t.w.Write(columns)
+}
+
+func (t *csvTableWriter) Row(cells []any) error {
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		switch v := cell.(type) {
+		case nil:
+		case string:
+			record[i] = escapeFormula(v)
+		case int:
+			record[i] = strconv.Itoa(v)
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case bool:
+			record[i] = strconv.FormatBool(v)
+		}
+	}
+	// csv.Writer is buffered, rows reach the client when the buffer is full
+	return t.w.Write(record)
+}
+
+func (t *csvTableWriter) Close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+// xlsxTableWriter writes the only sheet with excelize stream writer, it keeps rows in a temporary file
+// instead of memory, the file is written to the client on Close.
+type xlsxTableWriter struct {
+	w      io.Writer
+	f      *excelize.File
+	sw     *excelize.StreamWriter
+	rownum int
+}
+
+func newXLSXTableWriter(w io.Writer, sheet string, columns []string) (*xlsxTableWriter, error) {
+	f := excelize.NewFile()
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	t := &xlsxTableWriter{w: w, f: f, sw: sw}
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := t.row(header, excelize.RowOpts{StyleID: bold}); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *xlsxTableWriter) Row(cells []any) error {
+	escaped := make([]any, len(cells))
+	for i, cell := range cells {
+		if s, ok := cell.(string); ok {
+			cell = escapeFormula(s)
+		}
+		escaped[i] = cell
+	}
+	return t.row(escaped)
+}
+
+func (t *xlsxTableWriter) row(cells []any, opts ...excelize.RowOpts) error {
+	t.rownum++
+	cell, err := excelize.CoordinatesToCellName(1, t.rownum)
+	if err != nil {
+		return err
+	}
+	return t.sw.SetRow(cell, cells, opts...)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.f.Close()
+
+	if err := t.sw.Flush(); err != nil {
+		return err
+	}
+	_, err := t.f.WriteTo(t.w)
+	return err
+}
+
+// jsonTableWriter writes an array of objects keyed by columns in the order of columns.
+type jsonTableWriter struct {
+	w       *bufio.Writer
+	columns [][]byte
+	rows    int
+}
+
+func newJSONTableWriter(w io.Writer, columns []string) (*jsonTableWriter, error) {
+	t := &jsonTableWriter{w: bufio.NewWriter(w), columns: make([][]byte, len(columns))}
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		t.columns[i] = key
+	}
+	_, err := t.w.WriteString("[")
+	return t, err
+}
+
+func (t *jsonTableWriter) Row(cells []any) error {
+	if t.rows > 0 {
+		_ = t.w.WriteByte(',')
+	}
+	t.rows++
+
+	_, _ = t.w.WriteString("\n{")
+	for i, cell := range cells {
+		value, err := json.Marshal(cell)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			_ = t.w.WriteByte(',')
+		}
+		_, _ = t.w.Write(t.columns[i])
+		_ = t.w.WriteByte(':')
+		_, _ = t.w.Write(value)
+	}
+	// errors of bufio.Writer are sticky, the last write returns the first one
+	_, err := t.w.WriteString("}")
+	return err
+}
+
+func (t *jsonTableWriter) Close() error {
+	if _, err := t.w.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	return t.w.Flush()
+}
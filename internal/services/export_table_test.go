@@ -0,0 +1,79 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	cases := map[string]string{
+		"":                        "",
+		"ПИ-21":                   "ПИ-21",
+		"=HYPERLINK(\"x\",\"y\")": "'=HYPERLINK(\"x\",\"y\")",
+		"+7 900":                  "'+7 900",
+		"-1+1":                    "'-1+1",
+		"@SUM(A1)":                "'@SUM(A1)",
+		"\tcmd":                   "'\tcmd",
+		"\rcmd":                   "'\rcmd",
+	}
+	for in, want := range cases {
+		if got := escapeFormula(in); got != want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTableWritersEscapeFormulas(t *testing.T) {
+	row := []any{"=1+1", int64(-5), "ok"}
+
+	var csvOut bytes.Buffer
+	cw, err := newCSVTableWriter(&csvOut, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cw.Row(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(csvOut.String(), "\ufeff")))
+	r.Comma = ';'
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// numbers are not text, a negative one stays a number
+	if want := []string{"'=1+1", "-5", "ok"}; len(records) != 2 || !slices.Equal(records[1], want) {
+		t.Fatalf("csv rows %q, want %q after the header", records, want)
+	}
+
+	var xlsxOut bytes.Buffer
+	xw, err := newXLSXTableWriter(&xlsxOut, "Test", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xw.Row(row); err != nil {
+		t.Fatal(err)
+	}
+	if err := xw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := excelize.OpenReader(&xlsxOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := f.GetRows("Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"'=1+1", "-5", "ok"}; len(rows) != 2 || !slices.Equal(rows[1], want) {
+		t.Fatalf("xlsx rows %q, want %q after the header", rows, want)
+	}
+}
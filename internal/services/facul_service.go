@@ -26,9 +26,5 @@ func (f *FaculService) GetInfoAboutUni(ctx context.Context, id int64) ([]models.
 }
 
 func (f *FaculService) CreateNewFaculty(ctx context.Context, facultyName string, userID int64) error {
-	err := f.faculRepo.CreateFaculty(ctx, userID, facultyName)
-	if err != nil {
-		return err
-	}
-	return nil
+	return FromDB(f.faculRepo.CreateFaculty(ctx, userID, facultyName))
 }
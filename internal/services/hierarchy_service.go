@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// HierarchyService manages universities, faculties, university departments, courses and groups
+// of the admin's university. Deleting an entity deletes everything below it.
+type HierarchyService struct {
+	repo repositories.HierarchyRepository
+}
+
+func NewHierarchyService(repo repositories.HierarchyRepository) *HierarchyService {
+	return &HierarchyService{repo: repo}
+}
+
+func (s *HierarchyService) GetUniversity(ctx context.Context, adminID, id int64) (*models.UniversitiesData, error) {
+	uni, err := s.repo.GetUniversity(ctx, adminID, id)
+	return uni, FromDB(err)
+}
+
+func (s *HierarchyService) UpdateUniversity(ctx context.Context, adminID int64, uni models.UniversitiesData) error {
+	return FromDB(s.repo.UpdateUniversity(ctx, adminID, uni))
+}
+
+func (s *HierarchyService) DeleteUniversity(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteUniversity(ctx, adminID, id))
+}
+
+func (s *HierarchyService) GetFaculty(ctx context.Context, adminID, id int64) (*models.Faculties, error) {
+	faculty, err := s.repo.GetFaculty(ctx, adminID, id)
+	return faculty, FromDB(err)
+}
+
+func (s *HierarchyService) UpdateFaculty(ctx context.Context, adminID, id int64, name string) error {
+	return FromDB(s.repo.UpdateFaculty(ctx, adminID, id, name))
+}
+
+func (s *HierarchyService) DeleteFaculty(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteFaculty(ctx, adminID, id))
+}
+
+func (s *HierarchyService) GetDepartment(ctx context.Context, adminID, id int64) (*models.UniversityDepartment, error) {
+	dep, err := s.repo.GetDepartment(ctx, adminID, id)
+	return dep, FromDB(err)
+}
+
+func (s *HierarchyService) UpdateDepartment(ctx context.Context, adminID, id int64, aliasName *string, facultyID int64, studyYears *int) error {
+	return FromDB(s.repo.UpdateDepartment(ctx, adminID, id, aliasName, facultyID, studyYears))
+}
+
+func (s *HierarchyService) DeleteDepartment(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteDepartment(ctx, adminID, id))
+}
+
+func (s *HierarchyService) GetCourse(ctx context.Context, adminID, id int64) (*models.Course, error) {
+	course, err := s.repo.GetCourse(ctx, adminID, id)
+	return course, FromDB(err)
+}
+
+func (s *HierarchyService) UpdateCourse(ctx context.Context, adminID int64, course models.Course) error {
+	if !course.EndDate.After(course.StartDate) {
+		return Validation("end_date must be after start_date", FieldError{Field: "end_date", Code: "gtfield", Message: "must be after start_date"})
+	}
+	return FromDB(s.repo.UpdateCourse(ctx, adminID, course))
+}
+
+func (s *HierarchyService) DeleteCourse(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteCourse(ctx, adminID, id))
+}
+
+func (s *HierarchyService) GetGroups(ctx context.Context, adminID, courseID int64) ([]models.Groups, error) {
+	groups, err := s.repo.GetGroups(ctx, adminID, courseID)
+	return groups, FromDB(err)
+}
+
+func (s *HierarchyService) GetGroup(ctx context.Context, adminID, id int64) (*models.Groups, error) {
+	group, err := s.repo.GetGroup(ctx, adminID, id)
+	return group, FromDB(err)
+}
+
+func (s *HierarchyService) UpdateGroup(ctx context.Context, adminID, id int64, name string, courseID int64) error {
+	return FromDB(s.repo.UpdateGroup(ctx, adminID, id, name, courseID))
+}
+
+func (s *HierarchyService) DeleteGroup(ctx context.Context, adminID, id int64) error {
+	return FromDB(s.repo.DeleteGroup(ctx, adminID, id))
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/imports"
+	imports2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/imports"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// ImportService imports CSV and XLSX files of rooms, bell schedule, subjects, groups and rosters of a university.
+// Rows are validated one by one, invalid rows are reported and valid ones are applied in one transaction.
+type ImportService struct {
+	repo repositories.ImportRepository
+}
+
+func NewImportService(repo repositories.ImportRepository) *ImportService {
+	return &ImportService{repo: repo}
+}
+
+func (s *ImportService) Import(ctx context.Context, adminID int64, request imports.ImportRequest, filename string, content io.Reader) (*imports.ImportReport, error) {
+	t, err := readTable(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := imports2.Mode(request.Mode)
+	if mode == "" {
+		mode = imports2.Create
+	}
+
+	var (
+		result   *imports2.Result
+		failures []imports2.Failure
+	)
+	switch imports2.Kind(request.Kind) {
+	case imports2.Rooms:
+		result, failures, err = importTable(t, []string{"room"}, parseRoom, func(rooms []imports2.Room) (*imports2.Result, error) {
+			return s.repo.ImportRooms(ctx, adminID, request.UniversityID, mode, rooms)
+		})
+	case imports2.Classes:
+		result, failures, err = importTable(t, []string{"pair_number", "start_time", "end_time"}, parseClass, func(classes []imports2.Class) (*imports2.Result, error) {
+			return s.repo.ImportClasses(ctx, adminID, request.UniversityID, mode, classes)
+		})
+	case imports2.Subjects:
+		result, failures, err = importTable(t, []string{"name"}, parseSubject, func(subjects []imports2.Subject) (*imports2.Result, error) {
+			return s.repo.ImportSubjects(ctx, adminID, request.UniversityID, mode, subjects)
+		})
+	case imports2.Groups:
+		result, failures, err = importTable(t, []string{"department", "start_date", "end_date", "name"}, parseGroup, func(groups []imports2.Group) (*imports2.Result, error) {
+			return s.repo.ImportGroups(ctx, adminID, request.UniversityID, mode, groups)
+		})
+	case imports2.Rosters:
+		if !t.has("max_user_id") && !t.has("username") {
+			return nil, Validation("file misses required columns", FieldError{Field: "max_user_id", Code: "required_without", Message: "column is required when username is not set"})
+		}
+		result, failures, err = importTable(t, nil, parseRosterEntry, func(entries []imports2.RosterEntry) (*imports2.Result, error) {
+			return s.repo.ImportRoster(ctx, adminID, request.UniversityID, mode, entries)
+		})
+	default:
+		return nil, Validation("unknown import kind", FieldError{Field: "kind", Code: "import_kind", Message: "is invalid"})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	failures = append(failures, result.Failures...)
+	slices.SortFunc(failures, func(a, b imports2.Failure) int { return a.Row - b.Row })
+
+	report := &imports.ImportReport{
+		Kind:      request.Kind,
+		Mode:      string(mode),
+		Rows:      len(t.rows),
+		Created:   result.Created,
+		Updated:   result.Updated,
+		Unchanged: result.Unchanged,
+		Failed:    len(failures),
+		Errors:    make([]imports.RowErrorResponse, 0, len(failures)),
+	}
+	for _, f := range failures {
+		report.Errors = append(report.Errors, rowErrorResponse(f))
+	}
+	return report, nil
+}
+
+// importTable parses rows of the table and applies the valid ones.
+func importTable[T any](t *table, required []string, parse func(c *cells) T, apply func([]T) (*imports2.Result, error)) (*imports2.Result, []imports2.Failure, error) {
+	rows, failures, err := parseRows(t, required, parse)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := apply(rows)
+	if err != nil {
+		return nil, nil, FromDB(err)
+	}
+	return result, failures, nil
+}
+
+// parseRows checks required columns and parses rows of the table, rows with invalid cells are returned as failures.
+func parseRows[T any](t *table, required []string, parse func(c *cells) T) ([]T, []imports2.Failure, error) {
+	if err := t.require(required...); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		parsed   = make([]T, 0, len(t.rows))
+		failures []imports2.Failure
+	)
+	for _, row := range t.rows {
+		c := &cells{t: t, row: row}
+		v := parse(c)
+		if c.err != nil {
+			failures = append(failures, imports2.Failure{Row: row.number, Err: c.err})
+			continue
+		}
+		parsed = append(parsed, v)
+	}
+	return parsed, failures, nil
+}
+
+func parseRoom(c *cells) imports2.Room {
+	return imports2.Room{Row: c.row.number, Name: c.text("room", true, 125)}
+}
+
+func parseSubject(c *cells) imports2.Subject {
+	return imports2.Subject{Row: c.row.number, Name: c.text("name", true, 125)}
+}
+
+func parseClass(c *cells) imports2.Class {
+	class := imports2.Class{
+		Row:        c.row.number,
+		PairNumber: int(c.integer("pair_number", true)),
+		StartTime:  c.clock("start_time"),
+		EndTime:    c.clock("end_time"),
+	}
+	if class.PairNumber < 1 || class.PairNumber > 14 {
+		c.fail("pair_number", "must be from 1 to 14")
+	}
+	// HH:MM:SS strings are ordered as times
+	if class.EndTime <= class.StartTime {
+		c.fail("end_time", "must be after start_time")
+	}
+	return class
+}
+
+func parseGroup(c *cells) imports2.Group {
+	group := imports2.Group{
+		Row:        c.row.number,
+		Department: c.text("department", true, 125),
+		StartDate:  c.date("start_date"),
+		EndDate:    c.date("end_date"),
+		Name:       c.text("name", true, 125),
+	}
+	if !group.EndDate.After(group.StartDate) {
+		c.fail("end_date", "must be after start_date")
+	}
+	if year := int(c.integer("year_of_study", false)); year != 0 {
+		if year < 1 || year > 12 {
+			c.fail("year_of_study", "must be from 1 to 12")
+		}
+		group.YearOfStudy = &year
+	}
+	return group
+}
+
+func parseRosterEntry(c *cells) imports2.RosterEntry {
+	entry := imports2.RosterEntry{
+		Row:       c.row.number,
+		UserID:    c.integer("max_user_id", false),
+		Username:  strings.TrimPrefix(c.text("username", false, 125), "@"),
+		FirstName: c.text("first_name", false, 50),
+		LastName:  c.text("last_name", false, 50),
+		Role:      imports2.Role(strings.ToLower(c.text("role", false, 20))),
+		GroupID:   c.integer("group_id", false),
+		Group:     c.text("group", false, 125),
+	}
+	if entry.UserID == 0 && entry.Username == "" {
+		c.fail("max_user_id", "is required when username is not set")
+	}
+	if entry.UserID < 0 {
+		c.fail("max_user_id", "must be greater than 0")
+	}
+
+	switch entry.Role {
+	case "":
+		entry.Role = imports2.Student
+		fallthrough
+	case imports2.Student:
+		if entry.GroupID == 0 && entry.Group == "" {
+			c.fail("group", "is required for students when group_id is not set")
+		}
+	case imports2.Teacher:
+	default:
+		c.fail("role", "must be one of student, teacher")
+	}
+	return entry
+}
+
+func rowErrorResponse(f imports2.Failure) imports.RowErrorResponse {
+	response := imports.RowErrorResponse{Row: f.Row, Message: f.Err.Error()}
+
+	var cellErr *cellError
+	var rowErr *repositories.RowError
+	var domainErr *Error
+	switch {
+	case errors.As(f.Err, &cellErr):
+		response.Column, response.Message = cellErr.column, cellErr.message
+	case errors.As(f.Err, &rowErr):
+		response.Column, response.Message = rowErr.Column, rowErr.Message
+	case errors.As(FromDB(f.Err), &domainErr):
+		response.Message = domainErr.Message
+		if len(domainErr.Fields) > 0 {
+			response.Column = domainErr.Fields[0].Field
+		}
+	}
+	return response
+}
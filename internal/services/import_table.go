@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxImportRows limits rows of an imported file, larger files are split by the admin.
+const MaxImportRows = 10000
+
+// XLSX is a zip archive, a small upload can unpack to gigabytes. A sheet of MaxImportRows rows unpacks
+// to a few megabytes, larger archives are rejected while unpacking.
+const (
+	xlsxUnzipSizeLimit    = 100 << 20
+	xlsxUnzipXMLSizeLimit = 50 << 20
+)
+
+// table is an imported file: named columns of the header and data rows, cells are trimmed.
+type table struct {
+	columns map[string]int
+	rows    []tableRow
+}
+
+type tableRow struct {
+	// number is the line of the file, the header is 1
+	number int
+	cells  []string
+}
+
+// readTable reads CSV or XLSX file by its extension, the first sheet of XLSX is read with raw cell values.
+// Column names are case insensitive, empty rows are skipped.
+func readTable(filename string, content io.Reader) (*table, error) {
+	var (
+		records []tableRow
+		err     error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		records, err = readCSV(content)
+	case ".xlsx":
+		records, err = readXLSX(content)
+	default:
+		return nil, Validation("file must be .csv or .xlsx", FieldError{Field: "file", Code: "extension", Message: "must be .csv or .xlsx"})
+	}
+	if err != nil {
+		return nil, Validation("failed to read file: "+err.Error(), FieldError{Field: "file", Code: "format", Message: err.Error()})
+	}
+	if len(records) == 0 {
+		return nil, Validation("file is empty", FieldError{Field: "file", Code: "required", Message: "must have a header row"})
+	}
+
+	t := &table{columns: make(map[string]int, len(records[0].cells))}
+	for i, name := range records[0].cells {
+		t.columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, row := range records[1:] {
+		empty := true
+		for j, cell := range row.cells {
+			row.cells[j] = strings.TrimSpace(cell)
+			empty = empty && row.cells[j] == ""
+		}
+		if !empty {
+			t.rows = append(t.rows, row)
+		}
+	}
+	if len(t.rows) > MaxImportRows {
+		return nil, Validation(fmt.Sprintf("file has more than %d rows", MaxImportRows),
+			FieldError{Field: "file", Code: "max", Message: fmt.Sprintf("must have at most %d rows", MaxImportRows)})
+	}
+	return t, nil
+}
+
+// readCSV reads comma or semicolon separated file, the separator is the one found in the header.
+// Semicolons are used by spreadsheets with decimal comma.
+func readCSV(content io.Reader) ([]tableRow, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	r := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+	}
+	r.FieldsPerRecord = -1
+
+	var records []tableRow
+	for {
+		cells, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// empty lines are skipped by the reader, rows are numbered by their lines
+		line, _ := r.FieldPos(0)
+		records = append(records, tableRow{number: line, cells: cells})
+	}
+}
+
+func readXLSX(content io.Reader) ([]tableRow, error) {
+	f, err := excelize.OpenReader(content, excelize.Options{
+		UnzipSizeLimit:    xlsxUnzipSizeLimit,
+		UnzipXMLSizeLimit: xlsxUnzipXMLSizeLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	// raw values: numbers are not formatted by the cell format, MAX user ids are not shown in E notation
+	rows, err := f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]tableRow, 0, len(rows))
+	for i, cells := range rows {
+		records = append(records, tableRow{number: i + 1, cells: cells})
+	}
+	return records, nil
+}
+
+// require returns a validation error if any of columns is missing in the header.
+func (t *table) require(columns ...string) error {
+	var fields []FieldError
+	for _, column := range columns {
+		if _, ok := t.columns[column]; !ok {
+			fields = append(fields, FieldError{Field: column, Code: "required", Message: "column is required"})
+		}
+	}
+	if len(fields) > 0 {
+		return Validation("file misses required columns", fields...)
+	}
+	return nil
+}
+
+func (t *table) has(column string) bool {
+	_, ok := t.columns[column]
+	return ok
+}
+
+// get returns the cell of the column, it is empty if the column or the cell is missing.
+func (t *table) get(row tableRow, column string) string {
+	i, ok := t.columns[column]
+	if !ok || i >= len(row.cells) {
+		return ""
+	}
+	return row.cells[i]
+}
+
+// cellError is an invalid cell of an imported row.
+type cellError struct {
+	column  string
+	message string
+}
+
+func (e *cellError) Error() string {
+	return e.column + ": " + e.message
+}
+
+// cells parses cells of a row, the first error is kept and later calls do nothing.
+type cells struct {
+	t   *table
+	row tableRow
+	err *cellError
+}
+
+func (c *cells) fail(column, message string) {
+	if c.err == nil {
+		c.err = &cellError{column: column, message: message}
+	}
+}
+
+func (c *cells) text(column string, required bool, maxLen int) string {
+	v := c.t.get(c.row, column)
+	switch {
+	case v == "" && required:
+		c.fail(column, "is required")
+	case len([]rune(v)) > maxLen:
+		c.fail(column, fmt.Sprintf("must be at most %d characters long", maxLen))
+	}
+	return v
+}
+
+// integer parses whole numbers, XLSX numbers may have a zero fraction.
+func (c *cells) integer(column string, required bool) int64 {
+	v := c.t.get(c.row, column)
+	if v == "" {
+		if required {
+			c.fail(column, "is required")
+		}
+		return 0
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	c.fail(column, "must be a whole number")
+	return 0
+}
+
+// date parses YYYY-MM-DD, DD.MM.YYYY or a date serial number of XLSX.
+func (c *cells) date(column string) time.Time {
+	v := c.t.get(c.row, column)
+	if v == "" {
+		c.fail(column, "is required")
+		return time.Time{}
+	}
+	for _, layout := range []string{"2006-01-02", "02.01.2006"} {
+		if d, err := time.Parse(layout, v); err == nil {
+			return d
+		}
+	}
+	if serial, err := strconv.ParseFloat(v, 64); err == nil && serial > 0 {
+		if d, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return d.Truncate(24 * time.Hour)
+		}
+	}
+	c.fail(column, "must be a date in format YYYY-MM-DD")
+	return time.Time{}
+}
+
+// clock parses HH:MM, HH:MM:SS or a fraction of a day of XLSX and returns HH:MM:SS.
+func (c *cells) clock(column string) string {
+	v := c.t.get(c.row, column)
+	if v == "" {
+		c.fail(column, "is required")
+		return ""
+	}
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.Format("15:04:05")
+		}
+	}
+	if day, err := strconv.ParseFloat(v, 64); err == nil && day >= 0 && day < 1 {
+		seconds := int(math.Round(day * 24 * 60 * 60))
+		return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	c.fail(column, "must be a time in format HH:MM")
+	return ""
+}
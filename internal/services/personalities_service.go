@@ -27,11 +27,7 @@ func (s *PersonalitiesService) SendAccessToAddInUniversity(ctx context.Context,
 		UserID:       userID,
 	}
 
-	err := s.PersonsRepo.RequestUniversityAccess(ctx, access)
-	if err != nil {
-		return err
-	}
-	return nil
+	return FromDB(s.PersonsRepo.RequestUniversityAccess(ctx, access))
 }
 
 func (s *PersonalitiesService) GetAccessRequest(ctx context.Context, userID, limit, offset int64) (*personalities.AccessRequestResponse, error) {
@@ -67,16 +63,11 @@ func (s *PersonalitiesService) GetAccessRequest(ctx context.Context, userID, lim
 }
 
 func (s *PersonalitiesService) AcceptAccess(ctx context.Context, request personalities.AcceptAccessRequest) error {
-	err := s.PersonsRepo.AddNewUser(ctx, request)
-	if err != nil {
-		return err
-	}
-	return nil
+	return FromDB(s.PersonsRepo.AddNewUser(ctx, request))
 }
 
 func (s *PersonalitiesService) RejectRequest(ctx context.Context, requestID int64) error {
-	err := s.PersonsRepo.DeleteRequest(ctx, requestID)
-	return err
+	return FromDB(s.PersonsRepo.DeleteRequest(ctx, requestID))
 }
 
 func (s *PersonalitiesService) GetAllUniversitiesForPerson(ctx context.Context, userID int64) ([]models.UniversitiesData, error) {
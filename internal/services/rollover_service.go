@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/rollover"
+	rollover2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/rollover"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// RolloverService moves universities to the next academic year: groups are promoted to the next year
+// of study, final year students are graduated and lessons of finished semesters are archived.
+type RolloverService struct {
+	repo repositories.RolloverRepository
+}
+
+func NewRolloverService(repo repositories.RolloverRepository) *RolloverService {
+	return &RolloverService{repo: repo}
+}
+
+// Preview reports what Rollover would do without changing anything.
+func (s *RolloverService) Preview(ctx context.Context, adminID int64, request rollover.RolloverRequest) (*rollover.ReportResponse, error) {
+	return s.rollover(ctx, adminID, request, true)
+}
+
+func (s *RolloverService) Rollover(ctx context.Context, adminID int64, request rollover.RolloverRequest) (*rollover.ReportResponse, error) {
+	return s.rollover(ctx, adminID, request, false)
+}
+
+func (s *RolloverService) rollover(ctx context.Context, adminID int64, request rollover.RolloverRequest, dryRun bool) (*rollover.ReportResponse, error) {
+	// формат дат уже проверен валидатором
+	startDate, _ := time.Parse("2006-01-02", request.StartDate)
+	endDate, _ := time.Parse("2006-01-02", request.EndDate)
+	if !endDate.After(startDate) {
+		return nil, Validation("end_date must be after start_date", FieldError{Field: "end_date", Code: "gtfield", Message: "must be after start_date"})
+	}
+
+	renames := make(map[int64]string, len(request.Renames))
+	for _, r := range request.Renames {
+		if _, ok := renames[r.GroupID]; ok {
+			return nil, Validation("group is renamed more than once", FieldError{Field: "renames", Code: "unique", Message: "must not contain duplicate group_id"})
+		}
+		renames[r.GroupID] = r.Name
+	}
+
+	report, err := s.repo.Rollover(ctx, adminID, rollover2.Params{
+		UniversityID: request.UniversityID,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Renames:      renames,
+	}, dryRun)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return reportResponse(report), nil
+}
+
+func reportResponse(report *rollover2.Report) *rollover.ReportResponse {
+	response := &rollover.ReportResponse{
+		ID:                report.ID,
+		DryRun:            report.DryRun,
+		UniversityID:      report.UniversityID,
+		StartDate:         report.StartDate.Format("2006-01-02"),
+		EndDate:           report.EndDate.Format("2006-01-02"),
+		Courses:           make([]rollover.CourseResponse, 0, len(report.Courses)),
+		Skipped:           make([]rollover.SkippedCourseResponse, 0, len(report.Skipped)),
+		PromotedGroups:    report.PromotedGroups,
+		GraduatedStudents: report.GraduatedStudents,
+		ArchivedLessons:   report.ArchivedLessons,
+	}
+
+	for _, c := range report.Courses {
+		groups := make([]rollover.GroupResponse, 0, len(c.Groups))
+		for _, g := range c.Groups {
+			groups = append(groups, rollover.GroupResponse{
+				ID:       g.ID,
+				Name:     g.Name,
+				NewName:  g.NewName,
+				Students: g.Students,
+			})
+		}
+		response.Courses = append(response.Courses, rollover.CourseResponse{
+			ID:                     c.ID,
+			UniversityDepartmentID: c.UniversityDepartmentID,
+			DepartmentName:         c.DepartmentName,
+			YearOfStudy:            c.YearOfStudy,
+			StudyYears:             c.StudyYears,
+			Action:                 string(c.Action),
+			NewCourseID:            c.NewCourseID,
+			NewCourseCreated:       c.NewCourseCreated,
+			Groups:                 groups,
+		})
+	}
+
+	for _, s := range report.Skipped {
+		response.Skipped = append(response.Skipped, rollover.SkippedCourseResponse{
+			CourseID:               s.CourseID,
+			UniversityDepartmentID: s.UniversityDepartmentID,
+			DepartmentName:         s.DepartmentName,
+		})
+	}
+	return response
+}
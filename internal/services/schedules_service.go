@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/schedules"
 	schedules2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/schedules"
@@ -24,11 +26,12 @@ func (s *SchedulesService) CreateClass(ctx context.Context, request schedules.Cr
 		EndTime:      request.EndTime,
 	}
 
-	return s.repo.CreateClass(ctx, class)
+	id, err := s.repo.CreateClass(ctx, class)
+	return id, FromDB(err)
 }
 
 func (s *SchedulesService) DeleteClass(ctx context.Context, classID int64) error {
-	return s.repo.DeleteClass(ctx, classID)
+	return FromDB(s.repo.DeleteClass(ctx, classID))
 }
 
 func (s *SchedulesService) GetClassesByUniversity(ctx context.Context, universityID int64) ([]schedules.ClassesResponse, error) {
@@ -55,11 +58,12 @@ func (s *SchedulesService) CreateRoom(ctx context.Context, request schedules.Cre
 		UniversityID: request.UniversityID,
 		Room:         request.Room,
 	}
-	return s.repo.CreateRoom(ctx, room)
+	id, err := s.repo.CreateRoom(ctx, room)
+	return id, FromDB(err)
 }
 
 func (s *SchedulesService) DeleteRoom(ctx context.Context, roomID int64) error {
-	return s.repo.DeleteRoom(ctx, roomID)
+	return FromDB(s.repo.DeleteRoom(ctx, roomID))
 }
 
 func (s *SchedulesService) GetRoomsByUniversity(ctx context.Context, universityID int64) ([]schedules.RoomsResponse, error) {
@@ -89,11 +93,12 @@ func (s *SchedulesService) CreateLesson(ctx context.Context, req schedules.Creat
 		RoomID:                 req.RoomID,
 		Interval:               schedules2.IntervalType(req.Interval),
 	}
-	return s.repo.CreateLesson(ctx, r)
+	id, err := s.repo.CreateLesson(ctx, r)
+	return id, FromDB(err)
 }
 
 func (s *SchedulesService) DeleteLesson(ctx context.Context, lessonID int64) error {
-	return s.repo.DeleteLesson(ctx, lessonID)
+	return FromDB(s.repo.DeleteLesson(ctx, lessonID))
 }
 
 func (s *SchedulesService) GetUserSchedule(ctx context.Context, userID int64) (schedules.LessonsResponse, error) {
@@ -123,3 +128,99 @@ func (s *SchedulesService) GetUserSchedule(ctx context.Context, userID int64) (s
 	}
 	return lessonResponse, nil
 }
+
+// TimetablePDF renders the printable week timetable of a course group, a teacher or a room of the admin's university.
+func (s *SchedulesService) TimetablePDF(ctx context.Context, adminID int64, request schedules.TimetableRequest) ([]byte, error) {
+	timetable, err := s.repo.GetTimetable(ctx, adminID, schedules2.TimetableKind(request.Kind), request.ID)
+	if err != nil {
+		return nil, FromDB(err)
+	}
+	return renderTimetable(timetable, time.Now())
+}
+
+// GetRoomOccupancy returns rooms of the university with their lessons and bookings by date and pair
+// in the week of the requested date. Only admins, teachers and active students of the university see it.
+func (s *SchedulesService) GetRoomOccupancy(ctx context.Context, userID int64, request schedules.RoomOccupancyRequest) (*schedules.RoomOccupancyResponse, error) {
+	member, err := s.repo.IsUniversityMember(ctx, userID, request.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		return nil, Forbidden(repositories.ErrNotMember.Error())
+	}
+
+	date := time.Now()
+	if d, err := parseDate("date", request.Date); err != nil {
+		return nil, err
+	} else if d != nil {
+		date = *d
+	}
+	// weeks start on monday
+	weekStart := time.Date(date.Year(), date.Month(), date.Day()-(int(date.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+
+	rooms, err := s.repo.GetRoomsByUniversity(ctx, request.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	occupancy, err := s.repo.GetRoomOccupancy(ctx, request.UniversityID, weekStart, request.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &schedules.RoomOccupancyResponse{
+		WeekStart: weekStart.Format(dateLayout),
+		WeekEnd:   weekStart.AddDate(0, 0, 6).Format(dateLayout),
+		Rooms:     []schedules.RoomOccupancy{},
+	}
+	for _, room := range rooms {
+		if request.RoomID != nil && room.ID != *request.RoomID {
+			continue
+		}
+		response.Rooms = append(response.Rooms, schedules.RoomOccupancy{RoomID: room.ID, Room: room.Room, Slots: []schedules.OccupiedSlot{}})
+	}
+	if request.RoomID != nil && len(response.Rooms) == 0 {
+		return nil, NotFound("room not found")
+	}
+	byID := make(map[int64]*schedules.RoomOccupancy, len(response.Rooms))
+	for i := range response.Rooms {
+		byID[response.Rooms[i].RoomID] = &response.Rooms[i]
+	}
+
+	// items come ordered by room, date and pair, so items of a slot are adjacent
+	for _, o := range occupancy {
+		room, ok := byID[o.RoomID]
+		if !ok {
+			continue
+		}
+		date := o.Date.Format(dateLayout)
+		if n := len(room.Slots); n == 0 || room.Slots[n-1].Date != date || room.Slots[n-1].ClassID != o.ClassID {
+			room.Slots = append(room.Slots, schedules.OccupiedSlot{
+				Date:       date,
+				Day:        strings.ToLower(o.Date.Weekday().String()),
+				PairNumber: o.PairNumber,
+				ClassID:    o.ClassID,
+				StartTime:  o.StartTime.Format("15:04"),
+				EndTime:    o.EndTime.Format("15:04"),
+			})
+		}
+		slot := &room.Slots[len(room.Slots)-1]
+
+		if o.LessonID != nil {
+			slot.Lessons = append(slot.Lessons, schedules.OccupancyLesson{
+				LessonID:    *o.LessonID,
+				Interval:    o.Interval,
+				Subject:     o.Subject,
+				SubjectType: o.SubjectType,
+				Group:       o.Group,
+			})
+		} else if o.BookingID != nil {
+			slot.Bookings = append(slot.Bookings, schedules.OccupancyBooking{
+				BookingID: *o.BookingID,
+				Title:     o.Title,
+				Purpose:   o.Purpose,
+				Status:    o.Status,
+			})
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/max-main-team/backend_hackaton_MAX/internal/models/http/schedules"
+	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
+)
+
+// outsiderSchedulesRepo knows no members, other methods panic as the embedded interface is nil.
+type outsiderSchedulesRepo struct {
+	repositories.SchedulesRepository
+}
+
+func (outsiderSchedulesRepo) IsUniversityMember(context.Context, int64, int64) (bool, error) {
+	return false, nil
+}
+
+func TestGetRoomOccupancyRejectsOutsiders(t *testing.T) {
+	s := NewSchedulesService(outsiderSchedulesRepo{})
+
+	_, err := s.GetRoomOccupancy(context.Background(), 1, schedules.RoomOccupancyRequest{UniversityID: 2})
+	var domainErr *Error
+	if !errors.As(err, &domainErr) || domainErr.Status != http.StatusForbidden {
+		t.Fatalf("GetRoomOccupancy = %v, want 403", err)
+	}
+}
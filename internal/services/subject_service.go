@@ -18,11 +18,7 @@ func NewSubjectService(subjectsRepo repositories.SubjectsRepository) *SubjectSer
 }
 
 func (s *SubjectService) Create(ctx context.Context, request subjects.CreateSubjectRequest) error {
-	err := s.subjectsRepo.Create(ctx, request.Name, request.UniversityID)
-	if err != nil {
-		return err
-	}
-	return nil
+	return FromDB(s.subjectsRepo.Create(ctx, request.Name, request.UniversityID))
 }
 
 func (s *SubjectService) Get(ctx context.Context, request subjects.GetSubjectsRequest, limit, offset int64) (*subjects.SubjectsResponse, error) {
@@ -56,6 +52,5 @@ func (s *SubjectService) Get(ctx context.Context, request subjects.GetSubjectsRe
 }
 
 func (s *SubjectService) Delete(ctx context.Context, subjectID int64) error {
-	err := s.subjectsRepo.Delete(ctx, subjectID)
-	return err
+	return FromDB(s.subjectsRepo.Delete(ctx, subjectID))
 }
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	schedules2 "github.com/max-main-team/backend_hackaton_MAX/internal/models/repository/schedules"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// page layout of printed timetables in mm, A4 landscape
+const (
+	pdfMargin     = 10.0
+	pdfPairWidth  = 24.0
+	pdfHeaderH    = 8.0
+	pdfMinRowH    = 16.0
+	pdfLineH      = 3.6
+	pdfCellMargin = 1.2
+	pdfFont       = "go"
+)
+
+var dayTitles = map[schedules2.DayType]string{
+	schedules2.Monday:    "Понедельник",
+	schedules2.Tuesday:   "Вторник",
+	schedules2.Wednesday: "Среда",
+	schedules2.Thursday:  "Четверг",
+	schedules2.Friday:    "Пятница",
+	schedules2.Saturday:  "Суббота",
+	schedules2.Sunday:    "Воскресенье",
+}
+
+var subjectTypeTitles = map[string]string{
+	"lecture":  "лекция",
+	"practice": "практика",
+	"labwork":  "лаб. работа",
+	"seminar":  "семинар",
+}
+
+// weekTitles label halves of cells with every two week lessons
+var weekTitles = map[int]string{1: "I неделя", 2: "II неделя"}
+
+// weekSplitNote is printed under grids with split cells: lessons have no stored parity, halves follow
+// the order lessons were created in, see SchedulesRepo.GetTimetable.
+const weekSplitNote = "Деление на I и II неделю условное: пары раз в две недели распределены по порядку их добавления " +
+	"в расписание, а не по календарю. Какая неделя сейчас идёт, уточняйте в деканате."
+
+// cellLesson is a lesson of a grid cell, the same lecture of several groups is one cell lesson.
+type cellLesson struct {
+	lesson schedules2.TimetableLesson
+	groups []string
+}
+
+// cellBlock is a part of a cell: every week lessons or lessons of odd or even weeks.
+type cellBlock struct {
+	week  int
+	lines []pdfLine
+}
+
+type pdfLine struct {
+	text  string
+	bold  bool
+	muted bool
+}
+
+// renderTimetable draws the week grid, days are columns and pairs of the bell schedule are rows.
+// Cells with every two week lessons are split into odd and even week halves.
+func renderTimetable(t *schedules2.Timetable, now time.Time) ([]byte, error) {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(pdfFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", gobold.TTF)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.SetCellMargin(pdfCellMargin)
+	pdf.SetTitle(timetableTitle(t), true)
+	pdf.SetCreator(t.University.Name, true)
+	pdf.SetCreationDate(now)
+	pdf.SetDrawColor(90, 90, 90)
+	pdf.AddPage()
+
+	drawBranding(pdf, t, now)
+
+	days := slices.Clone(schedules2.Days[:6])
+	for _, l := range t.Lessons {
+		if l.Day == schedules2.Sunday {
+			days = schedules2.Days
+			break
+		}
+	}
+	pageW, pageH := pdf.GetPageSize()
+	dayW := (pageW - 2*pdfMargin - pdfPairWidth) / float64(len(days))
+
+	drawDaysHeader(pdf, days, dayW)
+	for _, class := range t.Classes {
+		cells := make([][]cellBlock, len(days))
+		rowH := pdfMinRowH
+		for i, day := range days {
+			cells[i] = cellBlocks(pdf, t.Kind, lessonsAt(t.Lessons, day, class.PairNumber), dayW)
+			rowH = max(rowH, blocksHeight(cells[i]))
+		}
+
+		if pdf.GetY()+rowH > pageH-pdfMargin {
+			pdf.AddPage()
+			drawDaysHeader(pdf, days, dayW)
+		}
+
+		x, y := pdfMargin, pdf.GetY()
+		pdf.Rect(x, y, pdfPairWidth, rowH, "D")
+		pdf.SetXY(x, y+pdfCellMargin)
+		pdf.SetFont(pdfFont, "B", 9)
+		pdf.CellFormat(pdfPairWidth, pdfLineH+0.6, fmt.Sprintf("%d пара", class.PairNumber), "", 2, "C", false, 0, "")
+		pdf.SetFont(pdfFont, "", 8)
+		pdf.CellFormat(pdfPairWidth, pdfLineH, class.StartTime.Format("15:04")+"–"+class.EndTime.Format("15:04"), "", 2, "C", false, 0, "")
+
+		x += pdfPairWidth
+		for _, blocks := range cells {
+			drawCell(pdf, blocks, x, y, dayW, rowH)
+			x += dayW
+		}
+		pdf.SetXY(pdfMargin, y+rowH)
+	}
+	if slices.ContainsFunc(t.Lessons, func(l schedules2.TimetableLesson) bool { return l.Week != 0 }) {
+		drawWeekSplitNote(pdf, pageH)
+	}
+	if len(t.Classes) == 0 {
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.CellFormat(0, 10, "Расписание звонков университета не задано", "", 1, "C", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func timetableTitle(t *schedules2.Timetable) string {
+	switch t.Kind {
+	case schedules2.TeacherTimetable:
+		return "Расписание преподавателя " + t.Owner
+	case schedules2.RoomTimetable:
+		return "Расписание аудитории " + t.Owner
+	default:
+		return "Расписание группы " + t.Owner
+	}
+}
+
+// drawBranding draws the university, the owner of the timetable and the date it is printed at.
+func drawBranding(pdf *fpdf.Fpdf, t *schedules2.Timetable, now time.Time) {
+	u := t.University
+	name := u.Name
+	if u.ShortName != nil && *u.ShortName != "" && *u.ShortName != u.Name {
+		name += " (" + *u.ShortName + ")"
+	}
+	pdf.SetFont(pdfFont, "B", 14)
+	pdf.CellFormat(0, 7, pdfText(name), "", 1, "L", false, 0, "")
+
+	var contacts []string
+	for _, s := range []*string{u.City, u.SiteURL} {
+		if s != nil && *s != "" {
+			contacts = append(contacts, *s)
+		}
+	}
+	if len(contacts) > 0 {
+		pdf.SetFont(pdfFont, "", 9)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(0, 5, pdfText(strings.Join(contacts, " · ")), "", 1, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	y := pdf.GetY() + 2
+	pdf.SetXY(pdfMargin, y)
+	pdf.SetFont(pdfFont, "B", 12)
+	pdf.CellFormat(0, 6, pdfText(timetableTitle(t)), "", 1, "L", false, 0, "")
+
+	var details []string
+	if t.Department != nil {
+		details = append(details, *t.Department)
+	}
+	if t.YearOfStudy != nil {
+		details = append(details, fmt.Sprintf("%d курс", *t.YearOfStudy))
+	}
+	if len(details) > 0 {
+		pdf.SetFont(pdfFont, "", 10)
+		pdf.CellFormat(0, 5, pdfText(strings.Join(details, ", ")), "", 1, "L", false, 0, "")
+	}
+
+	after := pdf.GetY()
+	pdf.SetXY(pdfMargin, y)
+	pdf.SetFont(pdfFont, "", 8)
+	pdf.CellFormat(0, 6, "Сформировано "+now.Format("02.01.2006"), "", 0, "R", false, 0, "")
+	pdf.SetXY(pdfMargin, after+3)
+}
+
+func drawWeekSplitNote(pdf *fpdf.Fpdf, pageH float64) {
+	if pdf.GetY()+2+3*pdfLineH > pageH-pdfMargin {
+		pdf.AddPage()
+	}
+	pdf.SetXY(pdfMargin, pdf.GetY()+2)
+	pdf.SetFont(pdfFont, "", 8)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.MultiCell(0, pdfLineH, weekSplitNote, "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func drawDaysHeader(pdf *fpdf.Fpdf, days []schedules2.DayType, dayW float64) {
+	pdf.SetFont(pdfFont, "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(pdfPairWidth, pdfHeaderH, "Пара", "1", 0, "C", true, 0, "")
+	for _, day := range days {
+		pdf.CellFormat(dayW, pdfHeaderH, dayTitles[day], "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(pdfHeaderH)
+}
+
+// lessonsAt returns lessons of the cell, lessons differing only by group are merged.
+func lessonsAt(lessons []schedules2.TimetableLesson, day schedules2.DayType, pair int) []cellLesson {
+	var cell []cellLesson
+	for _, l := range lessons {
+		if l.Day != day || l.PairNumber != pair {
+			continue
+		}
+		i := slices.IndexFunc(cell, func(c cellLesson) bool {
+			o := c.lesson
+			return o.Week == l.Week && o.SubjectType == l.SubjectType && o.Room == l.Room &&
+				equalText(o.Subject, l.Subject) && equalText(o.Teacher, l.Teacher)
+		})
+		if i < 0 {
+			cell = append(cell, cellLesson{lesson: l})
+			i = len(cell) - 1
+		}
+		if l.Group != "" && !slices.Contains(cell[i].groups, l.Group) {
+			cell[i].groups = append(cell[i].groups, l.Group)
+		}
+	}
+	return cell
+}
+
+func equalText(a, b *string) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// cellBlocks lays out lessons of a cell into lines of the cell width. A cell with every two week lessons
+// always has both odd and even halves, so an empty half means no lesson in that week.
+func cellBlocks(pdf *fpdf.Fpdf, kind schedules2.TimetableKind, lessons []cellLesson, width float64) []cellBlock {
+	if len(lessons) == 0 {
+		return nil
+	}
+
+	var blocks []cellBlock
+	biweekly := slices.ContainsFunc(lessons, func(c cellLesson) bool { return c.lesson.Week != 0 })
+	for _, week := range []int{0, 1, 2} {
+		block := cellBlock{week: week}
+		if week != 0 && biweekly {
+			block.lines = append(block.lines, pdfLine{text: weekTitles[week], muted: true})
+		}
+		for _, c := range lessons {
+			if c.lesson.Week != week {
+				continue
+			}
+			pdf.SetFont(pdfFont, "B", 8)
+			for _, s := range pdf.SplitText(pdfText(lessonSubject(c.lesson)), width) {
+				block.lines = append(block.lines, pdfLine{text: s, bold: true})
+			}
+			pdf.SetFont(pdfFont, "", 8)
+			for _, s := range pdf.SplitText(pdfText(lessonDetails(kind, c)), width) {
+				block.lines = append(block.lines, pdfLine{text: s})
+			}
+		}
+		if week == 0 && len(block.lines) == 0 || week != 0 && !biweekly {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// pdfText replaces characters out of the basic multilingual plane, such as emoji, fonts of the PDF
+// have no widths for them.
+func pdfText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+func lessonSubject(l schedules2.TimetableLesson) string {
+	if l.Subject == nil {
+		return "—"
+	}
+	return *l.Subject
+}
+
+// lessonDetails lists the type and who and where the lesson is, without the owner of the timetable.
+func lessonDetails(kind schedules2.TimetableKind, c cellLesson) string {
+	details := []string{subjectTypeTitles[c.lesson.SubjectType]}
+	if kind != schedules2.GroupTimetable && len(c.groups) > 0 {
+		details = append(details, strings.Join(c.groups, ", "))
+	}
+	if kind != schedules2.TeacherTimetable && c.lesson.Teacher != nil {
+		details = append(details, *c.lesson.Teacher)
+	}
+	if kind != schedules2.RoomTimetable {
+		details = append(details, "ауд. "+c.lesson.Room)
+	}
+	return strings.Join(details, ", ")
+}
+
+func blocksHeight(blocks []cellBlock) float64 {
+	h := 0.0
+	for _, b := range blocks {
+		h += blockHeight(b)
+	}
+	return h
+}
+
+func blockHeight(b cellBlock) float64 {
+	return float64(max(len(b.lines), 1))*pdfLineH + 2*pdfCellMargin
+}
+
+// drawCell draws blocks of the cell stretched to the row, every two week halves share the height left
+// by every week lessons and are separated by a dashed line.
+func drawCell(pdf *fpdf.Fpdf, blocks []cellBlock, x, y, w, h float64) {
+	pdf.Rect(x, y, w, h, "D")
+
+	spare := h - blocksHeight(blocks)
+	halves := 0
+	for _, b := range blocks {
+		if b.week != 0 {
+			halves++
+		}
+	}
+
+	top := y
+	for i, b := range blocks {
+		bh := blockHeight(b)
+		if b.week != 0 {
+			bh += spare / float64(halves)
+		}
+		if i > 0 {
+			pdf.SetDashPattern([]float64{0.8, 0.8}, 0)
+			pdf.Line(x, top, x+w, top)
+			pdf.SetDashPattern(nil, 0)
+		}
+
+		pdf.SetXY(x, top+pdfCellMargin)
+		for _, line := range b.lines {
+			style := ""
+			if line.bold {
+				style = "B"
+			}
+			pdf.SetFont(pdfFont, style, 8)
+			if line.muted {
+				pdf.SetTextColor(110, 110, 110)
+			}
+			pdf.CellFormat(w, pdfLineH, line.text, "", 2, "L", false, 0, "")
+			pdf.SetTextColor(0, 0, 0)
+		}
+		top += bh
+	}
+}
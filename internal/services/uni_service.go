@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
@@ -41,12 +42,12 @@ func (u *UniService) SetNewSemesterPeriod(ctx context.Context, uniID int64, peri
 
 	err := ValidateSemesters(periods)
 	if err != nil {
-		return fmt.Errorf("failed create semesters. Invalid semesters periods. err :%w", err)
+		return &Error{Status: http.StatusBadRequest, Code: CodeValidation, Message: "invalid semesters periods: " + err.Error(), Err: err}
 	}
 
 	err = u.uniRepo.CreateSemestersForUniversity(ctx, uniID, periods)
 	if err != nil {
-		return fmt.Errorf("failed create semesters. err :%w", err)
+		return FromDB(err)
 	}
 
 	return nil
@@ -84,40 +85,40 @@ func ValidateSemesters(periods []models.SemesterPeriod) error {
 
 func (u *UniService) CreateNewDepartment(ctx context.Context, departmentName, departmentCode, aliasName string, facultyID, universityID int64) error {
 	if departmentName == "" {
-		return fmt.Errorf("department name cannot be empty")
+		return Validation("department name cannot be empty", FieldError{Field: "department_name", Code: "required", Message: "department name cannot be empty"})
 	}
 	if facultyID <= 0 {
-		return fmt.Errorf("invalid faculty ID")
+		return Validation("invalid faculty ID", FieldError{Field: "faculty_id", Code: "gt", Message: "invalid faculty ID"})
 	}
 	if universityID <= 0 {
-		return fmt.Errorf("invalid university ID")
+		return Validation("invalid university ID", FieldError{Field: "university_id", Code: "gt", Message: "invalid university ID"})
 	}
 
 	err := u.uniRepo.CreateNewDepartment(ctx, departmentName, departmentCode, aliasName, facultyID, universityID)
 	if err != nil {
-		return fmt.Errorf("failed to create department: %w", err)
+		return FromDB(err)
 	}
 
 	return nil
 }
 
-func (u *UniService) CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64) error {
+func (u *UniService) CreateNewCourse(ctx context.Context, startDate, endDate time.Time, universityDepartmentID int64, yearOfStudy *int) error {
 	if startDate.IsZero() {
-		return fmt.Errorf("start date cannot be empty")
+		return Validation("start date cannot be empty", FieldError{Field: "start_date", Code: "required", Message: "start date cannot be empty"})
 	}
 	if endDate.IsZero() {
-		return fmt.Errorf("end date cannot be empty")
+		return Validation("end date cannot be empty", FieldError{Field: "end_date", Code: "required", Message: "end date cannot be empty"})
 	}
 	if endDate.Before(startDate) {
-		return fmt.Errorf("end date must be after start date")
+		return Validation("end date must be after start date", FieldError{Field: "end_date", Code: "gtfield", Message: "end date must be after start date"})
 	}
 	if universityDepartmentID <= 0 {
-		return fmt.Errorf("invalid university department ID")
+		return Validation("invalid university department ID", FieldError{Field: "university_department_id", Code: "gt", Message: "invalid university department ID"})
 	}
 
-	err := u.uniRepo.CreateNewCourse(ctx, startDate, endDate, universityDepartmentID)
+	err := u.uniRepo.CreateNewCourse(ctx, startDate, endDate, universityDepartmentID, yearOfStudy)
 	if err != nil {
-		return fmt.Errorf("failed to create course: %w", err)
+		return FromDB(err)
 	}
 
 	return nil
@@ -125,7 +126,7 @@ func (u *UniService) CreateNewCourse(ctx context.Context, startDate, endDate tim
 
 func (u *UniService) GetAllCoursesByUniversityID(ctx context.Context, universityID int64) ([]models.Course, error) {
 	if universityID <= 0 {
-		return nil, fmt.Errorf("invalid university ID")
+		return nil, Validation("invalid university ID", FieldError{Field: "university_id", Code: "gt", Message: "invalid university ID"})
 	}
 
 	courses, err := u.uniRepo.GetAllCoursesByUniversityID(ctx, universityID)
@@ -138,15 +139,15 @@ func (u *UniService) GetAllCoursesByUniversityID(ctx context.Context, university
 
 func (u *UniService) CreateNewGroup(ctx context.Context, groupName string, courseID int64) error {
 	if groupName == "" {
-		return fmt.Errorf("group name cannot be empty")
+		return Validation("group name cannot be empty", FieldError{Field: "group_name", Code: "required", Message: "group name cannot be empty"})
 	}
 	if courseID <= 0 {
-		return fmt.Errorf("invalid course ID")
+		return Validation("invalid course ID", FieldError{Field: "course_id", Code: "gt", Message: "invalid course ID"})
 	}
 
 	err := u.uniRepo.CreateNewGroup(ctx, groupName, courseID)
 	if err != nil {
-		return fmt.Errorf("failed to create group: %w", err)
+		return FromDB(err)
 	}
 
 	return nil
@@ -154,21 +155,21 @@ func (u *UniService) CreateNewGroup(ctx context.Context, groupName string, cours
 
 func (u *UniService) CreateNewEvent(ctx context.Context, event models.Event) error {
 	if event.Title == "" {
-		return fmt.Errorf("event title cannot be empty")
+		return Validation("event title cannot be empty", FieldError{Field: "title", Code: "required", Message: "event title cannot be empty"})
 	}
 	if event.Description == "" {
-		return fmt.Errorf("event description cannot be empty")
+		return Validation("event description cannot be empty", FieldError{Field: "description", Code: "required", Message: "event description cannot be empty"})
 	}
 	if event.PhotoUrl == "" {
-		return fmt.Errorf("event photo URL cannot be empty")
+		return Validation("event photo URL cannot be empty", FieldError{Field: "photo_url", Code: "required", Message: "event photo URL cannot be empty"})
 	}
 	if event.UniversityID <= 0 {
-		return fmt.Errorf("invalid university ID")
+		return Validation("invalid university ID", FieldError{Field: "university_id", Code: "gt", Message: "invalid university ID"})
 	}
 
 	err := u.uniRepo.CreateNewEvent(ctx, event)
 	if err != nil {
-		return fmt.Errorf("failed to create event: %w", err)
+		return FromDB(err)
 	}
 
 	return nil
@@ -176,7 +177,7 @@ func (u *UniService) CreateNewEvent(ctx context.Context, event models.Event) err
 
 func (u *UniService) GetAllEventsByUniversityID(ctx context.Context, universityID int64) ([]models.Event, error) {
 	if universityID <= 0 {
-		return nil, fmt.Errorf("invalid university ID")
+		return nil, Validation("invalid university ID", FieldError{Field: "university_id", Code: "gt", Message: "invalid university ID"})
 	}
 
 	events, err := u.uniRepo.GetAllEventsByUniversityID(ctx, universityID)
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/models"
 	"github.com/max-main-team/backend_hackaton_MAX/internal/repositories"
 )
@@ -16,7 +18,13 @@ func NewUserService(repo repositories.UserRepository) *UserService {
 }
 
 func (u *UserService) GetUser(ctx context.Context, id int64) (*models.User, error) {
-	return u.userRepo.GetUserByID(ctx, id)
+	user, err := u.userRepo.GetUserByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		e := NotFound("user not found")
+		e.Err = err
+		return nil, e
+	}
+	return user, err
 }
 
 func (u *UserService) GetUserRolesByID(ctx context.Context, id int64) (*models.UserRoles, error) {